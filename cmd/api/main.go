@@ -1,23 +1,105 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	_ "time/tzdata"
 
+	"github.com/itsaFan/fleetify-be/internal/cache"
 	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	apihttp "github.com/itsaFan/fleetify-be/internal/http"
+	"github.com/itsaFan/fleetify-be/internal/jobs"
+	"github.com/itsaFan/fleetify-be/internal/notify"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	jobrepo "github.com/itsaFan/fleetify-be/internal/repo/job"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	notifrepo "github.com/itsaFan/fleetify-be/internal/repo/notification"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+	notifsvc "github.com/itsaFan/fleetify-be/internal/service/notification"
+	reportsvc "github.com/itsaFan/fleetify-be/internal/service/report"
+	retentionsvc "github.com/itsaFan/fleetify-be/internal/service/retention"
+	webhooksvc "github.com/itsaFan/fleetify-be/internal/service/webhook"
 )
 
 func main() {
 	config.LoadEnv()
+	if err := config.LoadAppTimezone(); err != nil {
+		log.Fatal(err)
+	}
 	db, err := config.DBConnection()
 	if err != nil {
 		log.Fatalf("failed to connect DB: %v", err)
 	}
 
-	router := apihttp.NewRouter(db)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	bus := event.NewBus()
+
+	// one store for the jobs and the API, so writes through either reach both
+	cacheCfg := config.LoadCacheConfig()
+	var store cache.Store
+	if cacheCfg.Enabled {
+		store = cache.NewLRU(cacheCfg.Size)
+	}
+
+	atdRepo := atdrepo.New(db)
+	empRepo := emprepo.NewCached(emprepo.New(db), store, cacheCfg.TTL)
+	atdSvc := atdsvc.New(atdRepo, empRepo, holidayrepo.New(db), leaverepo.New(db), bus)
+
+	webhookRepo, notifRepo, jobRepo := webhookrepo.New(db), notifrepo.New(db), jobrepo.New(db)
+
+	// the dispatcher polls every few seconds, too often for a cron job;
+	// SKIP LOCKED claims keep replicas apart
+	webhookCfg := config.LoadWebhookConfig()
+	go jobs.RunWebhookDispatcher(ctx, webhooksvc.New(webhookRepo, webhookCfg), webhookCfg)
+
+	notifyCfg := config.LoadNotifyConfig()
+	notifSvc := notifsvc.New(notifRepo, atdSvc, atdRepo, empRepo, notify.NewMailer(config.LoadSMTPConfig()), notifyCfg)
+	retentionSvc := retentionsvc.New(outboxrepo.New(db), webhookRepo, notifRepo, jobRepo)
+	reportSvc := reportsvc.New(atdSvc, empRepo, deptrepo.NewCached(deptrepo.New(db), store, cacheCfg.TTL))
+
+	sched := scheduler.New(jobRepo, config.LoadSchedulerConfig())
+	autoCloseCfg, retentionCfg, reportCfg := config.LoadAutoCloseConfig(), config.LoadRetentionConfig(), config.LoadReportConfig()
+	dailyCfg := config.LoadDailyRebuildConfig()
+	for _, j := range []struct {
+		enabled bool
+		job     scheduler.Job
+	}{
+		{autoCloseCfg.Enabled, jobs.AutoClose(atdSvc, autoCloseCfg)},
+		{notifyCfg.Enabled, jobs.DailyDigest(notifSvc, notifyCfg)},
+		{notifyCfg.Enabled, jobs.ClockOutReminders(notifSvc, notifyCfg)},
+		{retentionCfg.Enabled, jobs.Retention(retentionSvc, retentionCfg)},
+		{reportCfg.Enabled, jobs.MonthlyReports(reportSvc, reportCfg)},
+		{dailyCfg.Enabled, jobs.RebuildDaily(atdSvc, dailyCfg)},
+	} {
+		if !j.enabled {
+			log.Printf("job %s disabled", j.job.Name)
+			continue
+		}
+		if err := sched.Register(j.job); err != nil {
+			log.Fatal(err)
+		}
+	}
+	schedDone := make(chan struct{})
+	go func() {
+		defer close(schedDone)
+		sched.Run(ctx)
+	}()
+
+	router := apihttp.NewRouter(db, bus, sched, store, atdSvc)
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -26,8 +108,18 @@ func main() {
 		Addr:    ":" + port,
 		Handler: router,
 	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
 	log.Println("listening on port", port)
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatal(err)
 	}
+	// let running jobs record their outcome
+	<-schedDone
 }
@@ -0,0 +1,56 @@
+// Command rebuild-daily recomputes the attendance_daily rows of a date range
+// from the raw attendance history, e.g. after the day rules changed:
+//
+//	go run ./cmd/rebuild-daily -from 2025-01-01 -to 2025-09-30 [-department 3]
+//
+// Dates are calendar dates in APP_TZ.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	_ "time/tzdata"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+func main() {
+	from := flag.String("from", "", "first day, YYYY-MM-DD")
+	to := flag.String("to", "", "last day, YYYY-MM-DD")
+	department := flag.Uint64("department", 0, "only this department id")
+	flag.Parse()
+
+	config.LoadEnv()
+	if err := config.LoadAppTimezone(); err != nil {
+		log.Fatal(err)
+	}
+	db, err := config.DBConnection()
+	if err != nil {
+		log.Fatalf("failed to connect DB: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	svc := atdsvc.New(atdrepo.New(db), emprepo.New(db), holidayrepo.New(db), leaverepo.New(db), event.NewBus())
+
+	in := atdsvc.RebuildDailyInput{FromLocal: *from, ToLocal: *to}
+	if *department != 0 {
+		in.DepartmentID = department
+	}
+	out, err := svc.RebuildDaily(ctx, in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("rebuilt %s to %s (%s): %d employees, %d days", out.From, out.To, out.TZUsed, out.Employees, out.Days)
+}
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE departments
+  ADD COLUMN working_days VARCHAR(20) NOT NULL DEFAULT '1,2,3,4,5' COMMENT 'ISO weekdays, 1=Mon..7=Sun'
+  AFTER max_clock_out_time;
+
+-- +goose Down
+ALTER TABLE departments DROP COLUMN working_days;
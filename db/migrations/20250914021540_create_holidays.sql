@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE holidays (
+  id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  holiday_date   DATE            NOT NULL,
+  name           VARCHAR(255)    NOT NULL,
+  kind           VARCHAR(20)     NOT NULL DEFAULT 'public' COMMENT 'public | closure',
+  department_id  BIGINT UNSIGNED NULL COMMENT 'NULL = company wide',
+  source         VARCHAR(20)     NOT NULL DEFAULT 'manual' COMMENT 'manual | ics',
+  external_uid   VARCHAR(255)    NULL,
+  department_key BIGINT UNSIGNED AS (COALESCE(department_id, 0)) VIRTUAL COMMENT '0 = company wide, NULLs would not collide in the unique key',
+  created_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_holidays_date_department (holiday_date, department_key),
+  KEY ix_holidays_date (holiday_date),
+  KEY ix_holidays_department_date (department_id, holiday_date),
+  CONSTRAINT fk_holidays_department
+    FOREIGN KEY (department_id) REFERENCES departments(id)
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS holidays;
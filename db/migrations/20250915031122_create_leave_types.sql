@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE leave_types (
+  id            BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  code          VARCHAR(50)     NOT NULL,
+  name          VARCHAR(255)    NOT NULL,
+  yearly_quota  INT UNSIGNED    NOT NULL DEFAULT 0 COMMENT 'days per year, 0 = no quota',
+  created_at    DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at    DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_leave_types_code (code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO leave_types (code, name, yearly_quota) VALUES
+  ('annual', 'Cuti Tahunan', 12),
+  ('sick',   'Sakit', 0),
+  ('permit', 'Izin', 0);
+
+-- +goose Down
+DROP TABLE IF EXISTS leave_types;
@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE leave_requests (
+  id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  request_id     VARCHAR(100)    NOT NULL,
+  employee_id    VARCHAR(50)     NOT NULL COLLATE utf8mb4_unicode_ci,
+  leave_type_id  BIGINT UNSIGNED NOT NULL,
+  start_date     DATE            NOT NULL,
+  end_date       DATE            NOT NULL,
+  days           INT UNSIGNED    NOT NULL COMMENT 'working days covered',
+  reason         TEXT            NULL,
+  status         VARCHAR(20)     NOT NULL DEFAULT 'pending' COMMENT 'pending | approved | rejected',
+  approver_id    VARCHAR(50)     NULL COLLATE utf8mb4_unicode_ci,
+  decision_note  TEXT            NULL,
+  decided_at     DATETIME        NULL,
+  created_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_leave_requests_request_id (request_id),
+  KEY ix_leave_requests_employee_dates (employee_id, start_date, end_date),
+  KEY ix_leave_requests_status_dates (status, start_date),
+  CONSTRAINT ck_leave_requests_one_year
+    CHECK (YEAR(start_date) = YEAR(end_date) AND start_date <= end_date),
+  CONSTRAINT fk_leave_requests_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(employee_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_leave_requests_type
+    FOREIGN KEY (leave_type_id) REFERENCES leave_types(id)
+    ON UPDATE RESTRICT ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS leave_requests;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE attendance_histories
+  ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'terminal' COMMENT 'terminal | manual'
+  AFTER attendance_type;
+
+-- +goose Down
+ALTER TABLE attendance_histories DROP COLUMN source;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE attendance_corrections (
+  id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  correction_id   VARCHAR(100)    NOT NULL,
+  employee_id     VARCHAR(50)     NOT NULL COLLATE utf8mb4_unicode_ci,
+  date_local      DATE            NOT NULL,
+  tz              VARCHAR(64)     NOT NULL DEFAULT 'UTC',
+  clock_in        DATETIME        NULL COMMENT 'proposed, UTC',
+  clock_out       DATETIME        NULL COMMENT 'proposed, UTC',
+  reason          TEXT            NOT NULL,
+  status          VARCHAR(20)     NOT NULL DEFAULT 'pending' COMMENT 'pending | approved | rejected',
+  approver_id     VARCHAR(50)     NULL COLLATE utf8mb4_unicode_ci,
+  decision_note   TEXT            NULL,
+  decided_at      DATETIME        NULL,
+  attendance_id   VARCHAR(100)    NULL COLLATE utf8mb4_unicode_ci COMMENT 'attendance written on approval',
+  prev_clock_in   DATETIME        NULL COMMENT 'attendance values before approval',
+  prev_clock_out  DATETIME        NULL,
+  created_at      DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at      DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_attendance_corrections_correction_id (correction_id),
+  KEY ix_attendance_corrections_employee_date (employee_id, date_local),
+  KEY ix_attendance_corrections_status (status),
+  CONSTRAINT fk_attendance_corrections_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(employee_id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS attendance_corrections;
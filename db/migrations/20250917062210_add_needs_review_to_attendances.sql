@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE attendances
+  ADD COLUMN needs_review TINYINT(1) NOT NULL DEFAULT 0 COMMENT '1 = auto-closed, waiting for a correction or review'
+  AFTER clock_out,
+  ADD KEY idx_attendances_open (clock_out, clock_in),
+  ADD KEY idx_attendances_needs_review (needs_review);
+
+ALTER TABLE attendance_histories
+  MODIFY COLUMN source VARCHAR(20) NOT NULL DEFAULT 'terminal' COMMENT 'terminal | manual | auto_closed';
+
+-- +goose Down
+ALTER TABLE attendance_histories
+  MODIFY COLUMN source VARCHAR(20) NOT NULL DEFAULT 'terminal' COMMENT 'terminal | manual';
+
+ALTER TABLE attendances
+  DROP KEY idx_attendances_needs_review,
+  DROP KEY idx_attendances_open,
+  DROP COLUMN needs_review;
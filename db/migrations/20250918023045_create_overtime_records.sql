@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE overtime_records (
+  id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  overtime_id    VARCHAR(100)    NOT NULL,
+  employee_id    VARCHAR(50)     NOT NULL COLLATE utf8mb4_unicode_ci,
+  attendance_id  VARCHAR(100)    NOT NULL,
+  work_date      DATE            NOT NULL COMMENT 'local date of the clock in',
+  day_kind       VARCHAR(20)     NOT NULL COMMENT 'workday | rest_day | holiday',
+  raw_minutes    INT UNSIGNED    NOT NULL COMMENT 'overtime before caps',
+  minutes        INT UNSIGNED    NOT NULL COMMENT 'overtime after daily and weekly caps',
+  multiplier     DECIMAL(4,2)    NOT NULL,
+  status         VARCHAR(20)     NOT NULL DEFAULT 'pending' COMMENT 'pending | approved | rejected',
+  approver_id    VARCHAR(50)     NULL COLLATE utf8mb4_unicode_ci,
+  decision_note  TEXT            NULL,
+  decided_at     DATETIME        NULL,
+  created_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_overtime_records_overtime_id (overtime_id),
+  UNIQUE KEY ux_overtime_records_attendance_id (attendance_id),
+  KEY ix_overtime_records_employee_date (employee_id, work_date),
+  KEY ix_overtime_records_status_date (status, work_date),
+  CONSTRAINT fk_overtime_records_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(employee_id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS overtime_records;
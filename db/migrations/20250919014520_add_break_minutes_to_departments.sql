@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE departments
+  ADD COLUMN break_minutes SMALLINT UNSIGNED NOT NULL DEFAULT 60 COMMENT 'unpaid break deducted from worked time per day'
+  AFTER working_days;
+
+-- +goose Down
+ALTER TABLE departments DROP COLUMN break_minutes;
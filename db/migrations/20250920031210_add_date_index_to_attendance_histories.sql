@@ -0,0 +1,8 @@
+-- +goose Up
+-- range scans by date for the department summary
+ALTER TABLE attendance_histories
+  ADD KEY idx_histories_date_employee (date_attendance, employee_id);
+
+-- +goose Down
+ALTER TABLE attendance_histories
+  DROP KEY idx_histories_date_employee;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE outbox_events (
+  id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  event_id       VARCHAR(100)    NOT NULL,
+  event_type     VARCHAR(100)    NOT NULL,
+  payload        JSON            NOT NULL,
+  created_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  dispatched_at  DATETIME        NULL COMMENT 'set once fanned out to webhook deliveries',
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_outbox_events_event_id (event_id),
+  KEY ix_outbox_events_pending (dispatched_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
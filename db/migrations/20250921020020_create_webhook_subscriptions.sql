@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE webhook_subscriptions (
+  id               BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  subscription_id  VARCHAR(100)    NOT NULL,
+  url              VARCHAR(2048)   NOT NULL,
+  secret           VARCHAR(255)    NOT NULL COMMENT 'HMAC-SHA256 signing key',
+  event_types      VARCHAR(1000)   NOT NULL COMMENT 'comma separated, * = all',
+  description      VARCHAR(255)    NULL,
+  active           TINYINT(1)      NOT NULL DEFAULT 1,
+  created_at       DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_webhook_subscriptions_subscription_id (subscription_id),
+  KEY ix_webhook_subscriptions_active (active)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE webhook_deliveries (
+  id                BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  delivery_id       VARCHAR(100)    NOT NULL,
+  subscription_id   VARCHAR(100)    NOT NULL,
+  event_id          VARCHAR(100)    NOT NULL,
+  event_type        VARCHAR(100)    NOT NULL,
+  payload           JSON            NOT NULL,
+  status            VARCHAR(20)     NOT NULL DEFAULT 'pending' COMMENT 'pending | succeeded | failed',
+  attempts          INT UNSIGNED    NOT NULL DEFAULT 0,
+  next_attempt_at   DATETIME        NOT NULL,
+  last_status_code  INT             NULL,
+  last_error        TEXT            NULL,
+  delivered_at      DATETIME        NULL,
+  replay_of         VARCHAR(100)    NULL COMMENT 'delivery_id this one replays',
+  created_at        DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at        DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_webhook_deliveries_delivery_id (delivery_id),
+  KEY ix_webhook_deliveries_due (status, next_attempt_at),
+  KEY ix_webhook_deliveries_subscription (subscription_id, id),
+  CONSTRAINT fk_webhook_deliveries_subscription
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(subscription_id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_deliveries;
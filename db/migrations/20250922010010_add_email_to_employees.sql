@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE employees
+  ADD COLUMN email VARCHAR(255) NULL AFTER name,
+  ADD UNIQUE KEY ux_employees_email (email);
+
+-- +goose Down
+ALTER TABLE employees
+  DROP INDEX ux_employees_email,
+  DROP COLUMN email;
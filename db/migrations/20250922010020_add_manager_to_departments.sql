@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE departments
+  ADD COLUMN manager_employee_id VARCHAR(50) NULL COMMENT 'receives the daily attendance digest'
+  AFTER break_minutes,
+  ADD KEY ix_departments_manager_employee_id (manager_employee_id),
+  ADD CONSTRAINT fk_departments_manager
+    FOREIGN KEY (manager_employee_id) REFERENCES employees(employee_id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE departments
+  DROP FOREIGN KEY fk_departments_manager,
+  DROP INDEX ix_departments_manager_employee_id,
+  DROP COLUMN manager_employee_id;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE notification_preferences (
+  id                  BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  employee_id         VARCHAR(50)     NOT NULL,
+  daily_digest        TINYINT(1)      NOT NULL DEFAULT 1 COMMENT 'department managers only',
+  clock_out_reminder  TINYINT(1)      NOT NULL DEFAULT 1,
+  created_at          DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at          DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_notification_preferences_employee_id (employee_id),
+  CONSTRAINT fk_notification_preferences_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(employee_id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- One row per notification sent; the unique key stops a digest or reminder
+-- from going out twice, also across restarts.
+CREATE TABLE notification_logs (
+  id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  kind        VARCHAR(50)     NOT NULL COMMENT 'daily_digest | clock_out_reminder',
+  ref_key     VARCHAR(150)    NOT NULL COMMENT 'department+date or attendance_id',
+  recipient   VARCHAR(255)    NOT NULL,
+  sent_at     DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_notification_logs_kind_ref (kind, ref_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_logs;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- One row per job known to the scheduler. A replica runs a job only after it
+-- takes the lease (lease_owner/lease_until), so each run happens once across
+-- all replicas.
+CREATE TABLE scheduled_jobs (
+  id                 BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  name               VARCHAR(100)    NOT NULL,
+  schedule           VARCHAR(150)    NOT NULL COMMENT 'cron expression',
+  timezone           VARCHAR(64)     NOT NULL,
+  description        VARCHAR(255)    NULL,
+  paused             TINYINT(1)      NOT NULL DEFAULT 0,
+  trigger_requested  TINYINT(1)      NOT NULL DEFAULT 0 COMMENT 'run on the next tick regardless of schedule',
+  next_run_at        DATETIME        NULL,
+  last_run_at        DATETIME        NULL,
+  last_status        VARCHAR(20)     NULL,
+  lease_owner        VARCHAR(150)    NULL,
+  lease_until        DATETIME        NULL,
+  created_at         DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at         DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_scheduled_jobs_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS scheduled_jobs;
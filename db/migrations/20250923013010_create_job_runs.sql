@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE job_runs (
+  id           BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  run_id       VARCHAR(100)    NOT NULL,
+  job_name     VARCHAR(100)    NOT NULL,
+  `trigger`    VARCHAR(20)     NOT NULL COMMENT 'schedule | manual',
+  owner        VARCHAR(150)    NOT NULL COMMENT 'replica that ran it',
+  status       VARCHAR(20)     NOT NULL COMMENT 'running | succeeded | failed | abandoned',
+  started_at   DATETIME(3)     NOT NULL,
+  finished_at  DATETIME(3)     NULL,
+  duration_ms  BIGINT          NULL,
+  output       TEXT            NULL,
+  error        TEXT            NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_job_runs_run_id (run_id),
+  KEY ix_job_runs_job_started (job_name, started_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS job_runs;
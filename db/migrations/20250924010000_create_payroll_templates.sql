@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE payroll_templates (
+  id                 BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  name               VARCHAR(100)    NOT NULL,
+  description        VARCHAR(255)    NULL,
+  delimiter          VARCHAR(1)      NOT NULL DEFAULT ',' COMMENT 'empty = fixed width columns',
+  include_header     TINYINT(1)      NOT NULL DEFAULT 1,
+  date_format        VARCHAR(20)     NOT NULL DEFAULT 'YYYY-MM-DD',
+  decimal_separator  VARCHAR(1)      NOT NULL DEFAULT '.',
+  use_crlf           TINYINT(1)      NOT NULL DEFAULT 1,
+  columns            JSON            NOT NULL COMMENT 'ordered column mapping',
+  created_at         DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at         DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_payroll_templates_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO payroll_templates (name, description, columns) VALUES (
+  'default',
+  'One row per employee with every payroll figure',
+  '[{"header":"employee_id","field":"employee_id"},{"header":"employee_name","field":"employee_name"},{"header":"department","field":"department_name"},{"header":"period_from","field":"period_from"},{"header":"period_to","field":"period_to"},{"header":"worked_days","field":"worked_days"},{"header":"worked_hours","field":"worked_hours"},{"header":"late_count","field":"late_count"},{"header":"late_minutes","field":"late_minutes"},{"header":"overtime_hours","field":"overtime_hours"},{"header":"leave_days","field":"leave_days"},{"header":"absent_days","field":"absent_days"}]'
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS payroll_templates;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE attendance_daily (
+  id                 BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  employee_id        VARCHAR(50)     NOT NULL COLLATE utf8mb4_unicode_ci,
+  date_local         DATE            NOT NULL COMMENT 'calendar date in APP_TZ',
+  attendance_id      VARCHAR(100)    NULL COLLATE utf8mb4_unicode_ci COMMENT 'attendance of the picked clock in',
+  first_in           DATETIME        NULL,
+  last_out           DATETIME        NULL,
+  status_in          VARCHAR(20)     NOT NULL,
+  status_out         VARCHAR(20)     NOT NULL,
+  delta_in_minutes   INT             NULL,
+  delta_out_minutes  INT             NULL,
+  worked_minutes     INT             NOT NULL DEFAULT 0,
+  holiday            VARCHAR(255)    NULL,
+  corrected          TINYINT(1)      NOT NULL DEFAULT 0,
+  needs_review       TINYINT(1)      NOT NULL DEFAULT 0,
+  rule_version       INT             NOT NULL,
+  created_at         DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at         DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_attendance_daily_employee_date (employee_id, date_local),
+  KEY ix_attendance_daily_date (date_local),
+  CONSTRAINT fk_attendance_daily_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(employee_id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS attendance_daily;
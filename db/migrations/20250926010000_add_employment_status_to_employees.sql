@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE employees
+  ADD COLUMN employment_status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT 'active | suspended | terminated'
+  AFTER address,
+  ADD KEY ix_employees_employment_status (employment_status),
+  ADD KEY ix_employees_created_at (created_at),
+  ADD KEY ix_employees_updated_at (updated_at);
+
+ALTER TABLE attendances
+  ADD KEY idx_attendances_employee_open (employee_id, clock_out);
+
+-- +goose Down
+ALTER TABLE attendances
+  DROP KEY idx_attendances_employee_open;
+
+ALTER TABLE employees
+  DROP KEY ix_employees_updated_at,
+  DROP KEY ix_employees_created_at,
+  DROP KEY ix_employees_employment_status,
+  DROP COLUMN employment_status;
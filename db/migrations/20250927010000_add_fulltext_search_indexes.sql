@@ -0,0 +1,15 @@
+-- +goose Up
+-- ngram splits words into 2 character tokens (ngram_token_size), so partial
+-- words match and the unicode_ci collation makes matching accent insensitive
+ALTER TABLE employees
+  ADD FULLTEXT KEY ft_employees_search (name, address, employee_id) WITH PARSER ngram;
+
+ALTER TABLE departments
+  ADD FULLTEXT KEY ft_departments_search (department_name) WITH PARSER ngram;
+
+-- +goose Down
+ALTER TABLE departments
+  DROP KEY ft_departments_search;
+
+ALTER TABLE employees
+  DROP KEY ft_employees_search;
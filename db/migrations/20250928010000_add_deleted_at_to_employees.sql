@@ -0,0 +1,12 @@
+-- +goose Up
+-- archived employees keep their row so the cascading attendance foreign keys
+-- never fire, only an admin purge removes it
+ALTER TABLE employees
+  ADD COLUMN deleted_at DATETIME NULL COMMENT 'archived at, NULL = active record'
+  AFTER updated_at,
+  ADD KEY ix_employees_deleted_at (deleted_at);
+
+-- +goose Down
+ALTER TABLE employees
+  DROP KEY ix_employees_deleted_at,
+  DROP COLUMN deleted_at;
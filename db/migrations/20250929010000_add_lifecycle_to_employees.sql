@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE employees
+  ADD COLUMN hire_date DATE NULL AFTER address,
+  ADD COLUMN termination_date DATE NULL COMMENT 'last day of employment' AFTER hire_date,
+  ADD COLUMN employment_type VARCHAR(20) NOT NULL DEFAULT 'permanent' COMMENT 'permanent | contract | intern'
+  AFTER termination_date;
+
+UPDATE employees SET hire_date = DATE(created_at);
+
+ALTER TABLE employees
+  MODIFY COLUMN hire_date DATE NOT NULL;
+
+-- +goose Down
+ALTER TABLE employees
+  DROP COLUMN employment_type,
+  DROP COLUMN termination_date,
+  DROP COLUMN hire_date;
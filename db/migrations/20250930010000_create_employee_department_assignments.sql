@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE employee_department_assignments (
+  id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  employee_id     VARCHAR(50)     NOT NULL COLLATE utf8mb4_unicode_ci,
+  department_id   BIGINT UNSIGNED NOT NULL,
+  effective_from  DATE            NOT NULL COMMENT 'first day in the department',
+  effective_to    DATE            NULL COMMENT 'last day in the department, NULL = current',
+  created_at      DATETIME        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY ux_assignments_employee_from (employee_id, effective_from),
+  KEY ix_assignments_department_from (department_id, effective_from),
+  CONSTRAINT fk_assignments_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(employee_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_assignments_department
+    FOREIGN KEY (department_id) REFERENCES departments(id)
+    ON UPDATE RESTRICT
+    ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- every employee starts with their current department since hiring
+INSERT INTO employee_department_assignments (employee_id, department_id, effective_from)
+SELECT employee_id, department_id, hire_date FROM employees;
+
+-- +goose Down
+DROP TABLE IF EXISTS employee_department_assignments;
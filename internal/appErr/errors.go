@@ -10,4 +10,5 @@ var (
 	ErrInvalidTimeRange = errors.New("invalid time range")
 	ErrNotFound         = errors.New("not found")
 	ErrConflict         = errors.New("conflict")
+	ErrForbidden        = errors.New("forbidden")
 )
@@ -0,0 +1,95 @@
+// Package cache is a read-through cache for repository lookups. Values are
+// JSON encoded into a Store, so the in-process LRU can be swapped for a
+// shared cache without touching the callers.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+)
+
+// Store keeps encoded values under string keys. Implementations must be safe
+// for concurrent use; a shared cache (e.g. Redis) fits behind it as well.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Cache is a typed namespace of a Store. Caches sharing a name share keys and
+// metrics, so one repository can invalidate what another one cached.
+type Cache[T any] struct {
+	name  string
+	store Store
+	ttl   time.Duration
+	stats *counters
+}
+
+func New[T any](name string, store Store, ttl time.Duration) *Cache[T] {
+	return &Cache[T]{name: name, store: store, ttl: ttl, stats: countersFor(name)}
+}
+
+// Get returns the cached value of key or calls load and caches its result.
+// Load errors are returned as is and never cached. A failing store only
+// costs the lookup: it is counted and logged, the value is loaded.
+func (c *Cache[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	k := c.key(key)
+
+	raw, ok, err := c.store.Get(ctx, k)
+	if err != nil {
+		c.stats.errors.Add(1)
+		log.Printf("cache %s: get %q: %v", c.name, key, err)
+	}
+	if ok {
+		var v T
+		if err := json.Unmarshal(raw, &v); err == nil {
+			c.stats.hits.Add(1)
+			return v, nil
+		}
+		c.stats.errors.Add(1)
+	}
+	c.stats.misses.Add(1)
+
+	v, err := load(ctx)
+	if err != nil {
+		return v, err
+	}
+	c.Set(ctx, key, v)
+	return v, nil
+}
+
+// Set caches v under key, for values loaded along with another one.
+func (c *Cache[T]) Set(ctx context.Context, key string, v T) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if err := c.store.Set(ctx, c.key(key), raw, c.ttl); err != nil {
+		c.stats.errors.Add(1)
+		log.Printf("cache %s: set %q: %v", c.name, key, err)
+	}
+}
+
+// Invalidate drops keys. Call it after the write committed; a reader racing
+// the write can still put the old value back, the TTL bounds that.
+func (c *Cache[T]) Invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	full := make([]string, len(keys))
+	for i, k := range keys {
+		full[i] = c.key(k)
+	}
+	c.stats.invalidations.Add(int64(len(keys)))
+	if err := c.store.Delete(ctx, full...); err != nil {
+		c.stats.errors.Add(1)
+		log.Printf("cache %s: delete %s: %v", c.name, strings.Join(keys, ", "), err)
+	}
+}
+
+func (c *Cache[T]) key(k string) string {
+	return c.name + ":" + k
+}
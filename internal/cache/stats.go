@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+type counters struct {
+	hits, misses, invalidations, errors atomic.Int64
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*counters{}
+)
+
+func countersFor(name string) *counters {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	c, ok := registry[name]
+	if !ok {
+		c = &counters{}
+		registry[name] = c
+	}
+	return c
+}
+
+type Stat struct {
+	Name          string   `json:"name"`
+	Hits          int64    `json:"hits"`
+	Misses        int64    `json:"misses"`
+	Invalidations int64    `json:"invalidations"`
+	Errors        int64    `json:"errors"`
+	HitRate       *float64 `json:"hit_rate"` // nil before the first lookup
+}
+
+// Stats reports the counters of every cache name since start, by name.
+func Stats() []Stat {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	out := make([]Stat, 0, len(registry))
+	for name, c := range registry {
+		s := Stat{
+			Name:          name,
+			Hits:          c.hits.Load(),
+			Misses:        c.misses.Load(),
+			Invalidations: c.invalidations.Load(),
+			Errors:        c.errors.Load(),
+		}
+		if total := s.Hits + s.Misses; total > 0 {
+			r := float64(s.Hits) / float64(total)
+			s.HitRate = &r
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+var appTZ = time.UTC
+
+// LoadAppTimezone reads APP_TZ once at startup, an unknown zone is an error.
+// Unset means UTC.
+func LoadAppTimezone() error {
+	tz := os.Getenv("APP_TZ")
+	if tz == "" {
+		appTZ = time.UTC
+		return nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return fmt.Errorf("invalid APP_TZ %q: %w", tz, err)
+	}
+	appTZ = loc
+	return nil
+}
+
+// AppTimezone is the zone used when there is no request to take a tz from,
+// e.g. background jobs resolving department cutoff times. It is the zone
+// loaded by LoadAppTimezone, UTC before that.
+func AppTimezone() *time.Location {
+	return appTZ
+}
+
+// AdminToken guards the /v1/admin endpoints. Empty disables them.
+func AdminToken() string {
+	return os.Getenv("ADMIN_TOKEN")
+}
+
+type AutoCloseConfig struct {
+	Enabled bool
+	// open attendances clocked in longer ago than this are closed
+	After    time.Duration
+	Schedule string
+}
+
+func LoadAutoCloseConfig() AutoCloseConfig {
+	return AutoCloseConfig{
+		Enabled:  envBool("AUTO_CLOSE_ENABLED", true),
+		After:    time.Duration(envInt("AUTO_CLOSE_AFTER_HOURS", 16)) * time.Hour,
+		Schedule: envString("AUTO_CLOSE_CRON", "*/15 * * * *"),
+	}
+}
+
+// OvertimePolicy drives overtime derivation. Minutes are counted past the
+// department max clock-out on working days, and for the whole session on rest
+// days and holidays.
+type OvertimePolicy struct {
+	MinMinutes        int
+	DailyCapMinutes   int
+	WeeklyCapMinutes  int
+	WeekdayMultiplier float64
+	HolidayMultiplier float64
+}
+
+func LoadOvertimePolicy() OvertimePolicy {
+	return OvertimePolicy{
+		MinMinutes:        envInt("OVERTIME_MIN_MINUTES", 30),
+		DailyCapMinutes:   envInt("OVERTIME_DAILY_CAP_MINUTES", 240),
+		WeeklyCapMinutes:  envInt("OVERTIME_WEEKLY_CAP_MINUTES", 720),
+		WeekdayMultiplier: envFloat("OVERTIME_WEEKDAY_MULTIPLIER", 1.5),
+		HolidayMultiplier: envFloat("OVERTIME_HOLIDAY_MULTIPLIER", 2.0),
+	}
+}
+
+// WebhookConfig drives the outbox dispatcher. A failed delivery is retried
+// after Backoff, doubling each attempt up to an hour, until MaxAttempts.
+type WebhookConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	Timeout     time.Duration
+	Backoff     time.Duration
+	MaxAttempts int
+}
+
+func LoadWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Enabled:     envBool("WEBHOOK_DISPATCH_ENABLED", true),
+		Interval:    time.Duration(envInt("WEBHOOK_DISPATCH_INTERVAL_SECONDS", 5)) * time.Second,
+		Timeout:     time.Duration(envInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		Backoff:     time.Duration(envInt("WEBHOOK_BACKOFF_SECONDS", 30)) * time.Second,
+		MaxAttempts: envInt("WEBHOOK_MAX_ATTEMPTS", 8),
+	}
+}
+
+// SMTPConfig is the outgoing mail server. An empty Host logs mails instead of
+// sending them, which is handy locally; point it at a stand-in like MailHog
+// (localhost:1025) to see the rendered messages.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func LoadSMTPConfig() SMTPConfig {
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "Fleetify Attendance <no-reply@fleetify.local>"
+	}
+	return SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     envInt("SMTP_PORT", 587),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+	}
+}
+
+// NotifyConfig schedules the emails. The digest covers the previous day in
+// APP_TZ; a reminder goes out ReminderAfter past the department max clock-out
+// when an employee is still clocked in.
+type NotifyConfig struct {
+	Enabled          bool
+	DigestSchedule   string
+	ReminderSchedule string
+	ReminderAfter    time.Duration
+}
+
+func LoadNotifyConfig() NotifyConfig {
+	return NotifyConfig{
+		Enabled:          envBool("NOTIFY_ENABLED", true),
+		DigestSchedule:   envString("NOTIFY_DIGEST_CRON", "0 8 * * *"),
+		ReminderSchedule: envString("NOTIFY_REMINDER_CRON", "*/5 * * * *"),
+		ReminderAfter:    time.Duration(envInt("NOTIFY_REMINDER_AFTER_MINUTES", 60)) * time.Minute,
+	}
+}
+
+// SchedulerConfig drives the in-process job scheduler. Job schedules are cron
+// expressions in APP_TZ; prefix one with CRON_TZ=<zone> to pin it elsewhere.
+type SchedulerConfig struct {
+	Enabled bool
+	// how often due jobs are polled
+	Tick time.Duration
+	// how long a replica owns a run before others may take it over; renewed
+	// while the job is running
+	Lease time.Duration
+}
+
+func LoadSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		Enabled: envBool("SCHEDULER_ENABLED", true),
+		Tick:    time.Duration(envInt("SCHEDULER_TICK_SECONDS", 15)) * time.Second,
+		Lease:   time.Duration(envInt("SCHEDULER_LEASE_SECONDS", 300)) * time.Second,
+	}
+}
+
+// RetentionConfig prunes bookkeeping tables: dispatched outbox events,
+// finished webhook deliveries, notification logs and job runs.
+type RetentionConfig struct {
+	Enabled  bool
+	Schedule string
+	Days     int
+}
+
+func LoadRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		Enabled:  envBool("RETENTION_ENABLED", true),
+		Schedule: envString("RETENTION_CRON", "30 3 * * *"),
+		Days:     envInt("RETENTION_DAYS", 90),
+	}
+}
+
+// ReportConfig drives the monthly batch of department PDF reports, written
+// as one zip per month into Dir.
+type ReportConfig struct {
+	Enabled  bool
+	Schedule string
+	Dir      string
+}
+
+func LoadReportConfig() ReportConfig {
+	return ReportConfig{
+		Enabled:  envBool("REPORT_ENABLED", true),
+		Schedule: envString("REPORT_CRON", "0 6 1 * *"),
+		Dir:      envString("REPORT_DIR", "reports"),
+	}
+}
+
+// DailyRebuildConfig drives the nightly rebuild of attendance_daily over the
+// last Days days, a safety net next to the rebuilds done on every edit.
+type DailyRebuildConfig struct {
+	Enabled  bool
+	Schedule string
+	Days     int
+}
+
+func LoadDailyRebuildConfig() DailyRebuildConfig {
+	return DailyRebuildConfig{
+		Enabled:  envBool("DAILY_REBUILD_ENABLED", true),
+		Schedule: envString("DAILY_REBUILD_CRON", "0 2 * * *"),
+		Days:     envInt("DAILY_REBUILD_DAYS", 7),
+	}
+}
+
+// CacheConfig sizes the in-process cache in front of employee and
+// department lookups. Writes on another replica reach this one's entries
+// only through the TTL.
+type CacheConfig struct {
+	Enabled bool
+	TTL     time.Duration
+	Size    int
+}
+
+func LoadCacheConfig() CacheConfig {
+	return CacheConfig{
+		Enabled: envBool("CACHE_ENABLED", true),
+		TTL:     time.Duration(envInt("CACHE_TTL_SECONDS", 60)) * time.Second,
+		Size:    envInt("CACHE_SIZE", 10000),
+	}
+}
+
+func envString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Printf("invalid %s %q, using %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+func envBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("invalid %s %q, using %v", key, v, def)
+		return def
+	}
+	return b
+}
+
+func envFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f <= 0 {
+		log.Printf("invalid %s %q, using %v", key, v, def)
+		return def
+	}
+	return f
+}
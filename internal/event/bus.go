@@ -0,0 +1,115 @@
+package event
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	AttendanceClockIn  = "attendance.clock_in"
+	AttendanceClockOut = "attendance.clock_out"
+	AttendanceLate     = "attendance.late"
+
+	EmployeeCreated     = "employee.created"
+	EmployeeUpdated     = "employee.updated"
+	EmployeeDeleted     = "employee.deleted"
+	EmployeeRestored    = "employee.restored"
+	EmployeePurged      = "employee.purged"
+	EmployeeTransferred = "employee.transferred"
+
+	DepartmentCreated = "department.created"
+	DepartmentUpdated = "department.updated"
+	DepartmentDeleted = "department.deleted"
+)
+
+// Types are the event types webhooks can subscribe to.
+var Types = []string{
+	AttendanceClockIn, AttendanceClockOut, AttendanceLate,
+	EmployeeCreated, EmployeeUpdated, EmployeeDeleted, EmployeeRestored, EmployeePurged,
+	EmployeeTransferred,
+	DepartmentCreated, DepartmentUpdated, DepartmentDeleted,
+}
+
+func IsKnownType(t string) bool {
+	for _, k := range Types {
+		if k == t {
+			return true
+		}
+	}
+	return false
+}
+
+type Event struct {
+	Type           string    `json:"type"`
+	At             time.Time `json:"at"`
+	EmployeeID     string    `json:"employee_id"`
+	EmployeeName   string    `json:"employee_name"`
+	DepartmentID   uint64    `json:"department_id"`
+	DepartmentName string    `json:"department_name"`
+	AttendanceID   string    `json:"attendance_id"`
+	// terminal | manual | auto_closed
+	Source string `json:"source,omitempty"`
+}
+
+// Bus is an in-process fan-out. Publish never blocks: a subscriber whose
+// buffer is full misses the event, it can resync from the live board.
+type Bus struct {
+	mu   sync.RWMutex
+	next int
+	subs map[int]*subscriber
+}
+
+type subscriber struct {
+	ch     chan Event
+	filter func(Event) bool
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: map[int]*subscriber{}}
+}
+
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, s := range b.subs {
+		if s.filter != nil && !s.filter(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			log.Printf("event bus: subscriber %d is slow, dropped %s", id, e.Type)
+		}
+	}
+}
+
+// Subscribe registers a listener, filter may be nil. The returned cancel
+// func unregisters it and closes the channel.
+func (b *Bus) Subscribe(buffer int, filter func(Event) bool) (<-chan Event, func()) {
+	if buffer <= 0 {
+		buffer = 16
+	}
+	s := &subscriber{ch: make(chan Event, buffer), filter: filter}
+
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subs[id] = s
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(s.ch)
+		})
+	}
+	return s.ch, cancel
+}
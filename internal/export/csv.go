@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSV writes a UTF-8 BOM, so spreadsheet apps detect the encoding, and the header.
+func NewCSV(w io.Writer, header []string) (Writer, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(header); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (c *csvWriter) WriteRow(cells ...any) error {
+	rec := make([]string, len(cells))
+	for i, v := range cells {
+		s, ok := cellText(v)
+		if !ok {
+			continue
+		}
+		switch v.(type) {
+		case string, *string:
+			s = escapeFormula(s)
+		}
+		rec[i] = s
+	}
+	return c.w.Write(rec)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
+
+// escapeFormula keeps free text such as names from being evaluated as a
+// formula when the file is opened in a spreadsheet.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+// Package export writes tabular reports row by row, so a report of any size
+// goes out without being held in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer receives rows after the header. Cells may be string, *string, int,
+// *int, int64, float64 or bool; nil pointers are written as empty cells.
+type Writer interface {
+	WriteRow(cells ...any) error
+	// Flush pushes buffered rows to the underlying writer.
+	Flush() error
+	// Close completes the file, the underlying writer is left open.
+	Close() error
+}
+
+// New opens a writer of the given format and writes the header row.
+func New(format string, w io.Writer, sheet string, header []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSV(w, header)
+	case FormatXLSX:
+		return NewXLSX(w, sheet, header)
+	default:
+		return nil, fmt.Errorf("export: unknown format %q", format)
+	}
+}
+
+// ContentType is the response media type of format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// cellText renders a non-nil cell value, ok is false for nil and nil pointers.
+func cellText(v any) (s string, ok bool) {
+	switch x := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return x, true
+	case *string:
+		if x == nil {
+			return "", false
+		}
+		return *x, true
+	case int:
+		return strconv.Itoa(x), true
+	case *int:
+		if x == nil {
+			return "", false
+		}
+		return strconv.Itoa(*x), true
+	case int64:
+		return strconv.FormatInt(x, 10), true
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), true
+	case bool:
+		if x {
+			return "TRUE", true
+		}
+		return "FALSE", true
+	default:
+		return fmt.Sprint(x), true
+	}
+}
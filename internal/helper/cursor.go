@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor is the position a keyset page starts from: the sort key and id of
+// the row next to it. Before walks back towards the start of the list.
+type Cursor struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Key    string `json:"k,omitempty"`
+	ID     uint64 `json:"i"`
+	Before bool   `json:"b,omitempty"`
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor returns c as an opaque url safe token.
+func EncodeCursor(c Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.SortBy == "" || c.ID == 0 {
+		return Cursor{}, errInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,16 @@
+package helper
+
+import "strings"
+
+// FileSafe keeps s usable as a download filename, inside a quoted
+// Content-Disposition header, and as a zip entry: anything but ASCII
+// letters, digits, '-' and '.' becomes '_'.
+func FileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -3,6 +3,8 @@ package helper
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -57,6 +59,35 @@ func DayBoundsLocalToUTC(loc *time.Location, y int, m time.Month, d int) (fromUT
 	return startLocal.UTC(), endLocal.UTC()
 }
 
+const DefaultWorkingDays = "1,2,3,4,5"
+
+const (
+	DefaultBreakMinutes = 60
+	MaxBreakMinutes     = 240
+)
+
+// ParseWorkingDays parses a comma separated list of ISO weekdays (1=Mon..7=Sun)
+// and returns a lookup indexed by time.Weekday. Empty input means Mon-Fri.
+func ParseWorkingDays(s string) ([7]bool, error) {
+	var days [7]bool
+	if strings.TrimSpace(s) == "" {
+		s = DefaultWorkingDays
+	}
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 || n > 7 {
+			return days, fmt.Errorf("working days must be ISO weekdays 1-7, got %q", part)
+		}
+		days[n%7] = true
+	}
+	return days, nil
+}
+
+// DateKey formats the calendar date of t as YYYY-MM-DD.
+func DateKey(t time.Time) string {
+	return fmt.Sprintf("%04d-%02d-%02d", t.Year(), t.Month(), t.Day())
+}
+
 type Pagination struct {
     TotalData   int64 `json:"totalData"`
     CurrentPage int   `json:"currentPage"`
@@ -24,6 +24,14 @@ func Conflict(c *gin.Context, msg string) {
 	RespondErr(c, http.StatusConflict, "conflict", msg)
 }
 
+func Unauthorized(c *gin.Context, msg string) {
+	RespondErr(c, http.StatusUnauthorized, "unauthorized", msg)
+}
+
+func Forbidden(c *gin.Context, msg string) {
+	RespondErr(c, http.StatusForbidden, "forbidden", msg)
+}
+
 func Internal(c *gin.Context, msg string) {
 	RespondErr(c, http.StatusInternalServerError, "internal_error", msg)
 }
@@ -34,7 +42,8 @@ func NotFound(c *gin.Context, msg string) {
 
 func WriteError(c *gin.Context, err error) {
 	switch {
-	case errors.Is(err, appErr.ErrAlreadyExists):
+	case errors.Is(err, appErr.ErrAlreadyExists),
+		errors.Is(err, appErr.ErrConflict):
 		Conflict(c, err.Error())
 	case errors.Is(err, appErr.ErrRequiredField),
 		errors.Is(err, appErr.ErrInvalidInput),
@@ -43,6 +52,8 @@ func WriteError(c *gin.Context, err error) {
 		BadRequest(c, err.Error())
 	case errors.Is(err, appErr.ErrNotFound):
 		NotFound(c, err.Error())
+	case errors.Is(err, appErr.ErrForbidden):
+		Forbidden(c, err.Error())
 	default:
 		Internal(c, err.Error())
 	}
@@ -30,6 +30,10 @@ type listQueryEmpAtdHistories struct {
 	To    string `form:"to" binding:"omitempty"`
 	Limit int    `form:"limit"   binding:"omitempty,min=1,max=100"`
 	Page  int    `form:"page"    binding:"omitempty,min=1"`
+	// csv | xlsx streams the whole range instead of a page
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	// header language, falls back to Accept-Language
+	Lang string `form:"lang" binding:"omitempty"`
 }
 
 type listEmpAtdHistoriesData struct {
@@ -54,6 +58,10 @@ type listQueryDeptAtdHistories struct {
 	To         string  `form:"to" binding:"omitempty"`
 	Limit      int     `form:"limit"   binding:"omitempty,min=1,max=100"`
 	Page       int     `form:"page"    binding:"omitempty,min=1"`
+	// csv | xlsx streams the whole range instead of a page
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	// header language, falls back to Accept-Language
+	Lang string `form:"lang" binding:"omitempty"`
 }
 
 type listDeptAtdHistoriesData struct {
@@ -70,3 +78,66 @@ type listDeptAtdHistoriesResp struct {
 	Data       listDeptAtdHistoriesData `json:"data"`
 	Pagination helper.Pagination        `json:"pagination"`
 }
+
+type autoCloseReq struct {
+	OlderThanHours int    `json:"older_than_hours" binding:"omitempty,min=1"`
+	TZ             string `json:"tz" binding:"omitempty"`
+}
+
+type autoCloseResponse struct {
+	Message string                 `json:"message"`
+	Data    atdSvc.AutoCloseOutput `json:"data"`
+}
+
+type listQueryNeedsReview struct {
+	Department *uint64 `form:"dept_id" binding:"omitempty"`
+	Limit      int     `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Page       int     `form:"page"    binding:"omitempty,min=1"`
+}
+
+type needsReviewData struct {
+	AttendanceID   string     `json:"attendance_id"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeName   string     `json:"employee_name"`
+	DepartmentName *string    `json:"department_name,omitempty"`
+	ClockIn        *time.Time `json:"clock_in,omitempty"`
+	ClockOut       *time.Time `json:"clock_out,omitempty"`
+}
+
+type listNeedsReviewResp struct {
+	Message    string            `json:"message"`
+	Data       []needsReviewData `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
+
+type timesheetQuery struct {
+	Period string `form:"period" binding:"omitempty,oneof=week month"`
+	Date   string `form:"date" binding:"omitempty"`
+	TZ     string `form:"tz" binding:"omitempty"`
+}
+
+type timesheetResponse struct {
+	Message string                 `json:"message"`
+	Data    atdSvc.TimesheetOutput `json:"data"`
+}
+
+type liveQuery struct {
+	Department *uint64 `form:"dept_id" binding:"omitempty"`
+}
+
+type liveResponse struct {
+	Message string            `json:"message"`
+	Data    atdSvc.LiveOutput `json:"data"`
+}
+
+type summaryQuery struct {
+	Department *uint64 `form:"dept_id" binding:"omitempty"`
+	TZ         string  `form:"tz" binding:"omitempty"`
+	From       string  `form:"from" binding:"required"`
+	To         string  `form:"to" binding:"required"`
+}
+
+type summaryResponse struct {
+	Message string               `json:"message"`
+	Data    atdSvc.SummaryOutput `json:"data"`
+}
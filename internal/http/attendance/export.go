@@ -0,0 +1,104 @@
+package attendance
+
+import (
+	"fmt"
+	"log"
+	stdhttp "net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/export"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	atdSvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+// rows between flushes to the client
+const exportFlushEvery = 500
+
+var exportHeaders = map[string][]string{
+	"en": {
+		"Employee ID", "Employee Name", "Department", "Date",
+		"Clock In", "Status In", "Delta In (min)",
+		"Clock Out", "Status Out", "Delta Out (min)",
+		"Holiday", "Leave Type", "Corrected", "Needs Review", "Attendance ID",
+	},
+	"id": {
+		"ID Karyawan", "Nama Karyawan", "Departemen", "Tanggal",
+		"Jam Masuk", "Status Masuk", "Selisih Masuk (menit)",
+		"Jam Keluar", "Status Keluar", "Selisih Keluar (menit)",
+		"Hari Libur", "Jenis Cuti", "Dikoreksi", "Perlu Ditinjau", "ID Absensi",
+	},
+}
+
+var exportSheetNames = map[string]string{
+	"en": "Attendance",
+	"id": "Absensi",
+}
+
+// exportLang picks the header language from ?lang, then Accept-Language, defaulting to en.
+func exportLang(c *gin.Context, lang string) string {
+	candidates := []string{lang}
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		candidates = append(candidates, strings.SplitN(part, ";", 2)[0])
+	}
+
+	for _, l := range candidates {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if i := strings.IndexAny(l, "-_"); i > 0 {
+			l = l[:i]
+		}
+		if _, ok := exportHeaders[l]; ok {
+			return l
+		}
+	}
+	return "en"
+}
+
+// writeExport streams exp as an attachment. Once the first byte is out the
+// status can no longer change, a failure midway ends the response early
+// and leaves a truncated file behind.
+func writeExport(c *gin.Context, exp *atdSvc.HistoryExport, format, lang, name string) {
+	filename := fmt.Sprintf("attendance_%s_%s_%s.%s", helper.FileSafe(name), exp.FromLocal, exp.ToLocal, format)
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(stdhttp.StatusOK)
+
+	w, err := export.New(format, c.Writer, exportSheetNames[lang], exportHeaders[lang])
+	if err != nil {
+		log.Printf("attendance export: %v", err)
+		return
+	}
+
+	rows := 0
+	err = exp.Each(c.Request.Context(), func(it atdSvc.AttendanceHistoryItem) error {
+		if err := w.WriteRow(
+			it.EmployeeID, it.EmployeeName, it.DepartmentName, it.DateLocal,
+			it.ClockInLocal, it.StatusIn, it.DeltaInMinutes,
+			it.ClockOutLocal, it.StatusOut, it.DeltaOutMinutes,
+			it.Holiday, it.LeaveType, it.Corrected, it.NeedsReview, it.AttendanceID,
+		); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushEvery == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("attendance export %s: %v", filename, err)
+		return
+	}
+
+	if err := w.Close(); err != nil {
+		log.Printf("attendance export %s: %v", filename, err)
+		return
+	}
+	c.Writer.Flush()
+}
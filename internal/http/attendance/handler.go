@@ -1,10 +1,14 @@
 package attendance
 
 import (
+	"fmt"
+	"io"
 	stdhttp "net/http"
 	"net/url"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/config"
 	"github.com/itsaFan/fleetify-be/internal/helper"
 	atdSvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
 )
@@ -84,6 +88,7 @@ func (h *Handler) GetEmpAtdHistories(c *gin.Context) {
 	var q listQueryEmpAtdHistories
 	if err := c.ShouldBindQuery(&q); err != nil {
 		helper.BadRequest(c, "Invalid query parameters")
+		return
 	}
 
 	if q.Limit == 0 {
@@ -96,6 +101,21 @@ func (h *Handler) GetEmpAtdHistories(c *gin.Context) {
 		q.TZ = "UTC"
 	}
 
+	if q.Format != "" {
+		exp, err := h.svc.ExportEmployeeAtdHistories(c.Request.Context(), atdSvc.ListInputEmp{
+			EmployeeID: empId,
+			FromLocal:  q.From,
+			ToLocal:    q.To,
+			TZ:         q.TZ,
+		})
+		if err != nil {
+			helper.WriteError(c, err)
+			return
+		}
+		writeExport(c, exp, q.Format, exportLang(c, q.Lang), empId)
+		return
+	}
+
 	res, err := h.svc.ListEmployeeAtdHistories(c.Request.Context(), atdSvc.ListInputEmp{
 		EmployeeID: empId,
 		FromLocal:  q.From,
@@ -130,6 +150,7 @@ func (h *Handler) GetDeptAtdHistories(c *gin.Context) {
 	var q listQueryDeptAtdHistories
 	if err := c.ShouldBindQuery(&q); err != nil {
 		helper.BadRequest(c, "Invalid query parameters")
+		return
 	}
 
 	if q.Limit == 0 {
@@ -142,6 +163,25 @@ func (h *Handler) GetDeptAtdHistories(c *gin.Context) {
 		q.TZ = "UTC"
 	}
 
+	if q.Format != "" {
+		exp, err := h.svc.ExportDepartmentAtdHistories(c.Request.Context(), atdSvc.ListInputDept{
+			DepartmentID: q.Department,
+			FromLocal:    q.From,
+			ToLocal:      q.To,
+			TZ:           q.TZ,
+		})
+		if err != nil {
+			helper.WriteError(c, err)
+			return
+		}
+		name := "all"
+		if q.Department != nil {
+			name = fmt.Sprintf("dept%d", *q.Department)
+		}
+		writeExport(c, exp, q.Format, exportLang(c, q.Lang), name)
+		return
+	}
+
 	res, err := h.svc.ListDeparmentAtdHistories(c.Request.Context(), atdSvc.ListInputDept{
 		DepartmentID: q.Department,
 		FromLocal:    q.From,
@@ -172,3 +212,215 @@ func (h *Handler) GetDeptAtdHistories(c *gin.Context) {
 	c.JSON(stdhttp.StatusOK, resp)
 
 }
+
+func (h *Handler) GetEmpTimesheet(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "Invalid employee_id name in path")
+		return
+	}
+
+	var q timesheetQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if q.TZ == "" {
+		q.TZ = "UTC"
+	}
+
+	out, err := h.svc.EmployeeTimesheet(c.Request.Context(), atdSvc.TimesheetInput{
+		EmployeeID: empId,
+		Period:     q.Period,
+		Date:       q.Date,
+		TZ:         q.TZ,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, timesheetResponse{
+		Message: "Employee timesheet retrieved successfully",
+		Data:    *out,
+	})
+}
+
+func (h *Handler) GetDeptSummary(c *gin.Context) {
+	var q summaryQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if q.TZ == "" {
+		q.TZ = "UTC"
+	}
+
+	out, err := h.svc.DepartmentSummary(c.Request.Context(), atdSvc.SummaryInput{
+		DepartmentID: q.Department,
+		FromLocal:    q.From,
+		ToLocal:      q.To,
+		TZ:           q.TZ,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, summaryResponse{
+		Message: "Department attendance summary retrieved successfully",
+		Data:    *out,
+	})
+}
+
+func (h *Handler) GetLive(c *gin.Context) {
+	var q liveQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.LiveBoard(c.Request.Context(), q.Department)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, liveResponse{
+		Message: "Live attendance board retrieved successfully",
+		Data:    *out,
+	})
+}
+
+const livePingInterval = 25 * time.Second
+
+// StreamLive is a Server-Sent Events stream. It opens with a "snapshot" of
+// the live board, then forwards clock-in/clock-out events and pings to keep
+// proxies from closing an idle connection.
+func (h *Handler) StreamLive(c *gin.Context) {
+	var q liveQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	// subscribe before the snapshot so nothing falls in between
+	events, cancel := h.svc.SubscribeLive(q.Department)
+	defer cancel()
+
+	snapshot, err := h.svc.LiveBoard(c.Request.Context(), q.Department)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("snapshot", snapshot)
+	c.Writer.Flush()
+
+	ping := time.NewTicker(livePingInterval)
+	defer ping.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(e.Type, e)
+			return true
+		case t := <-ping.C:
+			c.SSEvent("ping", t.UTC())
+			return true
+		}
+	})
+}
+
+// AutoClose is the manual trigger of the auto-close job. Body fields are
+// optional and fall back to the job configuration.
+func (h *Handler) AutoClose(c *gin.Context) {
+	var req autoCloseReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			helper.BadRequest(c, "invalid JSON body")
+			return
+		}
+	}
+
+	olderThan := config.LoadAutoCloseConfig().After
+	if req.OlderThanHours > 0 {
+		olderThan = time.Duration(req.OlderThanHours) * time.Hour
+	}
+	loc := config.AppTimezone()
+	if req.TZ != "" {
+		loc = helper.LoadLocationOrUTC(req.TZ)
+	}
+
+	out, err := h.svc.AutoCloseOpenAttendances(c.Request.Context(), atdSvc.AutoCloseInput{
+		OlderThan: olderThan,
+		Loc:       loc,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, autoCloseResponse{
+		Message: "Open attendances auto-closed",
+		Data:    *out,
+	})
+}
+
+func (h *Handler) GetNeedsReview(c *gin.Context) {
+	var q listQueryNeedsReview
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	if q.Limit == 0 {
+		q.Limit = 10
+	}
+	if q.Page == 0 {
+		q.Page = 1
+	}
+
+	res, err := h.svc.ListNeedsReview(c.Request.Context(), atdSvc.ListReviewInput{
+		DepartmentID: q.Department,
+		Limit:        q.Limit,
+		Page:         q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]needsReviewData, len(res.Items))
+	for i, a := range res.Items {
+		var deptName *string
+		if a.Employee.Department.ID != 0 {
+			n := a.Employee.Department.DepartmentName
+			deptName = &n
+		}
+		data[i] = needsReviewData{
+			AttendanceID:   a.AttendanceID,
+			EmployeeID:     a.EmployeeID,
+			EmployeeName:   a.Employee.Name,
+			DepartmentName: deptName,
+			ClockIn:        a.ClockIn,
+			ClockOut:       a.ClockOut,
+		}
+	}
+
+	c.JSON(stdhttp.StatusOK, listNeedsReviewResp{
+		Message:    "Attendances needing review retrieved successfully",
+		Data:       data,
+		Pagination: helper.BuildPagination(res.Total, q.Page, q.Limit),
+	})
+}
@@ -10,6 +10,26 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 		attendance.PUT("/:employee_id", h.EmployeeCheckOut)
 
 		attendance.GET("/histories", h.GetDeptAtdHistories)
+		attendance.GET("/summary", h.GetDeptSummary)
+		attendance.GET("/live", h.GetLive)
+		attendance.GET("/live/stream", h.StreamLive)
 		attendance.GET("/employee/:employee_id/histories", h.GetEmpAtdHistories)
 	}
+
+	// lives under /employee, the employee handler owns the rest of that group
+	employee := rg.Group("/employee")
+
+	{
+		employee.GET("/:employee_id/timesheet", h.GetEmpTimesheet)
+	}
+}
+
+// RegisterAdmin mounts the admin endpoints, rg is expected to be guarded.
+func (h *Handler) RegisterAdmin(rg *gin.RouterGroup) {
+	attendance := rg.Group("/attendance")
+
+	{
+		attendance.POST("/auto-close", h.AutoClose)
+		attendance.GET("/needs-review", h.GetNeedsReview)
+	}
 }
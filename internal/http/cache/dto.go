@@ -0,0 +1,10 @@
+package cache
+
+import (
+	appcache "github.com/itsaFan/fleetify-be/internal/cache"
+)
+
+type statsResponse struct {
+	Message string          `json:"message"`
+	Data    []appcache.Stat `json:"data"`
+}
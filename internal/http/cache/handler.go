@@ -0,0 +1,22 @@
+package cache
+
+import (
+	stdhttp "net/http"
+
+	"github.com/gin-gonic/gin"
+	appcache "github.com/itsaFan/fleetify-be/internal/cache"
+)
+
+// Handler exposes the lookup cache counters of this replica.
+type Handler struct{}
+
+func New() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) Stats(c *gin.Context) {
+	c.JSON(stdhttp.StatusOK, statsResponse{
+		Message: "Cache stats retrieved successfully",
+		Data:    appcache.Stats(),
+	})
+}
@@ -0,0 +1,8 @@
+package cache
+
+import "github.com/gin-gonic/gin"
+
+// Register mounts the cache endpoints, rg is expected to be guarded.
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	rg.GET("/cache", h.Stats)
+}
@@ -0,0 +1,61 @@
+package correction
+
+import (
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+)
+
+type correctionResp struct {
+	CorrectionID string     `json:"correction_id"`
+	EmployeeID   string     `json:"employee_id"`
+	EmployeeName string     `json:"employee_name"`
+	Date         string     `json:"date"`
+	TZ           string     `json:"tz"`
+	ClockInUTC   *time.Time `json:"clock_in_utc"`
+	ClockOutUTC  *time.Time `json:"clock_out_utc"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	ApproverID   *string    `json:"approver_id"`
+	DecisionNote *string    `json:"decision_note"`
+	DecidedAt    *time.Time `json:"decided_at"`
+	AttendanceID *string    `json:"attendance_id"`
+	PrevClockIn  *time.Time `json:"prev_clock_in_utc"`
+	PrevClockOut *time.Time `json:"prev_clock_out_utc"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type submitReq struct {
+	EmployeeID string  `json:"employee_id" binding:"required"`
+	Date       string  `json:"date" binding:"required"`
+	TZ         string  `json:"tz"`
+	ClockIn    *string `json:"clock_in"`
+	ClockOut   *string `json:"clock_out"`
+	Reason     string  `json:"reason" binding:"required"`
+}
+
+type decisionReq struct {
+	ApproverID string  `json:"approver_id" binding:"required"`
+	Note       *string `json:"note"`
+}
+
+type correctionResponse struct {
+	Message string         `json:"message"`
+	Data    correctionResp `json:"data"`
+}
+
+type listQuery struct {
+	EmployeeID string  `form:"employee_id"`
+	Department *uint64 `form:"dept_id"`
+	Status     string  `form:"status"  binding:"omitempty,oneof=pending approved rejected"`
+	From       string  `form:"from"`
+	To         string  `form:"to"`
+	Limit      int     `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Page       int     `form:"page"    binding:"omitempty,min=1"`
+}
+
+type listResponse struct {
+	Message    string            `json:"message"`
+	Data       []correctionResp  `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
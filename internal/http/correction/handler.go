@@ -0,0 +1,146 @@
+package correction
+
+import (
+	"context"
+	stdhttp "net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	corrSvc "github.com/itsaFan/fleetify-be/internal/service/correction"
+)
+
+type Handler struct {
+	svc corrSvc.Service
+}
+
+func New(svc corrSvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toResp(c *model.AttendanceCorrection) correctionResp {
+	return correctionResp{
+		CorrectionID: c.CorrectionID,
+		EmployeeID:   c.EmployeeID,
+		EmployeeName: c.Employee.Name,
+		Date:         helper.DateKey(c.DateLocal),
+		TZ:           c.TZ,
+		ClockInUTC:   c.ClockIn,
+		ClockOutUTC:  c.ClockOut,
+		Reason:       c.Reason,
+		Status:       c.Status,
+		ApproverID:   c.ApproverID,
+		DecisionNote: c.DecisionNote,
+		DecidedAt:    c.DecidedAt,
+		AttendanceID: c.AttendanceID,
+		PrevClockIn:  c.PrevClockIn,
+		PrevClockOut: c.PrevClockOut,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func (h *Handler) Submit(c *gin.Context) {
+	var req submitReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	corr, err := h.svc.Submit(c.Request.Context(), corrSvc.SubmitInput{
+		EmployeeID: req.EmployeeID,
+		Date:       req.Date,
+		TZ:         req.TZ,
+		ClockIn:    req.ClockIn,
+		ClockOut:   req.ClockOut,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusCreated, correctionResponse{
+		Message: "Attendance correction submitted successfully",
+		Data:    toResp(corr),
+	})
+}
+
+func (h *Handler) List(c *gin.Context) {
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.List(c.Request.Context(), corrSvc.ListInput{
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.Department,
+		Status:       q.Status,
+		From:         q.From,
+		To:           q.To,
+		Limit:        q.Limit,
+		Page:         q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]correctionResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message:    "Attendance corrections retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
+
+func (h *Handler) GetByCorrectionID(c *gin.Context) {
+	corr, err := h.svc.GetByCorrectionID(c.Request.Context(), c.Param("correction_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, correctionResponse{
+		Message: "Attendance correction retrieved successfully",
+		Data:    toResp(corr),
+	})
+}
+
+func (h *Handler) Approve(c *gin.Context) {
+	h.decide(c, h.svc.Approve, "Attendance correction approved")
+}
+
+func (h *Handler) Reject(c *gin.Context) {
+	h.decide(c, h.svc.Reject, "Attendance correction rejected")
+}
+
+func (h *Handler) decide(
+	c *gin.Context,
+	fn func(ctx context.Context, correctionID string, in corrSvc.DecisionInput) (*model.AttendanceCorrection, error),
+	msg string,
+) {
+	var req decisionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	corr, err := fn(c.Request.Context(), c.Param("correction_id"), corrSvc.DecisionInput{
+		ApproverID: req.ApproverID,
+		Note:       req.Note,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, correctionResponse{
+		Message: msg,
+		Data:    toResp(corr),
+	})
+}
@@ -0,0 +1,15 @@
+package correction
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	corrections := rg.Group("/corrections")
+
+	{
+		corrections.POST("", h.Submit)
+		corrections.GET("", h.List)
+		corrections.GET("/:correction_id", h.GetByCorrectionID)
+		corrections.POST("/:correction_id/approve", h.Approve)
+		corrections.POST("/:correction_id/reject", h.Reject)
+	}
+}
@@ -3,10 +3,13 @@ package department
 import deptsvc "github.com/itsaFan/fleetify-be/internal/service/department"
 
 type departmentResp struct {
-	ID             uint64 `json:"id"`
-	DepartmentName string `json:"department_name"`
-	MaxClockIn     string `json:"max_clock_in"`
-	MaxClockOut    string `json:"max_clock_out"`
+	ID             uint64  `json:"id"`
+	DepartmentName string  `json:"department_name"`
+	MaxClockIn     string  `json:"max_clock_in"`
+	MaxClockOut    string  `json:"max_clock_out"`
+	WorkingDays    string  `json:"working_days"`
+	BreakMinutes   int     `json:"break_minutes"`
+	ManagerID      *string `json:"manager_employee_id"`
 }
 
 type listQuery struct {
@@ -15,12 +18,16 @@ type listQuery struct {
 	Page    int    `form:"page"    binding:"omitempty,min=1"`
 	SortBy  string `form:"sortBy"  binding:"omitempty,oneof=id department_name"`
 	SortDir string `form:"sortDir" binding:"omitempty,oneof=asc desc"`
+	Cursor  string `form:"cursor"`
 }
 
 type createReq struct {
-	DepartmentName string `json:"department_name" binding:"required,max=255"`
-	MaxClockIn     string `json:"max_clock_in"   binding:"required"`
-	MaxClockOut    string `json:"max_clock_out"  binding:"required"`
+	DepartmentName string  `json:"department_name" binding:"required,max=255"`
+	MaxClockIn     string  `json:"max_clock_in"   binding:"required"`
+	MaxClockOut    string  `json:"max_clock_out"  binding:"required"`
+	WorkingDays    string  `json:"working_days"`
+	BreakMinutes   *int    `json:"break_minutes"`
+	ManagerID      *string `json:"manager_employee_id"`
 }
 type createResponse struct {
 	Message string         `json:"message"`
@@ -31,6 +38,8 @@ type listResponse struct {
 	Message    string             `json:"message"`
 	Data       []departmentResp   `json:"data"`
 	Pagination deptsvc.Pagination `json:"pagination"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	PrevCursor string             `json:"prev_cursor,omitempty"`
 }
 
 type getByNameResponse struct {
@@ -42,6 +51,9 @@ type updateReq struct {
 	DepartmentName *string `json:"department_name,omitempty"`
 	MaxClockIn     *string `json:"max_clock_in,omitempty"`
 	MaxClockOut    *string `json:"max_clock_out,omitempty"`
+	WorkingDays    *string `json:"working_days,omitempty"`
+	BreakMinutes   *int    `json:"break_minutes,omitempty"`
+	ManagerID      *string `json:"manager_employee_id,omitempty"`
 }
 
 type updateResponse struct {
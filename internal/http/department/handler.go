@@ -25,9 +25,12 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 	input := deptSvc.CreateInput{
-		DepartmentName: helper.NormalizeStringField(req.DepartmentName),
-		MaxClockIn:     req.MaxClockIn,
-		MaxClockOut:    req.MaxClockOut,
+		DepartmentName:    helper.NormalizeStringField(req.DepartmentName),
+		MaxClockIn:        req.MaxClockIn,
+		MaxClockOut:       req.MaxClockOut,
+		WorkingDays:       req.WorkingDays,
+		BreakMinutes:      req.BreakMinutes,
+		ManagerEmployeeID: req.ManagerID,
 	}
 
 	dept, err := h.svc.Create(c.Request.Context(), input)
@@ -40,6 +43,9 @@ func (h *Handler) Create(c *gin.Context) {
 		DepartmentName: dept.DepartmentName,
 		MaxClockIn:     dept.MaxClockInTime,
 		MaxClockOut:    dept.MaxClockOutTime,
+		WorkingDays:    dept.WorkingDays,
+		BreakMinutes:   dept.BreakMinutes,
+		ManagerID:      dept.ManagerEmployeeID,
 	}
 
 	c.JSON(stdhttp.StatusCreated, createResponse{
@@ -62,6 +68,7 @@ func (h *Handler) List(c *gin.Context) {
 		Page:    q.Page,
 		SortBy:  q.SortBy,
 		SortDir: q.SortDir,
+		Cursor:  q.Cursor,
 	})
 	if err != nil {
 		helper.WriteError(c, err)
@@ -76,6 +83,9 @@ func (h *Handler) List(c *gin.Context) {
 			DepartmentName: d.DepartmentName,
 			MaxClockIn:     d.MaxClockInTime,
 			MaxClockOut:    d.MaxClockOutTime,
+			WorkingDays:    d.WorkingDays,
+			BreakMinutes:   d.BreakMinutes,
+			ManagerID:      d.ManagerEmployeeID,
 		}
 	}
 
@@ -83,6 +93,8 @@ func (h *Handler) List(c *gin.Context) {
 		Message:    "Department retrieved successfully",
 		Data:       data,
 		Pagination: out.Pagination,
+		NextCursor: out.NextCursor,
+		PrevCursor: out.PrevCursor,
 	})
 }
 
@@ -107,6 +119,9 @@ func (h *Handler) GetByName(c *gin.Context) {
 		DepartmentName: dept.DepartmentName,
 		MaxClockIn:     dept.MaxClockInTime,
 		MaxClockOut:    dept.MaxClockOutTime,
+		WorkingDays:    dept.WorkingDays,
+		BreakMinutes:   dept.BreakMinutes,
+		ManagerID:      dept.ManagerEmployeeID,
 	}
 	c.JSON(stdhttp.StatusOK, getByNameResponse{
 		Message: "Department retrieved successfully",
@@ -142,6 +157,16 @@ func (h *Handler) UpdateByName(c *gin.Context) {
 	if req.MaxClockOut != nil {
 		in.MaxClockOut = req.MaxClockOut
 	}
+	if req.WorkingDays != nil {
+		in.WorkingDays = req.WorkingDays
+	}
+	if req.BreakMinutes != nil {
+		in.BreakMinutes = req.BreakMinutes
+	}
+	if req.ManagerID != nil {
+		m := helper.NormalizeStringField(*req.ManagerID)
+		in.ManagerEmployeeID = &m
+	}
 
 	dept, err := h.svc.UpdateByName(c.Request.Context(), name, in)
 	if err != nil {
@@ -155,6 +180,9 @@ func (h *Handler) UpdateByName(c *gin.Context) {
 			DepartmentName: dept.DepartmentName,
 			MaxClockIn:     dept.MaxClockInTime,
 			MaxClockOut:    dept.MaxClockOutTime,
+			WorkingDays:    dept.WorkingDays,
+			BreakMinutes:   dept.BreakMinutes,
+			ManagerID:      dept.ManagerEmployeeID,
 		},
 	})
 
@@ -17,30 +17,52 @@ type employeeResp struct {
 	ID         uint64         `json:"id"`
 	EmployeeID string         `json:"employee_id"`
 	Name       string         `json:"name"`
+	Email      *string        `json:"email"`
 	Address    string         `json:"address"`
+	HireDate   string         `json:"hire_date"`
+	Terminated *string        `json:"termination_date"`
+	Type       string         `json:"employment_type"`
+	Status     string         `json:"employment_status"`
 	Department departmentResp `json:"department"`
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
+	ArchivedAt *time.Time     `json:"archived_at,omitempty"`
 }
 
 type listQuery struct {
 	Search  string `form:"search"`
 	Limit   int    `form:"limit"   binding:"omitempty,min=1,max=100"`
 	Page    int    `form:"page"    binding:"omitempty,min=1"`
-	SortBy  string `form:"sortBy"  binding:"omitempty,oneof=id department_name"`
+	SortBy  string `form:"sortBy"  binding:"omitempty,oneof=id name department_name created_at"`
 	SortDir string `form:"sortDir" binding:"omitempty,oneof=asc desc"`
+	Cursor  string `form:"cursor"`
+
+	DepartmentIDs []uint64 `form:"department_id"`
+	CreatedFrom   string   `form:"created_from"`
+	CreatedTo     string   `form:"created_to"`
+	UpdatedFrom   string   `form:"updated_from"`
+	UpdatedTo     string   `form:"updated_to"`
+	ClockedIn     *bool    `form:"clocked_in"`
+	Status        string   `form:"status"     binding:"omitempty,oneof=active suspended terminated"`
+	ManagerID     string   `form:"manager_id"`
+	Archived      string   `form:"archived"   binding:"omitempty,oneof=include only"`
 }
 
 type listResponse struct {
 	Message    string            `json:"message"`
 	Data       []employeeResp    `json:"data"`
 	Pagination empsvc.Pagination `json:"pagination"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
 }
 
 type createReq struct {
 	Name       string  `json:"name" binding:"required,max=255"`
+	Email      *string `json:"email" binding:"omitempty,max=255"`
 	Address    *string `json:"address"`
 	Department uint64  `json:"department" binding:"required"`
+	HireDate   string  `json:"hire_date"`
+	Type       string  `json:"employment_type" binding:"omitempty,oneof=permanent contract intern"`
 }
 
 type createResponse struct {
@@ -55,8 +77,32 @@ type getByEmployeeIDResponse struct {
 
 type updateReq struct {
 	Name       *string `json:"name,omitempty"`
+	Email      *string `json:"email,omitempty"`
 	Address    *string `json:"address,omitempty"`
 	Department *uint64 `json:"department,omitempty"`
+	HireDate   *string `json:"hire_date,omitempty"`
+	Type       *string `json:"employment_type,omitempty" binding:"omitempty,oneof=permanent contract intern"`
+}
+
+type statusReq struct {
+	Status          string `json:"status" binding:"required,oneof=active suspended terminated"`
+	TerminationDate string `json:"termination_date"`
+}
+
+type transferReq struct {
+	Department    uint64 `json:"department" binding:"required"`
+	EffectiveDate string `json:"effective_date"`
+}
+
+type assignmentResp struct {
+	Department    departmentResp `json:"department"`
+	EffectiveFrom string         `json:"effective_from"`
+	EffectiveTo   *string        `json:"effective_to"`
+}
+
+type assignmentsResponse struct {
+	Message string           `json:"message"`
+	Data    []assignmentResp `json:"data"`
 }
 
 type updateResponse struct {
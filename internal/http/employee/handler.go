@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/itsaFan/fleetify-be/internal/appErr"
 	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
 	empSvc "github.com/itsaFan/fleetify-be/internal/service/employee"
 )
 
@@ -19,6 +20,36 @@ func New(svc empSvc.Service) *Handler {
 	return &Handler{svc: svc}
 }
 
+func toResp(emp *model.Employee) employeeResp {
+	out := employeeResp{
+		ID:         emp.ID,
+		EmployeeID: emp.EmployeeID,
+		Name:       emp.Name,
+		Email:      emp.Email,
+		Address:    emp.Address,
+		HireDate:   helper.DateKey(emp.HireDate),
+		Type:       emp.EmploymentType,
+		Status:     emp.EmploymentStatus,
+		Department: departmentResp{
+			ID:              emp.Department.ID,
+			DepartmentName:  emp.Department.DepartmentName,
+			MaxClockInTime:  emp.Department.MaxClockInTime,
+			MaxClockOutTime: emp.Department.MaxClockOutTime,
+		},
+		CreatedAt: emp.CreatedAt,
+		UpdatedAt: emp.UpdatedAt,
+	}
+	if emp.TerminationDate != nil {
+		d := helper.DateKey(*emp.TerminationDate)
+		out.Terminated = &d
+	}
+	if emp.DeletedAt.Valid {
+		t := emp.DeletedAt.Time
+		out.ArchivedAt = &t
+	}
+	return out
+}
+
 // POST
 func (h *Handler) Create(c *gin.Context) {
 	var req createReq
@@ -29,8 +60,12 @@ func (h *Handler) Create(c *gin.Context) {
 
 	input := empSvc.CreateInput{
 		Name:       helper.NormalizeStringField(req.Name),
+		Email:      req.Email,
 		Address:    req.Address,
 		Department: req.Department,
+
+		HireDate:       req.HireDate,
+		EmploymentType: req.Type,
 	}
 
 	emp, err := h.svc.Create(c.Request.Context(), input)
@@ -43,20 +78,7 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	data := employeeResp{
-		ID:         emp.ID,
-		EmployeeID: emp.EmployeeID,
-		Name:       emp.Name,
-		Address:    emp.Address,
-		Department: departmentResp{
-			ID:              emp.Department.ID,
-			DepartmentName:  emp.Department.DepartmentName,
-			MaxClockInTime:  emp.Department.MaxClockInTime,
-			MaxClockOutTime: emp.Department.MaxClockOutTime,
-		},
-		CreatedAt: emp.CreatedAt,
-		UpdatedAt: emp.UpdatedAt,
-	}
+	data := toResp(emp)
 	c.JSON(stdhttp.StatusCreated, createResponse{
 		Message: "Employee created successfully",
 		Data:    data,
@@ -77,6 +99,17 @@ func (h *Handler) List(c *gin.Context) {
 		Page:    q.Page,
 		SortBy:  q.SortBy,
 		SortDir: q.SortDir,
+		Cursor:  q.Cursor,
+
+		DepartmentIDs: q.DepartmentIDs,
+		CreatedFrom:   q.CreatedFrom,
+		CreatedTo:     q.CreatedTo,
+		UpdatedFrom:   q.UpdatedFrom,
+		UpdatedTo:     q.UpdatedTo,
+		ClockedIn:     q.ClockedIn,
+		Status:        q.Status,
+		ManagerID:     q.ManagerID,
+		Archived:      q.Archived,
 	})
 	if err != nil {
 		helper.WriteError(c, err)
@@ -85,26 +118,15 @@ func (h *Handler) List(c *gin.Context) {
 
 	data := make([]employeeResp, 0, len(out.Data))
 	for _, e := range out.Data {
-		data = append(data, employeeResp{
-			ID:         e.ID,
-			EmployeeID: e.EmployeeID,
-			Name:       e.Name,
-			Address:    e.Address,
-			Department: departmentResp{
-				ID:              e.Department.ID,
-				DepartmentName:  e.Department.DepartmentName,
-				MaxClockInTime:  e.Department.MaxClockInTime,
-				MaxClockOutTime: e.Department.MaxClockOutTime,
-			},
-			CreatedAt: e.CreatedAt,
-			UpdatedAt: e.UpdatedAt,
-		})
+		data = append(data, toResp(&e))
 	}
 
 	c.JSON(stdhttp.StatusOK, listResponse{
 		Message:    "Employees retrieved successfully",
 		Data:       data,
 		Pagination: out.Pagination,
+		NextCursor: out.NextCursor,
+		PrevCursor: out.PrevCursor,
 	})
 }
 
@@ -124,20 +146,7 @@ func (h *Handler) GetByEmployeeID(c *gin.Context) {
 		return
 	}
 
-	data := employeeResp{
-		ID:         emp.ID,
-		EmployeeID: emp.EmployeeID,
-		Name:       emp.Name,
-		Address:    emp.Address,
-		Department: departmentResp{
-			ID:              emp.Department.ID,
-			DepartmentName:  emp.Department.DepartmentName,
-			MaxClockInTime:  emp.Department.MaxClockInTime,
-			MaxClockOutTime: emp.Department.MaxClockOutTime,
-		},
-		CreatedAt: emp.CreatedAt,
-		UpdatedAt: emp.UpdatedAt,
-	}
+	data := toResp(emp)
 	c.JSON(stdhttp.StatusOK, getByEmployeeIDResponse{
 		Message: "Employee retrieved successfully",
 		Data:    data,
@@ -164,6 +173,9 @@ func (h *Handler) UpdateEmployeeByEmployeeID(c *gin.Context) {
 		in.Name = n
 	}
 
+	if req.Email != nil {
+		in.Email = req.Email
+	}
 	if req.Address != nil {
 		n := helper.NormalizeStringField(*req.Address)
 		in.Address = &n
@@ -171,6 +183,10 @@ func (h *Handler) UpdateEmployeeByEmployeeID(c *gin.Context) {
 	if req.Department != nil {
 		in.Department = *req.Department
 	}
+	if req.HireDate != nil {
+		in.HireDate = *req.HireDate
+	}
+	in.EmploymentType = req.Type
 
 	emp, err := h.svc.UpdateEmployeeByEmployeeID(c.Request.Context(), empId, in)
 	if err != nil {
@@ -178,20 +194,7 @@ func (h *Handler) UpdateEmployeeByEmployeeID(c *gin.Context) {
 		return
 	}
 
-	data := employeeResp{
-		ID:         emp.ID,
-		EmployeeID: emp.EmployeeID,
-		Name:       emp.Name,
-		Address:    emp.Address,
-		Department: departmentResp{
-			ID:              emp.Department.ID,
-			DepartmentName:  emp.Department.DepartmentName,
-			MaxClockInTime:  emp.Department.MaxClockInTime,
-			MaxClockOutTime: emp.Department.MaxClockOutTime,
-		},
-		CreatedAt: emp.CreatedAt,
-		UpdatedAt: emp.UpdatedAt,
-	}
+	data := toResp(emp)
 
 	c.JSON(stdhttp.StatusOK, updateResponse{
 		Message: "Employee updated successfully",
@@ -214,6 +217,145 @@ func (h *Handler) DeleteByEmployeeID(c *gin.Context) {
 	}
 
 	c.JSON(stdhttp.StatusOK, deleteResponse{
-		Message: "Employee deleted successfully",
+		Message: "Employee archived successfully",
+	})
+}
+
+// POST move the employee to another employment status
+func (h *Handler) ChangeStatus(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "invalid employee_id name in path")
+		return
+	}
+
+	var req statusReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	emp, err := h.svc.ChangeStatus(c.Request.Context(), empId, empSvc.StatusInput{
+		Status:          req.Status,
+		TerminationDate: req.TerminationDate,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, updateResponse{
+		Message: "Employee status updated successfully",
+		Data:    toResp(emp),
+	})
+}
+
+// POST move the employee to another department
+func (h *Handler) Transfer(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "invalid employee_id name in path")
+		return
+	}
+
+	var req transferReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	emp, err := h.svc.Transfer(c.Request.Context(), empId, empSvc.TransferInput{
+		Department:    req.Department,
+		EffectiveDate: req.EffectiveDate,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, updateResponse{
+		Message: "Employee transferred successfully",
+		Data:    toResp(emp),
+	})
+}
+
+// GET department history, oldest first
+func (h *Handler) ListAssignments(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "invalid employee_id name in path")
+		return
+	}
+
+	items, err := h.svc.ListAssignments(c.Request.Context(), empId)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]assignmentResp, 0, len(items))
+	for _, a := range items {
+		it := assignmentResp{
+			Department: departmentResp{
+				ID:              a.Department.ID,
+				DepartmentName:  a.Department.DepartmentName,
+				MaxClockInTime:  a.Department.MaxClockInTime,
+				MaxClockOutTime: a.Department.MaxClockOutTime,
+			},
+			EffectiveFrom: helper.DateKey(a.EffectiveFrom),
+		}
+		if a.EffectiveTo != nil {
+			d := helper.DateKey(*a.EffectiveTo)
+			it.EffectiveTo = &d
+		}
+		data = append(data, it)
+	}
+
+	c.JSON(stdhttp.StatusOK, assignmentsResponse{
+		Message: "Employee assignments retrieved successfully",
+		Data:    data,
+	})
+}
+
+// POST restore an archived employee
+func (h *Handler) RestoreByEmployeeID(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "invalid employee_id name in path")
+		return
+	}
+
+	emp, err := h.svc.RestoreByEmployeeID(c.Request.Context(), empId)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, updateResponse{
+		Message: "Employee restored successfully",
+		Data:    toResp(emp),
+	})
+}
+
+// DELETE an archived employee and its attendance for good, admin only
+func (h *Handler) PurgeByEmployeeID(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "invalid employee_id name in path")
+		return
+	}
+
+	if err := h.svc.PurgeByEmployeeID(c.Request.Context(), empId); err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, deleteResponse{
+		Message: "Employee purged successfully",
 	})
 }
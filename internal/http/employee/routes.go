@@ -11,5 +11,18 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 		employee.GET("/:employee_id", h.GetByEmployeeID)
 		employee.PATCH("/:employee_id", h.UpdateEmployeeByEmployeeID)
 		employee.DELETE("/:employee_id", h.DeleteByEmployeeID)
+		employee.POST("/:employee_id/status", h.ChangeStatus)
+		employee.POST("/:employee_id/transfer", h.Transfer)
+		employee.GET("/:employee_id/assignments", h.ListAssignments)
+		employee.POST("/:employee_id/restore", h.RestoreByEmployeeID)
+	}
+}
+
+// RegisterAdmin mounts the admin endpoints, rg is expected to be guarded.
+func (h *Handler) RegisterAdmin(rg *gin.RouterGroup) {
+	employee := rg.Group("/employee")
+
+	{
+		employee.DELETE("/:employee_id/purge", h.PurgeByEmployeeID)
 	}
 }
@@ -0,0 +1,76 @@
+package holiday
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	holidaysvc "github.com/itsaFan/fleetify-be/internal/service/holiday"
+)
+
+type holidayResp struct {
+	ID             uint64  `json:"id"`
+	Date           string  `json:"date"`
+	Name           string  `json:"name"`
+	Kind           string  `json:"kind"`
+	DepartmentID   *uint64 `json:"department_id"`
+	DepartmentName *string `json:"department_name,omitempty"`
+	Source         string  `json:"source"`
+}
+
+type listQuery struct {
+	From       string  `form:"from"`
+	To         string  `form:"to"`
+	Department *uint64 `form:"dept_id"`
+	Kind       string  `form:"kind"    binding:"omitempty,oneof=public closure"`
+	Limit      int     `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Page       int     `form:"page"    binding:"omitempty,min=1"`
+}
+
+type listResponse struct {
+	Message    string            `json:"message"`
+	Data       []holidayResp     `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
+
+type createReq struct {
+	Date         string  `json:"date" binding:"required"`
+	Name         string  `json:"name" binding:"required,max=255"`
+	Kind         string  `json:"kind" binding:"omitempty,oneof=public closure"`
+	DepartmentID *uint64 `json:"department_id"`
+}
+
+type createResponse struct {
+	Message string      `json:"message"`
+	Data    holidayResp `json:"data"`
+}
+
+type getByIDResponse struct {
+	Message string      `json:"message"`
+	Data    holidayResp `json:"data"`
+}
+
+type updateReq struct {
+	Date         *string `json:"date,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Kind         *string `json:"kind,omitempty"`
+	DepartmentID *uint64 `json:"department_id,omitempty"`
+	// true turns a department closure into a company wide holiday
+	CompanyWide bool `json:"company_wide,omitempty"`
+}
+
+type updateResponse struct {
+	Message string      `json:"message"`
+	Data    holidayResp `json:"data"`
+}
+
+type deleteResponse struct {
+	Message string `json:"message"`
+}
+
+type importForm struct {
+	Kind       string  `form:"kind"    binding:"omitempty,oneof=public closure"`
+	Department *uint64 `form:"dept_id"`
+}
+
+type importResponse struct {
+	Message string                  `json:"message"`
+	Data    holidaysvc.ImportOutput `json:"data"`
+}
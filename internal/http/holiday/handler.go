@@ -0,0 +1,206 @@
+package holiday
+
+import (
+	stdhttp "net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	holidaySvc "github.com/itsaFan/fleetify-be/internal/service/holiday"
+)
+
+// 2 MB is plenty for a yearly holiday calendar
+const maxICSBytes = 2 << 20
+
+type Handler struct {
+	svc holidaySvc.Service
+}
+
+func New(svc holidaySvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toResp(h *model.Holiday) holidayResp {
+	out := holidayResp{
+		ID:           h.ID,
+		Date:         helper.DateKey(h.HolidayDate),
+		Name:         h.Name,
+		Kind:         h.Kind,
+		DepartmentID: h.DepartmentID,
+		Source:       h.Source,
+	}
+	if h.Department != nil {
+		n := h.Department.DepartmentName
+		out.DepartmentName = &n
+	}
+	return out
+}
+
+func parseID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		helper.BadRequest(c, "invalid holiday id in path")
+		return 0, false
+	}
+	return id, true
+}
+
+// POST
+func (h *Handler) Create(c *gin.Context) {
+	var req createReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	hol, err := h.svc.Create(c.Request.Context(), holidaySvc.CreateInput{
+		Date:         req.Date,
+		Name:         req.Name,
+		Kind:         req.Kind,
+		DepartmentID: req.DepartmentID,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusCreated, createResponse{
+		Message: "Holiday created successfully",
+		Data:    toResp(hol),
+	})
+}
+
+// POST multipart, field "file" holds the .ics calendar
+func (h *Handler) ImportICS(c *gin.Context) {
+	c.Request.Body = stdhttp.MaxBytesReader(c.Writer, c.Request.Body, maxICSBytes)
+
+	var form importForm
+	if err := c.ShouldBind(&form); err != nil {
+		helper.BadRequest(c, "invalid form fields")
+		return
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		helper.BadRequest(c, "file is required (iCalendar .ics)")
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		helper.BadRequest(c, "cannot read uploaded file")
+		return
+	}
+	defer f.Close()
+
+	out, err := h.svc.ImportICS(c.Request.Context(), f, holidaySvc.ImportInput{
+		Kind:         form.Kind,
+		DepartmentID: form.Department,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusCreated, importResponse{
+		Message: "Holidays imported successfully",
+		Data:    *out,
+	})
+}
+
+// GET List with flex q
+func (h *Handler) List(c *gin.Context) {
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.List(c.Request.Context(), holidaySvc.ListInput{
+		From:         q.From,
+		To:           q.To,
+		DepartmentID: q.Department,
+		Kind:         q.Kind,
+		Limit:        q.Limit,
+		Page:         q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]holidayResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message:    "Holidays retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
+
+func (h *Handler) GetByID(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	hol, err := h.svc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, getByIDResponse{
+		Message: "Holiday retrieved successfully",
+		Data:    toResp(hol),
+	})
+}
+
+func (h *Handler) UpdateByID(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req updateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	hol, err := h.svc.UpdateByID(c.Request.Context(), id, holidaySvc.UpdateInput{
+		Date:            req.Date,
+		Name:            req.Name,
+		Kind:            req.Kind,
+		DepartmentID:    req.DepartmentID,
+		ClearDepartment: req.CompanyWide,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, updateResponse{
+		Message: "Holiday updated successfully",
+		Data:    toResp(hol),
+	})
+}
+
+func (h *Handler) DeleteByID(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteByID(c.Request.Context(), id); err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, deleteResponse{
+		Message: "Holiday deleted successfully",
+	})
+}
@@ -0,0 +1,16 @@
+package holiday
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	holidays := rg.Group("/holidays")
+
+	{
+		holidays.POST("", h.Create)
+		holidays.POST("/import", h.ImportICS)
+		holidays.GET("", h.List)
+		holidays.GET("/:id", h.GetByID)
+		holidays.PATCH("/:id", h.UpdateByID)
+		holidays.DELETE("/:id", h.DeleteByID)
+	}
+}
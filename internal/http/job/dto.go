@@ -0,0 +1,56 @@
+package job
+
+import (
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+)
+
+type jobResp struct {
+	Name             string     `json:"name"`
+	Schedule         string     `json:"schedule"`
+	Timezone         string     `json:"timezone"`
+	Description      *string    `json:"description"`
+	Paused           bool       `json:"paused"`
+	TriggerRequested bool       `json:"trigger_requested"`
+	Running          bool       `json:"running"`
+	RunningOn        *string    `json:"running_on"`
+	NextRunAt        *time.Time `json:"next_run_at"`
+	LastRunAt        *time.Time `json:"last_run_at"`
+	LastStatus       *string    `json:"last_status"`
+}
+
+type runResp struct {
+	RunID      string     `json:"run_id"`
+	JobName    string     `json:"job_name"`
+	Trigger    string     `json:"trigger"`
+	Owner      string     `json:"owner"`
+	Status     string     `json:"status"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	DurationMs *int64     `json:"duration_ms"`
+	Output     *string    `json:"output"`
+	Error      *string    `json:"error"`
+}
+
+type jobResponse struct {
+	Message string  `json:"message"`
+	Data    jobResp `json:"data"`
+}
+
+type listResponse struct {
+	Message string    `json:"message"`
+	Data    []jobResp `json:"data"`
+}
+
+type runListQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=running succeeded failed abandoned"`
+	Limit  int    `form:"limit"  binding:"omitempty,min=1,max=100"`
+	Page   int    `form:"page"   binding:"omitempty,min=1"`
+}
+
+type runListResponse struct {
+	Message    string            `json:"message"`
+	Data       []runResp         `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
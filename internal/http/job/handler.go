@@ -0,0 +1,145 @@
+package job
+
+import (
+	"context"
+	stdhttp "net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	jobsvc "github.com/itsaFan/fleetify-be/internal/service/job"
+)
+
+type Handler struct {
+	svc jobsvc.Service
+}
+
+func New(svc jobsvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toResp(j *model.ScheduledJob) jobResp {
+	out := jobResp{
+		Name:             j.Name,
+		Schedule:         j.Schedule,
+		Timezone:         j.Timezone,
+		Description:      j.Description,
+		Paused:           j.Paused,
+		TriggerRequested: j.TriggerRequested,
+		NextRunAt:        j.NextRunAt,
+		LastRunAt:        j.LastRunAt,
+		LastStatus:       j.LastStatus,
+	}
+	if j.LeaseUntil != nil && j.LeaseUntil.After(time.Now()) {
+		out.Running = true
+		out.RunningOn = j.LeaseOwner
+	}
+	return out
+}
+
+func toRunResp(r *model.JobRun) runResp {
+	return runResp{
+		RunID:      r.RunID,
+		JobName:    r.JobName,
+		Trigger:    r.Trigger,
+		Owner:      r.Owner,
+		Status:     r.Status,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		DurationMs: r.DurationMs,
+		Output:     r.Output,
+		Error:      r.Error,
+	}
+}
+
+func (h *Handler) List(c *gin.Context) {
+	items, err := h.svc.List(c.Request.Context())
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]jobResp, len(items))
+	for i := range items {
+		data[i] = toResp(&items[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message: "Jobs retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *Handler) Get(c *gin.Context) {
+	j, err := h.svc.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, jobResponse{
+		Message: "Job retrieved successfully",
+		Data:    toResp(j),
+	})
+}
+
+func (h *Handler) Pause(c *gin.Context) {
+	h.act(c, h.svc.Pause, stdhttp.StatusOK, "Job paused")
+}
+
+func (h *Handler) Resume(c *gin.Context) {
+	h.act(c, h.svc.Resume, stdhttp.StatusOK, "Job resumed")
+}
+
+func (h *Handler) Trigger(c *gin.Context) {
+	h.act(c, h.svc.Trigger, stdhttp.StatusAccepted, "Job run requested")
+}
+
+func (h *Handler) act(
+	c *gin.Context,
+	fn func(ctx context.Context, name string) (*model.ScheduledJob, error),
+	status int,
+	msg string,
+) {
+	j, err := fn(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(status, jobResponse{
+		Message: msg,
+		Data:    toResp(j),
+	})
+}
+
+func (h *Handler) ListRuns(c *gin.Context) {
+	var q runListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.ListRuns(c.Request.Context(), jobsvc.RunListInput{
+		JobName: c.Param("name"),
+		Status:  q.Status,
+		Limit:   q.Limit,
+		Page:    q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]runResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toRunResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, runListResponse{
+		Message:    "Job runs retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
@@ -0,0 +1,17 @@
+package job
+
+import "github.com/gin-gonic/gin"
+
+// Register mounts the job endpoints, rg is expected to be guarded.
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	jobs := rg.Group("/jobs")
+
+	{
+		jobs.GET("", h.List)
+		jobs.GET("/:name", h.Get)
+		jobs.GET("/:name/runs", h.ListRuns)
+		jobs.POST("/:name/trigger", h.Trigger)
+		jobs.POST("/:name/pause", h.Pause)
+		jobs.POST("/:name/resume", h.Resume)
+	}
+}
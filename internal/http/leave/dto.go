@@ -0,0 +1,100 @@
+package leave
+
+import (
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	leavesvc "github.com/itsaFan/fleetify-be/internal/service/leave"
+)
+
+type leaveTypeResp struct {
+	ID          uint64 `json:"id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	YearlyQuota int    `json:"yearly_quota"`
+}
+
+type createTypeReq struct {
+	Code        string `json:"code" binding:"required,max=50"`
+	Name        string `json:"name" binding:"required,max=255"`
+	YearlyQuota int    `json:"yearly_quota" binding:"omitempty,min=0,max=366"`
+}
+
+type updateTypeReq struct {
+	Code        *string `json:"code,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	YearlyQuota *int    `json:"yearly_quota,omitempty"`
+}
+
+type leaveTypeResponse struct {
+	Message string        `json:"message"`
+	Data    leaveTypeResp `json:"data"`
+}
+
+type listTypesResponse struct {
+	Message string          `json:"message"`
+	Data    []leaveTypeResp `json:"data"`
+}
+
+type leaveRequestResp struct {
+	RequestID    string        `json:"request_id"`
+	EmployeeID   string        `json:"employee_id"`
+	EmployeeName string        `json:"employee_name"`
+	LeaveType    leaveTypeResp `json:"leave_type"`
+	StartDate    string        `json:"start_date"`
+	EndDate      string        `json:"end_date"`
+	Days         int           `json:"days"`
+	Reason       string        `json:"reason"`
+	Status       string        `json:"status"`
+	ApproverID   *string       `json:"approver_id"`
+	DecisionNote *string       `json:"decision_note"`
+	DecidedAt    *time.Time    `json:"decided_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type submitReq struct {
+	EmployeeID  string `json:"employee_id" binding:"required"`
+	LeaveTypeID uint64 `json:"leave_type_id" binding:"required"`
+	StartDate   string `json:"start_date" binding:"required"`
+	EndDate     string `json:"end_date" binding:"required"`
+	Reason      string `json:"reason"`
+}
+
+type decisionReq struct {
+	ApproverID string  `json:"approver_id" binding:"required"`
+	Note       *string `json:"note"`
+}
+
+type leaveRequestResponse struct {
+	Message string           `json:"message"`
+	Data    leaveRequestResp `json:"data"`
+}
+
+type listQuery struct {
+	EmployeeID string  `form:"employee_id"`
+	Department *uint64 `form:"dept_id"`
+	Status     string  `form:"status"  binding:"omitempty,oneof=pending approved rejected"`
+	From       string  `form:"from"`
+	To         string  `form:"to"`
+	Limit      int     `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Page       int     `form:"page"    binding:"omitempty,min=1"`
+}
+
+type listResponse struct {
+	Message    string             `json:"message"`
+	Data       []leaveRequestResp `json:"data"`
+	Pagination helper.Pagination  `json:"pagination"`
+}
+
+type balanceQuery struct {
+	Year int `form:"year" binding:"omitempty,min=2000,max=2100"`
+}
+
+type balanceResponse struct {
+	Message string                 `json:"message"`
+	Data    leavesvc.BalanceOutput `json:"data"`
+}
+
+type deleteResponse struct {
+	Message string `json:"message"`
+}
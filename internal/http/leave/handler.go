@@ -0,0 +1,273 @@
+package leave
+
+import (
+	"context"
+	stdhttp "net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	leaveSvc "github.com/itsaFan/fleetify-be/internal/service/leave"
+)
+
+type Handler struct {
+	svc leaveSvc.Service
+}
+
+func New(svc leaveSvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toTypeResp(lt *model.LeaveType) leaveTypeResp {
+	return leaveTypeResp{
+		ID:          lt.ID,
+		Code:        lt.Code,
+		Name:        lt.Name,
+		YearlyQuota: lt.YearlyQuota,
+	}
+}
+
+func toRequestResp(r *model.LeaveRequest) leaveRequestResp {
+	return leaveRequestResp{
+		RequestID:    r.RequestID,
+		EmployeeID:   r.EmployeeID,
+		EmployeeName: r.Employee.Name,
+		LeaveType:    toTypeResp(&r.LeaveType),
+		StartDate:    helper.DateKey(r.StartDate),
+		EndDate:      helper.DateKey(r.EndDate),
+		Days:         r.Days,
+		Reason:       r.Reason,
+		Status:       r.Status,
+		ApproverID:   r.ApproverID,
+		DecisionNote: r.DecisionNote,
+		DecidedAt:    r.DecidedAt,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func parseTypeID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		helper.BadRequest(c, "invalid leave type id in path")
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) CreateType(c *gin.Context) {
+	var req createTypeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	lt, err := h.svc.CreateType(c.Request.Context(), leaveSvc.CreateTypeInput{
+		Code:        req.Code,
+		Name:        req.Name,
+		YearlyQuota: req.YearlyQuota,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusCreated, leaveTypeResponse{
+		Message: "Leave type created successfully",
+		Data:    toTypeResp(lt),
+	})
+}
+
+func (h *Handler) ListTypes(c *gin.Context) {
+	items, err := h.svc.ListTypes(c.Request.Context())
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]leaveTypeResp, len(items))
+	for i := range items {
+		data[i] = toTypeResp(&items[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listTypesResponse{
+		Message: "Leave types retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *Handler) UpdateType(c *gin.Context) {
+	id, ok := parseTypeID(c)
+	if !ok {
+		return
+	}
+
+	var req updateTypeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	lt, err := h.svc.UpdateTypeByID(c.Request.Context(), id, leaveSvc.UpdateTypeInput{
+		Code:        req.Code,
+		Name:        req.Name,
+		YearlyQuota: req.YearlyQuota,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, leaveTypeResponse{
+		Message: "Leave type updated successfully",
+		Data:    toTypeResp(lt),
+	})
+}
+
+func (h *Handler) DeleteType(c *gin.Context) {
+	id, ok := parseTypeID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteTypeByID(c.Request.Context(), id); err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, deleteResponse{
+		Message: "Leave type deleted successfully",
+	})
+}
+
+func (h *Handler) Submit(c *gin.Context) {
+	var req submitReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	lr, err := h.svc.Submit(c.Request.Context(), leaveSvc.SubmitInput{
+		EmployeeID:  req.EmployeeID,
+		LeaveTypeID: req.LeaveTypeID,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusCreated, leaveRequestResponse{
+		Message: "Leave request submitted successfully",
+		Data:    toRequestResp(lr),
+	})
+}
+
+func (h *Handler) ListRequests(c *gin.Context) {
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.ListRequests(c.Request.Context(), leaveSvc.ListInput{
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.Department,
+		Status:       q.Status,
+		From:         q.From,
+		To:           q.To,
+		Limit:        q.Limit,
+		Page:         q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]leaveRequestResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toRequestResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message:    "Leave requests retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
+
+func (h *Handler) GetRequest(c *gin.Context) {
+	lr, err := h.svc.GetByRequestID(c.Request.Context(), c.Param("request_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, leaveRequestResponse{
+		Message: "Leave request retrieved successfully",
+		Data:    toRequestResp(lr),
+	})
+}
+
+func (h *Handler) Approve(c *gin.Context) {
+	h.decide(c, h.svc.Approve, "Leave request approved")
+}
+
+func (h *Handler) Reject(c *gin.Context) {
+	h.decide(c, h.svc.Reject, "Leave request rejected")
+}
+
+func (h *Handler) decide(
+	c *gin.Context,
+	fn func(ctx context.Context, requestID string, in leaveSvc.DecisionInput) (*model.LeaveRequest, error),
+	msg string,
+) {
+	var req decisionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	lr, err := fn(c.Request.Context(), c.Param("request_id"), leaveSvc.DecisionInput{
+		ApproverID: req.ApproverID,
+		Note:       req.Note,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, leaveRequestResponse{
+		Message: msg,
+		Data:    toRequestResp(lr),
+	})
+}
+
+func (h *Handler) Balance(c *gin.Context) {
+	raw := c.Param("employee_id")
+	empId, err := url.PathUnescape(raw)
+	if err != nil {
+		helper.BadRequest(c, "Invalid employee_id name in path")
+		return
+	}
+
+	var q balanceQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.Balance(c.Request.Context(), empId, q.Year)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, balanceResponse{
+		Message: "Leave balance retrieved successfully",
+		Data:    *out,
+	})
+}
@@ -0,0 +1,22 @@
+package leave
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	leave := rg.Group("/leave")
+
+	{
+		leave.POST("/types", h.CreateType)
+		leave.GET("/types", h.ListTypes)
+		leave.PATCH("/types/:id", h.UpdateType)
+		leave.DELETE("/types/:id", h.DeleteType)
+
+		leave.POST("/requests", h.Submit)
+		leave.GET("/requests", h.ListRequests)
+		leave.GET("/requests/:request_id", h.GetRequest)
+		leave.POST("/requests/:request_id/approve", h.Approve)
+		leave.POST("/requests/:request_id/reject", h.Reject)
+
+		leave.GET("/employee/:employee_id/balance", h.Balance)
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+)
+
+// AdminOnly checks the X-Admin-Token header against ADMIN_TOKEN. When no
+// token is configured every admin request is rejected.
+func AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		want := config.AdminToken()
+		got := c.GetHeader("X-Admin-Token")
+		if want == "" || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			helper.Unauthorized(c, "invalid or missing admin token")
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package notification
+
+import (
+	"time"
+
+	notifsvc "github.com/itsaFan/fleetify-be/internal/service/notification"
+)
+
+type preferencesResp struct {
+	EmployeeID       string     `json:"employee_id"`
+	DailyDigest      bool       `json:"daily_digest"`
+	ClockOutReminder bool       `json:"clock_out_reminder"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+type preferencesReq struct {
+	DailyDigest      *bool `json:"daily_digest"`
+	ClockOutReminder *bool `json:"clock_out_reminder"`
+}
+
+type preferencesResponse struct {
+	Message string          `json:"message"`
+	Data    preferencesResp `json:"data"`
+}
+
+type digestReq struct {
+	Date string `json:"date"`
+}
+
+type digestResponse struct {
+	Message string                `json:"message"`
+	Data    notifsvc.DigestOutput `json:"data"`
+}
+
+type reminderResponse struct {
+	Message string                  `json:"message"`
+	Data    notifsvc.ReminderOutput `json:"data"`
+}
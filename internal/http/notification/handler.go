@@ -0,0 +1,101 @@
+package notification
+
+import (
+	stdhttp "net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	notifsvc "github.com/itsaFan/fleetify-be/internal/service/notification"
+)
+
+type Handler struct {
+	svc notifsvc.Service
+}
+
+func New(svc notifsvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toResp(p *model.NotificationPreference) preferencesResp {
+	out := preferencesResp{
+		EmployeeID:       p.EmployeeID,
+		DailyDigest:      p.DailyDigest,
+		ClockOutReminder: p.ClockOutReminder,
+	}
+	if !p.UpdatedAt.IsZero() {
+		t := p.UpdatedAt
+		out.UpdatedAt = &t
+	}
+	return out
+}
+
+func (h *Handler) GetPreferences(c *gin.Context) {
+	p, err := h.svc.GetPreferences(c.Request.Context(), c.Param("employee_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, preferencesResponse{
+		Message: "Notification preferences retrieved successfully",
+		Data:    toResp(p),
+	})
+}
+
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	var req preferencesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	p, err := h.svc.UpdatePreferences(c.Request.Context(), c.Param("employee_id"), notifsvc.PreferencesInput{
+		DailyDigest:      req.DailyDigest,
+		ClockOutReminder: req.ClockOutReminder,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, preferencesResponse{
+		Message: "Notification preferences updated successfully",
+		Data:    toResp(p),
+	})
+}
+
+// SendDigests runs the daily digest by hand, body is optional
+func (h *Handler) SendDigests(c *gin.Context) {
+	var req digestReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			helper.BadRequest(c, "invalid JSON body")
+			return
+		}
+	}
+
+	out, err := h.svc.SendDailyDigests(c.Request.Context(), notifsvc.DigestInput{Date: req.Date})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, digestResponse{
+		Message: "Daily digests processed",
+		Data:    *out,
+	})
+}
+
+func (h *Handler) SendReminders(c *gin.Context) {
+	out, err := h.svc.SendClockOutReminders(c.Request.Context())
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, reminderResponse{
+		Message: "Clock-out reminders processed",
+		Data:    *out,
+	})
+}
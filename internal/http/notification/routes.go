@@ -0,0 +1,23 @@
+package notification
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	// lives under /employee, the employee handler owns the rest of that group
+	employee := rg.Group("/employee")
+
+	{
+		employee.GET("/:employee_id/notification-preferences", h.GetPreferences)
+		employee.PUT("/:employee_id/notification-preferences", h.UpdatePreferences)
+	}
+}
+
+// RegisterAdmin mounts the admin endpoints, rg is expected to be guarded.
+func (h *Handler) RegisterAdmin(rg *gin.RouterGroup) {
+	notifications := rg.Group("/notifications")
+
+	{
+		notifications.POST("/digest", h.SendDigests)
+		notifications.POST("/reminders", h.SendReminders)
+	}
+}
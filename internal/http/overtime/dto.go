@@ -0,0 +1,86 @@
+package overtime
+
+import (
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	otsvc "github.com/itsaFan/fleetify-be/internal/service/overtime"
+)
+
+type overtimeResp struct {
+	OvertimeID   string     `json:"overtime_id"`
+	EmployeeID   string     `json:"employee_id"`
+	EmployeeName string     `json:"employee_name"`
+	AttendanceID string     `json:"attendance_id"`
+	WorkDate     string     `json:"work_date"`
+	DayKind      string     `json:"day_kind"`
+	RawMinutes   int        `json:"raw_minutes"`
+	Minutes      int        `json:"minutes"`
+	Multiplier   float64    `json:"multiplier"`
+	Status       string     `json:"status"`
+	ApproverID   *string    `json:"approver_id"`
+	DecisionNote *string    `json:"decision_note"`
+	DecidedAt    *time.Time `json:"decided_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type computeReq struct {
+	EmployeeID   string  `json:"employee_id"`
+	DepartmentID *uint64 `json:"dept_id"`
+	From         string  `json:"from" binding:"required"`
+	To           string  `json:"to" binding:"required"`
+	TZ           string  `json:"tz"`
+}
+
+type computeData struct {
+	From           string         `json:"from"`
+	To             string         `json:"to"`
+	TZUsed         string         `json:"tz_used"`
+	Created        []overtimeResp `json:"created"`
+	Existing       int            `json:"existing"`
+	BelowThreshold int            `json:"below_threshold"`
+	Capped         int            `json:"capped"`
+}
+
+type computeResponse struct {
+	Message string      `json:"message"`
+	Data    computeData `json:"data"`
+}
+
+type decisionReq struct {
+	ApproverID string  `json:"approver_id" binding:"required"`
+	Note       *string `json:"note"`
+}
+
+type overtimeResponse struct {
+	Message string       `json:"message"`
+	Data    overtimeResp `json:"data"`
+}
+
+type listQuery struct {
+	EmployeeID string  `form:"employee_id"`
+	Department *uint64 `form:"dept_id"`
+	Status     string  `form:"status"  binding:"omitempty,oneof=pending approved rejected"`
+	From       string  `form:"from"`
+	To         string  `form:"to"`
+	Limit      int     `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Page       int     `form:"page"    binding:"omitempty,min=1"`
+}
+
+type listResponse struct {
+	Message    string            `json:"message"`
+	Data       []overtimeResp    `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
+
+type totalsQuery struct {
+	EmployeeID string  `form:"employee_id"`
+	Department *uint64 `form:"dept_id"`
+	From       string  `form:"from" binding:"required"`
+	To         string  `form:"to" binding:"required"`
+}
+
+type totalsResponse struct {
+	Message string             `json:"message"`
+	Data    otsvc.TotalsOutput `json:"data"`
+}
@@ -0,0 +1,180 @@
+package overtime
+
+import (
+	"context"
+	stdhttp "net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	otsvc "github.com/itsaFan/fleetify-be/internal/service/overtime"
+)
+
+type Handler struct {
+	svc otsvc.Service
+}
+
+func New(svc otsvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toResp(o *model.OvertimeRecord) overtimeResp {
+	return overtimeResp{
+		OvertimeID:   o.OvertimeID,
+		EmployeeID:   o.EmployeeID,
+		EmployeeName: o.Employee.Name,
+		AttendanceID: o.AttendanceID,
+		WorkDate:     helper.DateKey(o.WorkDate),
+		DayKind:      o.DayKind,
+		RawMinutes:   o.RawMinutes,
+		Minutes:      o.Minutes,
+		Multiplier:   o.Multiplier,
+		Status:       o.Status,
+		ApproverID:   o.ApproverID,
+		DecisionNote: o.DecisionNote,
+		DecidedAt:    o.DecidedAt,
+		CreatedAt:    o.CreatedAt,
+	}
+}
+
+func (h *Handler) Compute(c *gin.Context) {
+	var req computeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	out, err := h.svc.Compute(c.Request.Context(), otsvc.ComputeInput{
+		EmployeeID:   req.EmployeeID,
+		DepartmentID: req.DepartmentID,
+		From:         req.From,
+		To:           req.To,
+		TZ:           req.TZ,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	created := make([]overtimeResp, len(out.Created))
+	for i := range out.Created {
+		created[i] = toResp(&out.Created[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, computeResponse{
+		Message: "Overtime computed successfully",
+		Data: computeData{
+			From:           out.From,
+			To:             out.To,
+			TZUsed:         out.TZUsed,
+			Created:        created,
+			Existing:       out.Existing,
+			BelowThreshold: out.BelowThreshold,
+			Capped:         out.Capped,
+		},
+	})
+}
+
+func (h *Handler) List(c *gin.Context) {
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.List(c.Request.Context(), otsvc.ListInput{
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.Department,
+		Status:       q.Status,
+		From:         q.From,
+		To:           q.To,
+		Limit:        q.Limit,
+		Page:         q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]overtimeResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message:    "Overtime records retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
+
+func (h *Handler) Get(c *gin.Context) {
+	o, err := h.svc.GetByOvertimeID(c.Request.Context(), c.Param("overtime_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, overtimeResponse{
+		Message: "Overtime record retrieved successfully",
+		Data:    toResp(o),
+	})
+}
+
+func (h *Handler) Approve(c *gin.Context) {
+	h.decide(c, h.svc.Approve, "Overtime approved")
+}
+
+func (h *Handler) Reject(c *gin.Context) {
+	h.decide(c, h.svc.Reject, "Overtime rejected")
+}
+
+func (h *Handler) decide(
+	c *gin.Context,
+	fn func(ctx context.Context, overtimeID string, in otsvc.DecisionInput) (*model.OvertimeRecord, error),
+	msg string,
+) {
+	var req decisionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	o, err := fn(c.Request.Context(), c.Param("overtime_id"), otsvc.DecisionInput{
+		ApproverID: req.ApproverID,
+		Note:       req.Note,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, overtimeResponse{
+		Message: msg,
+		Data:    toResp(o),
+	})
+}
+
+func (h *Handler) Totals(c *gin.Context) {
+	var q totalsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.ApprovedTotals(c.Request.Context(), otsvc.TotalsInput{
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.Department,
+		From:         q.From,
+		To:           q.To,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, totalsResponse{
+		Message: "Approved overtime totals retrieved successfully",
+		Data:    *out,
+	})
+}
@@ -0,0 +1,16 @@
+package overtime
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	overtime := rg.Group("/overtime")
+
+	{
+		overtime.POST("/compute", h.Compute)
+		overtime.GET("", h.List)
+		overtime.GET("/totals", h.Totals)
+		overtime.GET("/:overtime_id", h.Get)
+		overtime.POST("/:overtime_id/approve", h.Approve)
+		overtime.POST("/:overtime_id/reject", h.Reject)
+	}
+}
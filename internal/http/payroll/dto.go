@@ -0,0 +1,50 @@
+package payroll
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/model"
+	payrollsvc "github.com/itsaFan/fleetify-be/internal/service/payroll"
+)
+
+type createReq struct {
+	Name             string                `json:"name" binding:"required,max=100"`
+	Description      *string               `json:"description"`
+	Delimiter        *string               `json:"delimiter"`
+	IncludeHeader    *bool                 `json:"include_header"`
+	DateFormat       string                `json:"date_format"`
+	DecimalSeparator string                `json:"decimal_separator"`
+	UseCRLF          *bool                 `json:"use_crlf"`
+	Columns          []model.PayrollColumn `json:"columns" binding:"required,min=1"`
+}
+
+type updateReq struct {
+	Name             *string               `json:"name,omitempty"`
+	Description      *string               `json:"description,omitempty"`
+	Delimiter        *string               `json:"delimiter,omitempty"`
+	IncludeHeader    *bool                 `json:"include_header,omitempty"`
+	DateFormat       *string               `json:"date_format,omitempty"`
+	DecimalSeparator *string               `json:"decimal_separator,omitempty"`
+	UseCRLF          *bool                 `json:"use_crlf,omitempty"`
+	Columns          []model.PayrollColumn `json:"columns,omitempty"`
+}
+
+type templateResponse struct {
+	Message string              `json:"message"`
+	Data    payrollsvc.Template `json:"data"`
+}
+
+type listResponse struct {
+	Message string                `json:"message"`
+	Data    []payrollsvc.Template `json:"data"`
+}
+
+type deleteResponse struct {
+	Message string `json:"message"`
+}
+
+type exportQuery struct {
+	Template   string  `form:"template" binding:"omitempty"`
+	Department *uint64 `form:"dept_id" binding:"omitempty"`
+	From       string  `form:"from" binding:"required"`
+	To         string  `form:"to" binding:"required"`
+	TZ         string  `form:"tz" binding:"omitempty"`
+}
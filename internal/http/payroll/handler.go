@@ -0,0 +1,153 @@
+package payroll
+
+import (
+	"fmt"
+	stdhttp "net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	payrollsvc "github.com/itsaFan/fleetify-be/internal/service/payroll"
+)
+
+type Handler struct {
+	svc payrollsvc.Service
+}
+
+func New(svc payrollsvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	var req createReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	t, err := h.svc.CreateTemplate(c.Request.Context(), payrollsvc.CreateTemplateInput{
+		Name:             req.Name,
+		Description:      req.Description,
+		Delimiter:        req.Delimiter,
+		IncludeHeader:    req.IncludeHeader,
+		DateFormat:       req.DateFormat,
+		DecimalSeparator: req.DecimalSeparator,
+		UseCRLF:          req.UseCRLF,
+		Columns:          req.Columns,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusCreated, templateResponse{
+		Message: "Payroll template created successfully",
+		Data:    *t,
+	})
+}
+
+func (h *Handler) ListTemplates(c *gin.Context) {
+	items, err := h.svc.ListTemplates(c.Request.Context())
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message: "Payroll templates retrieved successfully",
+		Data:    items,
+	})
+}
+
+func (h *Handler) GetTemplate(c *gin.Context) {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		helper.BadRequest(c, "invalid template name in path")
+		return
+	}
+
+	t, err := h.svc.GetTemplate(c.Request.Context(), name)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, templateResponse{
+		Message: "Payroll template retrieved successfully",
+		Data:    *t,
+	})
+}
+
+func (h *Handler) UpdateTemplate(c *gin.Context) {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		helper.BadRequest(c, "invalid template name in path")
+		return
+	}
+
+	var req updateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	t, err := h.svc.UpdateTemplate(c.Request.Context(), name, payrollsvc.UpdateTemplateInput{
+		Name:             req.Name,
+		Description:      req.Description,
+		Delimiter:        req.Delimiter,
+		IncludeHeader:    req.IncludeHeader,
+		DateFormat:       req.DateFormat,
+		DecimalSeparator: req.DecimalSeparator,
+		UseCRLF:          req.UseCRLF,
+		Columns:          req.Columns,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, templateResponse{
+		Message: "Payroll template updated successfully",
+		Data:    *t,
+	})
+}
+
+func (h *Handler) DeleteTemplate(c *gin.Context) {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		helper.BadRequest(c, "invalid template name in path")
+		return
+	}
+
+	if err := h.svc.DeleteTemplate(c.Request.Context(), name); err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, deleteResponse{
+		Message: "Payroll template deleted successfully",
+	})
+}
+
+func (h *Handler) Export(c *gin.Context) {
+	var q exportQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.Export(c.Request.Context(), payrollsvc.ExportInput{
+		Template:     q.Template,
+		DepartmentID: q.Department,
+		From:         q.From,
+		To:           q.To,
+		TZ:           q.TZ,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, out.Filename))
+	c.Data(stdhttp.StatusOK, out.ContentType, out.Body)
+}
@@ -0,0 +1,18 @@
+package payroll
+
+import "github.com/gin-gonic/gin"
+
+// Register mounts the payroll endpoints, rg is expected to be guarded.
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	payroll := rg.Group("/payroll")
+
+	{
+		payroll.GET("/export", h.Export)
+
+		payroll.POST("/templates", h.CreateTemplate)
+		payroll.GET("/templates", h.ListTemplates)
+		payroll.GET("/templates/:name", h.GetTemplate)
+		payroll.PATCH("/templates/:name", h.UpdateTemplate)
+		payroll.DELETE("/templates/:name", h.DeleteTemplate)
+	}
+}
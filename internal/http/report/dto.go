@@ -0,0 +1,7 @@
+package report
+
+type monthQuery struct {
+	// YYYY-MM, defaults to the previous month
+	Month string `form:"month" binding:"omitempty"`
+	TZ    string `form:"tz" binding:"omitempty"`
+}
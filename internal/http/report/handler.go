@@ -0,0 +1,102 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	stdhttp "net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	reportsvc "github.com/itsaFan/fleetify-be/internal/service/report"
+)
+
+type Handler struct {
+	svc reportsvc.Service
+}
+
+func New(svc reportsvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func (h *Handler) GetEmployeeMonthly(c *gin.Context) {
+	empId, err := url.PathUnescape(c.Param("employee_id"))
+	if err != nil {
+		helper.BadRequest(c, "Invalid employee_id in path")
+		return
+	}
+
+	var q monthQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	rep, err := h.svc.EmployeeMonthly(c.Request.Context(), empId, reportsvc.MonthlyInput{Month: q.Month, TZ: q.TZ})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+	writePDF(c, rep)
+}
+
+func (h *Handler) GetDepartmentMonthly(c *gin.Context) {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		helper.BadRequest(c, "Invalid department name in path")
+		return
+	}
+
+	var q monthQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	rep, err := h.svc.DepartmentMonthly(c.Request.Context(), name, reportsvc.MonthlyInput{Month: q.Month, TZ: q.TZ})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+	writePDF(c, rep)
+}
+
+// GetMonthlyArchive builds the department zip of the month on every request
+// and streams it as it goes, the copy the monthly report job keeps on disk is
+// not read.
+func (h *Handler) GetMonthlyArchive(c *gin.Context) {
+	var q monthQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	in := reportsvc.MonthlyInput{Month: q.Month, TZ: q.TZ}
+	filename, err := h.svc.ArchiveFilename(in)
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "no-store")
+	out, err := h.svc.WriteMonthlyArchive(c.Request.Context(), in, c.Writer)
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			helper.WriteError(c, err)
+			return
+		}
+		// the zip is already partly sent, it ends up truncated
+		log.Printf("report archive: %v", err)
+		return
+	}
+	log.Printf("report archive %s: %d departments", out.Filename, out.Departments)
+}
+
+func writePDF(c *gin.Context, rep *reportsvc.Report) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, rep.Filename))
+	c.Data(stdhttp.StatusOK, "application/pdf", rep.Body)
+}
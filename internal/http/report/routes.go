@@ -0,0 +1,21 @@
+package report
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	reports := rg.Group("/reports")
+
+	{
+		reports.GET("/employees/:employee_id/attendance", h.GetEmployeeMonthly)
+		reports.GET("/departments/:name/attendance", h.GetDepartmentMonthly)
+	}
+}
+
+// RegisterAdmin mounts the admin endpoints, rg is expected to be guarded.
+func (h *Handler) RegisterAdmin(rg *gin.RouterGroup) {
+	reports := rg.Group("/reports")
+
+	{
+		reports.GET("/attendance", h.GetMonthlyArchive)
+	}
+}
@@ -7,6 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
+	"github.com/itsaFan/fleetify-be/internal/cache"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/http/middleware"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+
 	dpthttp "github.com/itsaFan/fleetify-be/internal/http/department"
 	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
 	deptsvc "github.com/itsaFan/fleetify-be/internal/service/department"
@@ -15,12 +21,57 @@ import (
 	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
 	empsvc "github.com/itsaFan/fleetify-be/internal/service/employee"
 
+	holidayhttp "github.com/itsaFan/fleetify-be/internal/http/holiday"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	holidaysvc "github.com/itsaFan/fleetify-be/internal/service/holiday"
+
+	leavehttp "github.com/itsaFan/fleetify-be/internal/http/leave"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	leavesvc "github.com/itsaFan/fleetify-be/internal/service/leave"
+
 	atdhttp "github.com/itsaFan/fleetify-be/internal/http/attendance"
 	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
 	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+
+	corrhttp "github.com/itsaFan/fleetify-be/internal/http/correction"
+	corrrepo "github.com/itsaFan/fleetify-be/internal/repo/correction"
+	corrsvc "github.com/itsaFan/fleetify-be/internal/service/correction"
+
+	othttp "github.com/itsaFan/fleetify-be/internal/http/overtime"
+	otrepo "github.com/itsaFan/fleetify-be/internal/repo/overtime"
+	otsvc "github.com/itsaFan/fleetify-be/internal/service/overtime"
+
+	webhookhttp "github.com/itsaFan/fleetify-be/internal/http/webhook"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+	webhooksvc "github.com/itsaFan/fleetify-be/internal/service/webhook"
+
+	notifhttp "github.com/itsaFan/fleetify-be/internal/http/notification"
+	"github.com/itsaFan/fleetify-be/internal/notify"
+	notifrepo "github.com/itsaFan/fleetify-be/internal/repo/notification"
+	notifsvc "github.com/itsaFan/fleetify-be/internal/service/notification"
+
+	reporthttp "github.com/itsaFan/fleetify-be/internal/http/report"
+	reportsvc "github.com/itsaFan/fleetify-be/internal/service/report"
+
+	payrollhttp "github.com/itsaFan/fleetify-be/internal/http/payroll"
+	payrollrepo "github.com/itsaFan/fleetify-be/internal/repo/payroll"
+	payrollsvc "github.com/itsaFan/fleetify-be/internal/service/payroll"
+
+	cachehttp "github.com/itsaFan/fleetify-be/internal/http/cache"
+
+	searchhttp "github.com/itsaFan/fleetify-be/internal/http/search"
+	searchrepo "github.com/itsaFan/fleetify-be/internal/repo/search"
+	searchsvc "github.com/itsaFan/fleetify-be/internal/service/search"
+
+	jobhttp "github.com/itsaFan/fleetify-be/internal/http/job"
+	jobrepo "github.com/itsaFan/fleetify-be/internal/repo/job"
+	jobsvc "github.com/itsaFan/fleetify-be/internal/service/job"
 )
 
-func NewRouter(db *gorm.DB) *gin.Engine {
+// NewRouter wires the API. store backs the employee and department lookup
+// cache, nil disables it. atdSvc is the attendance service the jobs run on,
+// shared so both sides work through one instance.
+func NewRouter(db *gorm.DB, bus *event.Bus, sched *scheduler.Scheduler, store cache.Store, atdSvc atdsvc.Service) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Recovery(), gin.Logger())
 
@@ -29,27 +80,83 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 			"https://steffansim-fleetify.zeabur.app",
 		},
 		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Admin-Token"},
 		ExposeHeaders: []string{"Content-Length", "Content-Type"},
 		MaxAge:        12 * time.Hour,
 	}))
 
 	v1 := r.Group("/v1")
+	admin := v1.Group("/admin", middleware.AdminOnly())
 
-	dptRepo := deptrepo.New(db)
-	dptSvc := deptsvc.New(dptRepo)
+	cacheTTL := config.LoadCacheConfig().TTL
+
+	dptRepo := deptrepo.NewCached(deptrepo.New(db), store, cacheTTL)
+	dptSvc := deptsvc.New(dptRepo, atdSvc)
 	dptHdl := dpthttp.New(dptSvc)
 	dptHdl.Register(v1)
 
-	empRepo := emprepo.New(db)
-	empSvc := empsvc.New(empRepo, dptRepo)
+	empRepo := emprepo.NewCached(emprepo.New(db), store, cacheTTL)
+	empSvc := empsvc.New(empRepo, dptRepo, atdSvc)
 	empHdl := emphttp.New(empSvc)
 	empHdl.Register(v1)
+	empHdl.RegisterAdmin(admin)
+
+	searchHdl := searchhttp.New(searchsvc.New(searchrepo.New(db)))
+	searchHdl.Register(v1)
+
+	holidayRepo := holidayrepo.New(db)
+	holidaySvc := holidaysvc.New(holidayRepo, dptRepo, atdSvc)
+	holidayHdl := holidayhttp.New(holidaySvc)
+	holidayHdl.Register(v1)
+
+	leaveRepo := leaverepo.New(db)
+	leaveSvc := leavesvc.New(leaveRepo, empRepo, holidayRepo)
+	leaveHdl := leavehttp.New(leaveSvc)
+	leaveHdl.Register(v1)
 
 	atdRepo := atdrepo.New(db)
-	atdSvc := atdsvc.New(atdRepo, empRepo)
 	atdHdl := atdhttp.New(atdSvc)
 	atdHdl.Register(v1)
+	atdHdl.RegisterAdmin(admin)
+
+	corrRepo := corrrepo.New(db)
+	corrSvc := corrsvc.New(corrRepo, empRepo, atdSvc)
+	corrHdl := corrhttp.New(corrSvc)
+	corrHdl.Register(v1)
+
+	otRepo := otrepo.New(db)
+	otSvc := otsvc.New(otRepo, atdRepo, empRepo, holidayRepo, config.LoadOvertimePolicy())
+	otHdl := othttp.New(otSvc)
+	otHdl.Register(v1)
+
+	// subscriptions carry signing secrets, admin only
+	webhookRepo := webhookrepo.New(db)
+	webhookSvc := webhooksvc.New(webhookRepo, config.LoadWebhookConfig())
+	webhookHdl := webhookhttp.New(webhookSvc)
+	webhookHdl.Register(admin)
+
+	notifRepo := notifrepo.New(db)
+	mailer := notify.NewMailer(config.LoadSMTPConfig())
+	notifSvc := notifsvc.New(notifRepo, atdSvc, atdRepo, empRepo, mailer, config.LoadNotifyConfig())
+	notifHdl := notifhttp.New(notifSvc)
+	notifHdl.Register(v1)
+	notifHdl.RegisterAdmin(admin)
+
+	reportSvc := reportsvc.New(atdSvc, empRepo, dptRepo)
+	reportHdl := reporthttp.New(reportSvc)
+	reportHdl.Register(v1)
+	reportHdl.RegisterAdmin(admin)
+
+	// pay figures are admin only
+	payrollSvc := payrollsvc.New(payrollrepo.New(db), atdSvc, otSvc)
+	payrollHdl := payrollhttp.New(payrollSvc)
+	payrollHdl.Register(admin)
+
+	jobSvc := jobsvc.New(jobrepo.New(db), sched)
+	jobHdl := jobhttp.New(jobSvc)
+	jobHdl.Register(admin)
+
+	cachehttp.New().Register(admin)
 
 	return r
 }
@@ -0,0 +1,21 @@
+package search
+
+type searchQuery struct {
+	Q     string `form:"q"     binding:"required"`
+	Type  string `form:"type"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type hitResp struct {
+	Type           string  `json:"type"`
+	ID             uint64  `json:"id"`
+	EmployeeID     *string `json:"employee_id,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	DepartmentName *string `json:"department_name,omitempty"`
+	Score          float64 `json:"score"`
+}
+
+type searchResponse struct {
+	Message string    `json:"message"`
+	Data    []hitResp `json:"data"`
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	stdhttp "net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	searchSvc "github.com/itsaFan/fleetify-be/internal/service/search"
+)
+
+type Handler struct {
+	svc searchSvc.Service
+}
+
+func New(svc searchSvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// GET /v1/search?q=&type=employee,department&limit=
+func (h *Handler) Search(c *gin.Context) {
+	var q searchQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	var types []string
+	if q.Type != "" {
+		types = strings.Split(q.Type, ",")
+	}
+
+	hits, err := h.svc.Search(c.Request.Context(), searchSvc.SearchInput{
+		Query: q.Q,
+		Types: types,
+		Limit: q.Limit,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]hitResp, 0, len(hits))
+	for _, hit := range hits {
+		data = append(data, hitResp{
+			Type:           hit.Type,
+			ID:             hit.ID,
+			EmployeeID:     hit.EmployeeID,
+			Name:           hit.Name,
+			DepartmentName: hit.DepartmentName,
+			Score:          hit.Score,
+		})
+	}
+
+	c.JSON(stdhttp.StatusOK, searchResponse{
+		Message: "Search results retrieved successfully",
+		Data:    data,
+	})
+}
@@ -0,0 +1,7 @@
+package search
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	rg.GET("/search", h.Search)
+}
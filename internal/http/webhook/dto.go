@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+)
+
+type subscriptionResp struct {
+	SubscriptionID string   `json:"subscription_id"`
+	URL            string   `json:"url"`
+	EventTypes     []string `json:"event_types"`
+	Description    *string  `json:"description"`
+	Active         bool     `json:"active"`
+	// only returned on create
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type deliveryResp struct {
+	DeliveryID     string          `json:"delivery_id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      *string         `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	ReplayOf       *string         `json:"replay_of"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type createReq struct {
+	URL         string   `json:"url" binding:"required,max=2048"`
+	Secret      string   `json:"secret" binding:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Active      *bool    `json:"active"`
+}
+
+type updateReq struct {
+	URL         *string   `json:"url,omitempty" binding:"omitempty,max=2048"`
+	Secret      *string   `json:"secret,omitempty" binding:"omitempty,max=255"`
+	EventTypes  *[]string `json:"event_types,omitempty"`
+	Description *string   `json:"description,omitempty" binding:"omitempty,max=255"`
+	Active      *bool     `json:"active,omitempty"`
+}
+
+type subscriptionResponse struct {
+	Message string           `json:"message"`
+	Data    subscriptionResp `json:"data"`
+}
+
+type listQuery struct {
+	Active *bool `form:"active"`
+	Limit  int   `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Page   int   `form:"page"    binding:"omitempty,min=1"`
+}
+
+type listResponse struct {
+	Message    string             `json:"message"`
+	Data       []subscriptionResp `json:"data"`
+	Pagination helper.Pagination  `json:"pagination"`
+}
+
+type deleteResponse struct {
+	Message string `json:"message"`
+}
+
+type deliveryListQuery struct {
+	Status    string `form:"status"     binding:"omitempty,oneof=pending succeeded failed"`
+	EventType string `form:"event_type"`
+	Limit     int    `form:"limit"      binding:"omitempty,min=1,max=100"`
+	Page      int    `form:"page"       binding:"omitempty,min=1"`
+}
+
+type deliveryListResponse struct {
+	Message    string            `json:"message"`
+	Data       []deliveryResp    `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
+
+type deliveryResponse struct {
+	Message string       `json:"message"`
+	Data    deliveryResp `json:"data"`
+}
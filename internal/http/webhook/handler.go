@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	stdhttp "net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+	webhooksvc "github.com/itsaFan/fleetify-be/internal/service/webhook"
+)
+
+type Handler struct {
+	svc webhooksvc.Service
+}
+
+func New(svc webhooksvc.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func toSubscriptionResp(s *model.WebhookSubscription) subscriptionResp {
+	return subscriptionResp{
+		SubscriptionID: s.SubscriptionID,
+		URL:            s.URL,
+		EventTypes:     strings.Split(s.EventTypes, ","),
+		Description:    s.Description,
+		Active:         s.Active,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func toDeliveryResp(d *model.WebhookDelivery) deliveryResp {
+	out := deliveryResp{
+		DeliveryID:     d.DeliveryID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+	}
+	// only meaningful while a retry is still ahead
+	if d.Status == webhookrepo.DeliveryPending {
+		next := d.NextAttemptAt
+		out.NextAttemptAt = &next
+	}
+	return out
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	var req createReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	sub, err := h.svc.CreateSubscription(c.Request.Context(), webhooksvc.CreateInput{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		Active:      req.Active,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := toSubscriptionResp(sub)
+	data.Secret = sub.Secret
+
+	c.JSON(stdhttp.StatusCreated, subscriptionResponse{
+		Message: "Webhook subscription created successfully",
+		Data:    data,
+	})
+}
+
+func (h *Handler) List(c *gin.Context) {
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.ListSubscriptions(c.Request.Context(), webhooksvc.ListInput{
+		Active: q.Active,
+		Limit:  q.Limit,
+		Page:   q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]subscriptionResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toSubscriptionResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, listResponse{
+		Message:    "Webhook subscriptions retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
+
+func (h *Handler) Get(c *gin.Context) {
+	sub, err := h.svc.GetSubscription(c.Request.Context(), c.Param("subscription_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, subscriptionResponse{
+		Message: "Webhook subscription retrieved successfully",
+		Data:    toSubscriptionResp(sub),
+	})
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	var req updateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest(c, "invalid JSON body")
+		return
+	}
+
+	sub, err := h.svc.UpdateSubscription(c.Request.Context(), c.Param("subscription_id"), webhooksvc.UpdateInput{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		Active:      req.Active,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, subscriptionResponse{
+		Message: "Webhook subscription updated successfully",
+		Data:    toSubscriptionResp(sub),
+	})
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.svc.DeleteSubscription(c.Request.Context(), c.Param("subscription_id")); err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, deleteResponse{
+		Message: "Webhook subscription deleted successfully",
+	})
+}
+
+func (h *Handler) ListDeliveries(c *gin.Context) {
+	var q deliveryListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		helper.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	out, err := h.svc.ListDeliveries(c.Request.Context(), webhooksvc.DeliveryListInput{
+		SubscriptionID: c.Param("subscription_id"),
+		Status:         q.Status,
+		EventType:      q.EventType,
+		Limit:          q.Limit,
+		Page:           q.Page,
+	})
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	data := make([]deliveryResp, len(out.Data))
+	for i := range out.Data {
+		data[i] = toDeliveryResp(&out.Data[i])
+	}
+
+	c.JSON(stdhttp.StatusOK, deliveryListResponse{
+		Message:    "Webhook deliveries retrieved successfully",
+		Data:       data,
+		Pagination: out.Pagination,
+	})
+}
+
+func (h *Handler) GetDelivery(c *gin.Context) {
+	d, err := h.svc.GetDelivery(c.Request.Context(), c.Param("delivery_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusOK, deliveryResponse{
+		Message: "Webhook delivery retrieved successfully",
+		Data:    toDeliveryResp(d),
+	})
+}
+
+func (h *Handler) Replay(c *gin.Context) {
+	d, err := h.svc.Replay(c.Request.Context(), c.Param("delivery_id"))
+	if err != nil {
+		helper.WriteError(c, err)
+		return
+	}
+
+	c.JSON(stdhttp.StatusAccepted, deliveryResponse{
+		Message: "Webhook delivery queued for replay",
+		Data:    toDeliveryResp(d),
+	})
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	webhooks := rg.Group("/webhooks")
+
+	{
+		webhooks.POST("", h.Create)
+		webhooks.GET("", h.List)
+		webhooks.GET("/deliveries/:delivery_id", h.GetDelivery)
+		webhooks.POST("/deliveries/:delivery_id/replay", h.Replay)
+		webhooks.GET("/:subscription_id", h.Get)
+		webhooks.PATCH("/:subscription_id", h.Update)
+		webhooks.DELETE("/:subscription_id", h.Delete)
+		webhooks.GET("/:subscription_id/deliveries", h.ListDeliveries)
+	}
+}
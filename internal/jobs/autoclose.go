@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+// AutoClose closes forgotten open attendances. Failed items do not fail the
+// run; they are listed in its output and retried on the next one.
+func AutoClose(svc atdsvc.Service, cfg config.AutoCloseConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:        "attendance.auto_close",
+		Spec:        cfg.Schedule,
+		Description: "Close attendances left open past the auto-close window and flag them for review",
+		Run: func(ctx context.Context) (string, error) {
+			out, err := svc.AutoCloseOpenAttendances(ctx, atdsvc.AutoCloseInput{
+				OlderThan: cfg.After,
+				Loc:       config.AppTimezone(),
+			})
+			if err != nil {
+				return "", err
+			}
+			summary := fmt.Sprintf("closed %d, skipped %d, failed %d", out.Closed, out.Skipped, len(out.Errors))
+			if len(out.Errors) > 0 {
+				summary += "\n" + strings.Join(out.Errors, "\n")
+			}
+			return summary, nil
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+// RebuildDaily recomputes attendance_daily for the last cfg.Days APP_TZ days,
+// today included. Punches, department, holiday and transfer edits keep the
+// table current on their own; this is a safety net for rows written by an
+// older rule version or edits made outside the API.
+func RebuildDaily(svc atdsvc.Service, cfg config.DailyRebuildConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:        "attendance.rebuild_daily",
+		Spec:        cfg.Schedule,
+		Description: fmt.Sprintf("Recompute the pre-aggregated attendance days of the last %d days", cfg.Days),
+		Run: func(ctx context.Context) (string, error) {
+			today := time.Now().In(config.AppTimezone())
+			out, err := svc.RebuildDaily(ctx, atdsvc.RebuildDailyInput{
+				FromLocal: helper.DateKey(today.AddDate(0, 0, -(max(cfg.Days, 1) - 1))),
+				ToLocal:   helper.DateKey(today),
+			})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s to %s: %d employees, %d days", out.From, out.To, out.Employees, out.Days), nil
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	notifsvc "github.com/itsaFan/fleetify-be/internal/service/notification"
+)
+
+// DailyDigest mails managers yesterday's late arrivals and missing
+// clock-outs. The notification log keeps a rerun from mailing twice.
+func DailyDigest(svc notifsvc.Service, cfg config.NotifyConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:        "notifications.daily_digest",
+		Spec:        cfg.DigestSchedule,
+		Description: "Mail department managers the previous day's lateness digest",
+		Run: func(ctx context.Context) (string, error) {
+			out, err := svc.SendDailyDigests(ctx, notifsvc.DigestInput{})
+			if err != nil {
+				return "", err
+			}
+			summary := fmt.Sprintf("%s: sent %d, skipped %d", out.Date, out.Sent, out.Skipped)
+			if len(out.Errors) > 0 {
+				return summary, fmt.Errorf("%d digests failed: %s", len(out.Errors), strings.Join(out.Errors, "; "))
+			}
+			return summary, nil
+		},
+	}
+}
+
+func ClockOutReminders(svc notifsvc.Service, cfg config.NotifyConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:        "notifications.clock_out_reminders",
+		Spec:        cfg.ReminderSchedule,
+		Description: "Remind employees still clocked in well past their department's clock-out",
+		Run: func(ctx context.Context) (string, error) {
+			out, err := svc.SendClockOutReminders(ctx)
+			if err != nil {
+				return "", err
+			}
+			summary := fmt.Sprintf("sent %d", out.Sent)
+			if len(out.Errors) > 0 {
+				return summary, fmt.Errorf("%d reminders failed: %s", len(out.Errors), strings.Join(out.Errors, "; "))
+			}
+			return summary, nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	reportsvc "github.com/itsaFan/fleetify-be/internal/service/report"
+)
+
+// MonthlyReports writes last month's department PDFs as one zip into
+// cfg.Dir. The zip is assembled under a temporary name, so a failed run
+// never leaves a partial archive behind.
+func MonthlyReports(svc reportsvc.Service, cfg config.ReportConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:        "reports.monthly_attendance",
+		Spec:        cfg.Schedule,
+		Description: "Write the previous month's department attendance PDFs into a zip",
+		Run: func(ctx context.Context) (string, error) {
+			if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+				return "", err
+			}
+			tmp, err := os.CreateTemp(cfg.Dir, ".attendance-*.zip")
+			if err != nil {
+				return "", err
+			}
+			defer os.Remove(tmp.Name())
+
+			out, err := svc.WriteMonthlyArchive(ctx, reportsvc.MonthlyInput{}, tmp)
+			if err != nil {
+				tmp.Close()
+				return "", err
+			}
+			if err := tmp.Close(); err != nil {
+				return "", err
+			}
+
+			path := filepath.Join(cfg.Dir, out.Filename)
+			if err := os.Rename(tmp.Name(), path); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s: %d departments", path, out.Departments), nil
+		},
+	}
+}
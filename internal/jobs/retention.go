@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	retentionsvc "github.com/itsaFan/fleetify-be/internal/service/retention"
+)
+
+func Retention(svc retentionsvc.Service, cfg config.RetentionConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:        "maintenance.retention",
+		Spec:        cfg.Schedule,
+		Description: fmt.Sprintf("Delete outbox, webhook, notification and job history older than %d days", cfg.Days),
+		Run: func(ctx context.Context) (string, error) {
+			out, err := svc.Purge(ctx, retentionsvc.PurgeInput{OlderThanDays: cfg.Days})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("outbox %d, webhook deliveries %d, notification logs %d, job runs %d",
+				out.OutboxEvents, out.WebhookDeliveries, out.NotificationLogs, out.JobRuns), nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	webhooksvc "github.com/itsaFan/fleetify-be/internal/service/webhook"
+)
+
+// RunWebhookDispatcher drains the outbox and sends due webhook deliveries
+// every cfg.Interval until ctx is cancelled.
+func RunWebhookDispatcher(ctx context.Context, svc webhooksvc.Service, cfg config.WebhookConfig) {
+	if !cfg.Enabled {
+		log.Println("webhook dispatcher disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			out, err := svc.Dispatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("webhooks: %v", err)
+			}
+			if out != nil && out.Failed > 0 {
+				log.Printf("webhooks: %d deliveries gave up after max attempts", out.Failed)
+			}
+		}
+	}
+}
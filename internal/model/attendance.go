@@ -10,6 +10,7 @@ type Attendance struct {
 	AttendanceID string     `gorm:"size:100;not null;column:attendance_id"`
 	ClockIn      *time.Time `gorm:"column:clock_in"`
 	ClockOut     *time.Time `gorm:"column:clock_out"`
+	NeedsReview  bool       `gorm:"not null;default:false;column:needs_review"`
 	CreatedAt    time.Time  `gorm:"column:created_at"`
 	UpdatedAt    time.Time  `gorm:"column:updated_at"`
 
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+type AttendanceCorrection struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	CorrectionID string     `gorm:"size:100;uniqueIndex;not null;column:correction_id"`
+	EmployeeID   string     `gorm:"size:50;not null;column:employee_id"`
+	DateLocal    time.Time  `gorm:"type:date;not null;column:date_local"`
+	TZ           string     `gorm:"size:64;not null;default:UTC;column:tz"`
+	ClockIn      *time.Time `gorm:"column:clock_in"`
+	ClockOut     *time.Time `gorm:"column:clock_out"`
+	Reason       string     `gorm:"type:text;not null;column:reason"`
+	Status       string     `gorm:"size:20;not null;default:pending;column:status"` //note: pending | approved | rejected
+	ApproverID   *string    `gorm:"size:50;column:approver_id"`
+	DecisionNote *string    `gorm:"type:text;column:decision_note"`
+	DecidedAt    *time.Time `gorm:"column:decided_at"`
+	AttendanceID *string    `gorm:"size:100;column:attendance_id"`
+	PrevClockIn  *time.Time `gorm:"column:prev_clock_in"`
+	PrevClockOut *time.Time `gorm:"column:prev_clock_out"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Employee Employee `gorm:"foreignKey:EmployeeID;references:EmployeeID"`
+}
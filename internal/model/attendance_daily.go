@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// AttendanceDaily is the pre-computed day of one employee, bucketed in
+// APP_TZ. Rows only exist for days with punches; absences are still derived.
+type AttendanceDaily struct {
+	ID              uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	EmployeeID      string     `gorm:"size:50;not null;column:employee_id"`
+	DateLocal       time.Time  `gorm:"type:date;not null;column:date_local"`
+	AttendanceID    *string    `gorm:"size:100;column:attendance_id"`
+	FirstIn         *time.Time `gorm:"column:first_in"`
+	LastOut         *time.Time `gorm:"column:last_out"`
+	StatusIn        string     `gorm:"size:20;not null;column:status_in"`  //note: on_time | late | early | missing_in | holiday_work
+	StatusOut       string     `gorm:"size:20;not null;column:status_out"` //note: normal | overtime | early_leave | no_out | holiday_work
+	DeltaInMinutes  *int       `gorm:"column:delta_in_minutes"`
+	DeltaOutMinutes *int       `gorm:"column:delta_out_minutes"`
+	WorkedMinutes   int        `gorm:"not null;column:worked_minutes"`
+	Holiday         *string    `gorm:"size:255;column:holiday"`
+	Corrected       bool       `gorm:"not null;column:corrected"`
+	NeedsReview     bool       `gorm:"not null;column:needs_review"`
+	RuleVersion     int        `gorm:"not null;column:rule_version"` //note: bumped when the day rules change, older rows need a rebuild
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at"`
+}
+
+func (AttendanceDaily) TableName() string {
+	return "attendance_daily"
+}
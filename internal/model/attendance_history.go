@@ -9,7 +9,8 @@ type AttendanceHistory struct {
 	EmployeeID     string    `gorm:"size:50;not null;column:employee_id"`
 	AttendanceID   string    `gorm:"size:100;not null;column:attendance_id"`
 	DateAttendance time.Time `gorm:"not null;column:date_attendance"`
-	AttendanceType uint8     `gorm:"type:tinyint;not null;column:attendance_type"` //note: 1=In, 2=Out
+	AttendanceType uint8     `gorm:"type:tinyint;not null;column:attendance_type"`    //note: 1=In, 2=Out
+	Source         string    `gorm:"size:20;not null;default:terminal;column:source"` //note: terminal | manual | auto_closed
 	Description    string    `gorm:"type:text;column:description"`
 	CreatedAt      time.Time `gorm:"column:created_at"`
 	UpdatedAt      time.Time `gorm:"column:updated_at"`
@@ -19,4 +20,8 @@ type AttendanceHistory struct {
 	Attendance Attendance `gorm:"foreignKey:AttendanceID;references:AttendanceID"`
 }
 
-
+const (
+	HistorySourceTerminal = "terminal"
+	HistorySourceManual   = "manual"
+	HistorySourceAuto     = "auto_closed"
+)
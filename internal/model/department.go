@@ -1,10 +1,13 @@
 package model
 
 type Department struct {
-	ID              uint64 `gorm:"primaryKey;autoIncrement;column:id"`
-	DepartmentName  string `gorm:"size:255;not null;column:department_name"`
-	MaxClockInTime  string `gorm:"type:time;not null;column:max_clock_in_time"` 
-	MaxClockOutTime string `gorm:"type:time;not null;column:max_clock_out_time"`
+	ID                uint64  `gorm:"primaryKey;autoIncrement;column:id"`
+	DepartmentName    string  `gorm:"size:255;not null;column:department_name"`
+	MaxClockInTime    string  `gorm:"type:time;not null;column:max_clock_in_time"`
+	MaxClockOutTime   string  `gorm:"type:time;not null;column:max_clock_out_time"`
+	WorkingDays       string  `gorm:"size:20;not null;default:1,2,3,4,5;column:working_days"` //note: ISO weekdays, 1=Mon..7=Sun
+	BreakMinutes      int     `gorm:"not null;default:60;column:break_minutes"`
+	ManagerEmployeeID *string `gorm:"size:50;column:manager_employee_id"` //note: receives the daily attendance digest, decides leave, corrections and overtime
 
 	Employees []Employee `gorm:"foreignKey:DepartmentID;references:ID"`
 }
@@ -12,9 +12,15 @@ type Employee struct {
 	EmployeeID   string    `gorm:"size:50;uniqueIndex;not null; column:employee_id"`
 	DepartmentID uint64      `gorm:"not null;column:department_id"`
 	Name         string    `gorm:"size:255;not null;column:name"`
+	Email        *string   `gorm:"size:255;uniqueIndex;column:email"`
 	Address      string    `gorm:"type:text;column:address"`
+	HireDate        time.Time  `gorm:"type:date;not null;column:hire_date"`
+	TerminationDate *time.Time `gorm:"type:date;column:termination_date"` //note: last day of employment
+	EmploymentType   string `gorm:"size:20;not null;default:permanent;column:employment_type"` //note: permanent | contract | intern
+	EmploymentStatus string `gorm:"size:20;not null;default:active;column:employment_status"` //note: active | suspended | terminated
 	CreatedAt    time.Time `gorm:"column:created_at"`
 	UpdatedAt    time.Time `gorm:"column:updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index;column:deleted_at"` //note: archived, hidden from default scopes
 
 	// Relations
 	Department  Department          `gorm:"foreignKey:DepartmentID;references:ID"`
@@ -22,6 +28,26 @@ type Employee struct {
 	History     []AttendanceHistory `gorm:"foreignKey:EmployeeID;references:EmployeeID"`
 }
 
+const (
+	EmploymentPermanent = "permanent"
+	EmploymentContract  = "contract"
+	EmploymentIntern    = "intern"
+
+	EmploymentActive     = "active"
+	EmploymentSuspended  = "suspended"
+	EmploymentTerminated = "terminated"
+)
+
+// EmployedOn reports whether the calendar date day (any zone, only the date
+// is used) falls between the hire and termination dates.
+func (e *Employee) EmployedOn(day time.Time) bool {
+	key := day.Format("2006-01-02")
+	if key < e.HireDate.Format("2006-01-02") {
+		return false
+	}
+	return e.TerminationDate == nil || key <= e.TerminationDate.Format("2006-01-02")
+}
+
 func (e *Employee) BeforeCreate(tx *gorm.DB) (err error) {
 	if e.EmployeeID == "" {
 		e.EmployeeID = uuid.New().String() 
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// EmployeeDepartmentAssignment is one stint of an employee in a department.
+// employees.department_id mirrors the open one.
+type EmployeeDepartmentAssignment struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	EmployeeID    string     `gorm:"size:50;not null;column:employee_id"`
+	DepartmentID  uint64     `gorm:"not null;column:department_id"`
+	EffectiveFrom time.Time  `gorm:"type:date;not null;column:effective_from"` //note: first day in the department
+	EffectiveTo   *time.Time `gorm:"type:date;column:effective_to"`            //note: last day, NULL = current
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+
+	// Relations
+	Department Department `gorm:"foreignKey:DepartmentID;references:ID"`
+}
+
+func (EmployeeDepartmentAssignment) TableName() string {
+	return "employee_department_assignments"
+}
+
+// DepartmentOn returns the department of the stint covering day, from stints
+// of one employee oldest first. The first stint is open towards the past and
+// the last towards the future, without any the current department applies.
+func DepartmentOn(stints []EmployeeDepartmentAssignment, current Department, day time.Time) Department {
+	if len(stints) == 0 {
+		return current
+	}
+	for i := len(stints) - 1; i > 0; i-- {
+		if !day.Before(stints[i].EffectiveFrom) {
+			return stints[i].Department
+		}
+	}
+	return stints[0].Department
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+type Holiday struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	HolidayDate  time.Time `gorm:"type:date;not null;column:holiday_date"`
+	Name         string    `gorm:"size:255;not null;column:name"`
+	Kind         string    `gorm:"size:20;not null;default:public;column:kind"`   //note: public | closure
+	DepartmentID *uint64   `gorm:"column:department_id"`                          //note: nil = company wide, one holiday per day and scope
+	Source       string    `gorm:"size:20;not null;default:manual;column:source"` //note: manual | ics
+	ExternalUID  *string   `gorm:"size:255;column:external_uid"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+
+	// Relations
+	Department *Department `gorm:"foreignKey:DepartmentID;references:ID"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+type ScheduledJob struct {
+	ID               uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	Name             string     `gorm:"size:100;uniqueIndex;not null;column:name"`
+	Schedule         string     `gorm:"size:150;not null;column:schedule"`
+	Timezone         string     `gorm:"size:64;not null;column:timezone"`
+	Description      *string    `gorm:"size:255;column:description"`
+	Paused           bool       `gorm:"not null;default:false;column:paused"`
+	TriggerRequested bool       `gorm:"not null;default:false;column:trigger_requested"`
+	NextRunAt        *time.Time `gorm:"column:next_run_at"`
+	LastRunAt        *time.Time `gorm:"column:last_run_at"`
+	LastStatus       *string    `gorm:"size:20;column:last_status"`
+	LeaseOwner       *string    `gorm:"size:150;column:lease_owner"`
+	LeaseUntil       *time.Time `gorm:"column:lease_until"`
+	CreatedAt        time.Time  `gorm:"column:created_at"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at"`
+}
+
+type JobRun struct {
+	ID         uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	RunID      string     `gorm:"size:100;uniqueIndex;not null;column:run_id"`
+	JobName    string     `gorm:"size:100;not null;column:job_name"`
+	Trigger    string     `gorm:"size:20;not null;column:trigger"` //note: schedule | manual
+	Owner      string     `gorm:"size:150;not null;column:owner"`
+	Status     string     `gorm:"size:20;not null;column:status"` //note: running | succeeded | failed | abandoned
+	StartedAt  time.Time  `gorm:"column:started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+	DurationMs *int64     `gorm:"column:duration_ms"`
+	Output     *string    `gorm:"type:text;column:output"`
+	Error      *string    `gorm:"type:text;column:error"`
+}
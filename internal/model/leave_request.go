@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+type LeaveRequest struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	RequestID    string     `gorm:"size:100;uniqueIndex;not null;column:request_id"`
+	EmployeeID   string     `gorm:"size:50;not null;column:employee_id"`
+	LeaveTypeID  uint64     `gorm:"not null;column:leave_type_id"`
+	StartDate    time.Time  `gorm:"type:date;not null;column:start_date"`
+	EndDate      time.Time  `gorm:"type:date;not null;column:end_date"`
+	Days         int        `gorm:"not null;column:days"`
+	Reason       string     `gorm:"type:text;column:reason"`
+	Status       string     `gorm:"size:20;not null;default:pending;column:status"` //note: pending | approved | rejected
+	ApproverID   *string    `gorm:"size:50;column:approver_id"`
+	DecisionNote *string    `gorm:"type:text;column:decision_note"`
+	DecidedAt    *time.Time `gorm:"column:decided_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Employee  Employee  `gorm:"foreignKey:EmployeeID;references:EmployeeID"`
+	LeaveType LeaveType `gorm:"foreignKey:LeaveTypeID;references:ID"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+)
+
+type LeaveType struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	Code        string    `gorm:"size:50;uniqueIndex;not null;column:code"`
+	Name        string    `gorm:"size:255;not null;column:name"`
+	YearlyQuota int       `gorm:"not null;default:0;column:yearly_quota"` //note: days per year, 0 = no quota
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
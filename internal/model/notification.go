@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	NotificationDailyDigest      = "daily_digest"
+	NotificationClockOutReminder = "clock_out_reminder"
+)
+
+// NotificationPreference is optional per employee, no row means everything on.
+type NotificationPreference struct {
+	ID               uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	EmployeeID       string    `gorm:"size:50;uniqueIndex;not null;column:employee_id"`
+	DailyDigest      bool      `gorm:"not null;default:true;column:daily_digest"`
+	ClockOutReminder bool      `gorm:"not null;default:true;column:clock_out_reminder"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+type NotificationLog struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	Kind      string    `gorm:"size:50;not null;column:kind"`
+	RefKey    string    `gorm:"size:150;not null;column:ref_key"`
+	Recipient string    `gorm:"size:255;not null;column:recipient"`
+	SentAt    time.Time `gorm:"column:sent_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+)
+
+type OutboxEvent struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	EventID      string     `gorm:"size:100;uniqueIndex;not null;column:event_id"`
+	EventType    string     `gorm:"size:100;not null;column:event_type"`
+	Payload      []byte     `gorm:"type:json;not null;column:payload"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	DispatchedAt *time.Time `gorm:"column:dispatched_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+type OvertimeRecord struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	OvertimeID   string     `gorm:"size:100;uniqueIndex;not null;column:overtime_id"`
+	EmployeeID   string     `gorm:"size:50;not null;column:employee_id"`
+	AttendanceID string     `gorm:"size:100;uniqueIndex;not null;column:attendance_id"`
+	WorkDate     time.Time  `gorm:"type:date;not null;column:work_date"`
+	DayKind      string     `gorm:"size:20;not null;column:day_kind"` //note: workday | rest_day | holiday
+	RawMinutes   int        `gorm:"not null;column:raw_minutes"`
+	Minutes      int        `gorm:"not null;column:minutes"`
+	Multiplier   float64    `gorm:"type:decimal(4,2);not null;column:multiplier"`
+	Status       string     `gorm:"size:20;not null;default:pending;column:status"` //note: pending | approved | rejected
+	ApproverID   *string    `gorm:"size:50;column:approver_id"`
+	DecisionNote *string    `gorm:"type:text;column:decision_note"`
+	DecidedAt    *time.Time `gorm:"column:decided_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Employee Employee `gorm:"foreignKey:EmployeeID;references:EmployeeID"`
+}
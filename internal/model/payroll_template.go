@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// PayrollTemplate maps payroll figures onto a vendor's CSV layout. Bools and
+// the delimiter carry no gorm default, an explicit false or "" must be kept.
+type PayrollTemplate struct {
+	ID               uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	Name             string    `gorm:"size:100;uniqueIndex;not null;column:name"`
+	Description      *string   `gorm:"size:255;column:description"`
+	Delimiter        string    `gorm:"size:1;not null;column:delimiter"` //note: empty = fixed width columns
+	IncludeHeader    bool      `gorm:"not null;column:include_header"`
+	DateFormat       string    `gorm:"size:20;not null;column:date_format"` //note: YYYY-MM-DD | DD/MM/YYYY | MM/DD/YYYY | YYYYMMDD | DD-MM-YYYY
+	DecimalSeparator string    `gorm:"size:1;not null;column:decimal_separator"`
+	UseCRLF          bool      `gorm:"not null;column:use_crlf"`
+	Columns          []byte    `gorm:"type:json;not null;column:columns"` //note: ordered []PayrollColumn
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+type PayrollColumn struct {
+	Header string `json:"header"`
+	Field  string `json:"field"`
+	// constant fields only
+	Value string `json:"value,omitempty"`
+	// hour fields, defaults to 2
+	Decimals *int `json:"decimals,omitempty"`
+	// fixed width, values are padded or cut to it; 0 = as is
+	Width int    `json:"width,omitempty"`
+	Align string `json:"align,omitempty"` //note: left | right, numbers default to right
+	Pad   string `json:"pad,omitempty"`   //note: one character, defaults to a space
+}
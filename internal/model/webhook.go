@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+)
+
+type WebhookSubscription struct {
+	ID             uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	SubscriptionID string    `gorm:"size:100;uniqueIndex;not null;column:subscription_id"`
+	URL            string    `gorm:"size:2048;not null;column:url"`
+	Secret         string    `gorm:"size:255;not null;column:secret"`
+	EventTypes     string    `gorm:"size:1000;not null;column:event_types"` //note: comma separated, * = all
+	Description    *string   `gorm:"size:255;column:description"`
+	Active         bool      `gorm:"not null;default:true;column:active"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	DeliveryID     string     `gorm:"size:100;uniqueIndex;not null;column:delivery_id"`
+	SubscriptionID string     `gorm:"size:100;not null;column:subscription_id"`
+	EventID        string     `gorm:"size:100;not null;column:event_id"`
+	EventType      string     `gorm:"size:100;not null;column:event_type"`
+	Payload        []byte     `gorm:"type:json;not null;column:payload"`
+	Status         string     `gorm:"size:20;not null;default:pending;column:status"` //note: pending | succeeded | failed
+	Attempts       int        `gorm:"not null;default:0;column:attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;column:next_attempt_at"`
+	LastStatusCode *int       `gorm:"column:last_status_code"`
+	LastError      *string    `gorm:"type:text;column:last_error"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	ReplayOf       *string    `gorm:"size:100;column:replay_of"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Subscription WebhookSubscription `gorm:"foreignKey:SubscriptionID;references:SubscriptionID"`
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt.tmpl"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html.tmpl"))
+)
+
+type DigestLate struct {
+	EmployeeID   string
+	EmployeeName string
+	ClockIn      string
+	LateMinutes  int
+}
+
+type DigestMissingOut struct {
+	EmployeeID   string
+	EmployeeName string
+	ClockIn      string
+	// closed by the auto-close job rather than never closed
+	AutoClosed bool
+}
+
+type DigestData struct {
+	ManagerName    string
+	DepartmentName string
+	Date           string
+	Late           []DigestLate
+	MissingOut     []DigestMissingOut
+}
+
+type ReminderData struct {
+	EmployeeName   string
+	DepartmentName string
+	ClockIn        string
+	MaxClockOut    string
+}
+
+// Render fills the text and HTML variants of a template, e.g. "digest".
+func Render(name string, data any) (text, html string, err error) {
+	var t, h bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&t, name+".txt.tmpl", data); err != nil {
+		return "", "", err
+	}
+	if err := htmlTemplates.ExecuteTemplate(&h, name+".html.tmpl", data); err != nil {
+		return "", "", err
+	}
+	return t.String(), h.String(), nil
+}
@@ -0,0 +1,186 @@
+// Package pdf writes simple PDF 1.4 documents: text in the standard
+// Helvetica faces, lines and filled rectangles on A4 pages. It is enough for
+// tabular reports and bar charts without pulling in a layout engine.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A4 in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Color struct{ R, G, B float64 }
+
+var (
+	Black     = Color{0, 0, 0}
+	Gray      = Color{0.55, 0.55, 0.55}
+	LightGray = Color{0.92, 0.92, 0.92}
+)
+
+// RGB builds a color from 0-255 components.
+func RGB(r, g, b uint8) Color {
+	return Color{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+}
+
+type Document struct {
+	Title   string
+	Author  string
+	Created time.Time
+
+	pages []*Page
+}
+
+func New(title string) *Document {
+	return &Document{Title: title, Created: time.Now()}
+}
+
+// Page uses a top-left origin with y growing downwards, in points.
+type Page struct {
+	content bytes.Buffer
+}
+
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Pages lists the pages in order, handy for stamping footers once the
+// page count is known.
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// Text draws s with its baseline at y.
+func (p *Page) Text(x, y, size float64, bold bool, c Color, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&p.content, "BT %s rg /%s %s Tf %s %s Td (%s) Tj ET\n",
+		c.op(), font, num(size), num(x), num(PageHeight-y), escape(s))
+}
+
+// TextRight draws s ending at x.
+func (p *Page) TextRight(x, y, size float64, bold bool, c Color, s string) {
+	p.Text(x-TextWidth(s, size, bold), y, size, bold, c, s)
+}
+
+func (p *Page) Line(x1, y1, x2, y2, width float64, c Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s m %s %s l S\n",
+		c.op(), num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Rect fills the w by h rectangle whose top-left corner is x, y.
+func (p *Page) Rect(x, y, w, h float64, c Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		c.op(), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// WriteTo serializes the document with one compressed content stream per page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 page tree, 3-4 fonts, 5 info, then page and content pairs
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Author (%s) /Producer (fleetify) /CreationDate (D:%s) >>",
+		escape(d.Title), escape(d.Author), d.Created.UTC().Format("20060102150405Z")))
+
+	for i, p := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), firstPage+2*i+1))
+
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", len(offsets), z.Len())
+		out.Write(z.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.WriteTo(w)
+}
+
+// Bytes renders the whole document.
+func (d *Document) Bytes() ([]byte, error) {
+	var b bytes.Buffer
+	if _, err := d.WriteTo(&b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (c Color) op() string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B)
+}
+
+// num prints coordinates and color components, a thousandth is below what
+// any viewer can show
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+// escape encodes s as a WinAnsi literal string. Runes outside Latin-1 have
+// no glyph in the standard fonts and become '?'.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20 || r > 0xff || (r >= 0x7f && r < 0xa0):
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
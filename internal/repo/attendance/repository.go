@@ -9,20 +9,42 @@ import (
 
 	"github.com/itsaFan/fleetify-be/internal/appErr"
 	"github.com/itsaFan/fleetify-be/internal/model"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
 	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+	// Outbox shares this repository's connection, inside WithTx the same transaction
+	Outbox() outboxrepo.Repository
 
 	FindEmpOpenAttendanceForUpdate(ctx context.Context, employeeID string) (*model.Attendance, error)
+	FindEmpAttendanceInRangeForUpdate(ctx context.Context, employeeID string, fromUTC, toUTC time.Time) (*model.Attendance, error)
 	ListHistoryByEmpId(ctx context.Context, p ListParamsEmp) ([]model.AttendanceHistory, error)
-	ListHistoryByDepartment(ctx context.Context, p ListParamsDept) ([]model.AttendanceHistory, error)
+	ListHistoryDays(ctx context.Context, p HistoryDayParams) ([]HistoryDayRow, error)
+	CountHistoryDays(ctx context.Context, p HistoryDayParams) (int64, error)
 
 	CreateEmpAttendanceByEmpId(ctx context.Context, d *model.Attendance) error
 	CreateAttendanceHistory(ctx context.Context, d *model.AttendanceHistory) error
 	UpdateAttendanceOutByAttendanceID(tx context.Context, attendanceID string, clockOut time.Time) error
+	UpdateAttendanceTimes(ctx context.Context, attendanceID string, clockIn, clockOut *time.Time) error
+
+	// after is the last row of the previous batch, nil for the first one
+	ListOpenClockedInBefore(ctx context.Context, beforeUTC time.Time, after *model.Attendance, limit int) ([]model.Attendance, error)
+	GetAttendanceForUpdate(ctx context.Context, attendanceID string) (*model.Attendance, error)
+	AutoCloseAttendance(ctx context.Context, attendanceID string, clockOut time.Time) error
+	ListNeedsReview(ctx context.Context, p ListParamsReview) ([]model.Attendance, int64, error)
+	ListClosedInRange(ctx context.Context, p ListParamsClosed) ([]model.Attendance, error)
+	DepartmentDailySummary(ctx context.Context, p SummaryParams) ([]SummaryRow, error)
+	ListOpen(ctx context.Context, departmentID *uint64) ([]model.Attendance, error)
+
+	UpsertDaily(ctx context.Context, d *model.AttendanceDaily) error
+	CreateDaily(ctx context.Context, items []model.AttendanceDaily) error
+	DeleteDailyInRange(ctx context.Context, employeeID string, fromDate, toDate time.Time) error
+	ListDailyByEmpId(ctx context.Context, employeeID string, fromDate, toDate time.Time) ([]model.AttendanceDaily, error)
+	// oldest stored day, nil when the table is empty
+	FirstDailyDate(ctx context.Context) (*time.Time, error)
 }
 
 type repository struct {
@@ -41,6 +63,10 @@ func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) erro
 	})
 }
 
+func (r *repository) Outbox() outboxrepo.Repository {
+	return outboxrepo.New(r.db)
+}
+
 // Functional ops
 func (r *repository) FindEmpOpenAttendanceForUpdate(ctx context.Context, employeeID string) (*model.Attendance, error) {
 	var att model.Attendance
@@ -56,6 +82,21 @@ func (r *repository) FindEmpOpenAttendanceForUpdate(ctx context.Context, employe
 	return &att, err
 }
 
+// First attendance clocked in within [fromUTC, toUTC], nil when there is none
+func (r *repository) FindEmpAttendanceInRangeForUpdate(ctx context.Context, employeeID string, fromUTC, toUTC time.Time) (*model.Attendance, error) {
+	var att model.Attendance
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("employee_id = ? AND clock_in BETWEEN ? AND ?", employeeID, fromUTC, toUTC).
+		Order("clock_in ASC, id ASC").
+		First(&att).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &att, err
+}
+
 func (r *repository) CreateEmpAttendanceByEmpId(ctx context.Context, d *model.Attendance) error {
 	return r.db.WithContext(ctx).Create(d).Error
 }
@@ -80,6 +121,138 @@ func (r *repository) UpdateAttendanceOutByAttendanceID(ctx context.Context, atte
 	return nil
 }
 
+func (r *repository) UpdateAttendanceTimes(ctx context.Context, attendanceID string, clockIn, clockOut *time.Time) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.Attendance{}).
+		Where("attendance_id = ?", attendanceID).
+		Updates(map[string]any{"clock_in": clockIn, "clock_out": clockOut, "needs_review": false})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Open attendances (oldest first) with employee and department loaded
+func (r *repository) ListOpenClockedInBefore(ctx context.Context, beforeUTC time.Time, after *model.Attendance, limit int) ([]model.Attendance, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+
+	q := r.db.WithContext(ctx).
+		Preload("Employee", withArchived).
+		Preload("Employee.Department").
+		Where("clock_out IS NULL AND clock_in < ?", beforeUTC)
+	if after != nil && after.ClockIn != nil {
+		q = q.Where("(clock_in > ? OR (clock_in = ? AND id > ?))", *after.ClockIn, *after.ClockIn, after.ID)
+	}
+
+	var items []model.Attendance
+	if err := q.
+		Order("clock_in ASC, id ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Every open attendance, with employee and department loaded
+func (r *repository) ListOpen(ctx context.Context, departmentID *uint64) ([]model.Attendance, error) {
+	q := r.db.WithContext(ctx).
+		Model(&model.Attendance{}).
+		Where("attendances.clock_out IS NULL")
+
+	if departmentID != nil {
+		q = q.Joins("JOIN employees e ON e.employee_id = attendances.employee_id").
+			Where("e.department_id = ?", *departmentID)
+	}
+
+	var items []model.Attendance
+	if err := q.
+		Select("attendances.*").
+		Preload("Employee", withArchived).
+		Preload("Employee.Department").
+		Order("attendances.clock_in ASC, attendances.id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repository) GetAttendanceForUpdate(ctx context.Context, attendanceID string) (*model.Attendance, error) {
+	var att model.Attendance
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&att, "attendance_id = ?", attendanceID).Error; err != nil {
+		return nil, err
+	}
+	return &att, nil
+}
+
+func (r *repository) AutoCloseAttendance(ctx context.Context, attendanceID string, clockOut time.Time) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.Attendance{}).
+		Where("attendance_id = ? AND clock_out IS NULL", attendanceID).
+		Updates(map[string]any{"clock_out": clockOut, "needs_review": true})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+type ListParamsReview struct {
+	DepartmentID *uint64
+	Limit        int
+	Page         int
+}
+
+func (r *repository) ListNeedsReview(ctx context.Context, p ListParamsReview) ([]model.Attendance, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).
+		Model(&model.Attendance{}).
+		Where("attendances.needs_review = ?", true)
+
+	if p.DepartmentID != nil {
+		q = q.Joins("JOIN employees e ON e.employee_id = attendances.employee_id").
+			Where("e.department_id = ?", *p.DepartmentID)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.Attendance
+	if err := q.
+		Select("attendances.*").
+		Preload("Employee", withArchived).
+		Preload("Employee.Department").
+		Order("attendances.clock_in DESC, attendances.id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
 type ListParamsEmp struct {
 	EmployeeID string
 	FromUtc    time.Time
@@ -114,38 +287,482 @@ func (r *repository) ListHistoryByEmpId(ctx context.Context, p ListParamsEmp) ([
 	return items, nil
 }
 
-type ListParamsDept struct {
+type ListParamsClosed struct {
+	FromUTC      time.Time
+	ToUTC        time.Time
+	EmployeeID   string
 	DepartmentID *uint64
-	FromUtc      time.Time
-	ToUtc        time.Time
 }
 
-func (r *repository) ListHistoryByDepartment(ctx context.Context, p ListParamsDept) ([]model.AttendanceHistory, error) {
-
+// Closed attendances clocked in within [FromUTC, ToUTC], oldest first, with
+// employee and department loaded. Auto-closed ones still waiting for review
+// are left out.
+func (r *repository) ListClosedInRange(ctx context.Context, p ListParamsClosed) ([]model.Attendance, error) {
 	q := r.db.WithContext(ctx).
-		Model(&model.AttendanceHistory{}).
-		Joins("JOIN employees e ON e.employee_id = attendance_histories.employee_id")
+		Model(&model.Attendance{}).
+		Where("attendances.clock_out IS NOT NULL AND attendances.needs_review = ?", false).
+		Where("attendances.clock_in BETWEEN ? AND ?", p.FromUTC, p.ToUTC)
 
+	if e := strings.TrimSpace(p.EmployeeID); e != "" {
+		q = q.Where("attendances.employee_id = ?", e)
+	}
 	if p.DepartmentID != nil {
-		q = q.Where("e.department_id = ?", *p.DepartmentID)
+		q = q.Joins("JOIN employees e ON e.employee_id = attendances.employee_id").
+			Where("e.department_id = ?", *p.DepartmentID)
 	}
 
-	if !p.FromUtc.IsZero() {
-		q = q.Where("attendance_histories.date_attendance >= ?", p.FromUtc)
+	var items []model.Attendance
+	if err := q.
+		Select("attendances.*").
+		Preload("Employee", withArchived).
+		Preload("Employee.Department").
+		Order("attendances.clock_in ASC, attendances.id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
 	}
+	return items, nil
+}
 
-	if !p.ToUtc.IsZero() {
-		q = q.Where("attendance_histories.date_attendance <= ?", p.ToUtc)
+type SummaryParams struct {
+	// local calendar dates, inclusive
+	FromDate time.Time
+	ToDate   time.Time
+	// UTC bounds of the local range
+	FromUTC time.Time
+	ToUTC   time.Time
+	// local zone offsets over [FromUTC, ToUTC], applied to stored UTC times
+	Offsets      []ZoneSpan
+	DepartmentID *uint64
+	// days before TodayLocal are over, today is over after the department
+	// max clock-out (NowLocalTime, "HH:MM:SS")
+	TodayLocal   time.Time
+	NowLocalTime string
+	// read the day picks from attendance_daily, only when the dates are APP_TZ
+	Daily bool
+}
+
+// ZoneSpan is the local zone offset in effect from FromUTC until the next
+// span starts.
+type ZoneSpan struct {
+	FromUTC       time.Time
+	OffsetSeconds int
+}
+
+// localTime shifts the UTC column col to local time with the offset in effect
+// at each row, so a range across a DST change buckets every punch into its
+// own local day. Times before the first span take its offset.
+func localTime(col string, spans []ZoneSpan) (string, []any) {
+	switch len(spans) {
+	case 0:
+		return col, nil
+	case 1:
+		return col + " + INTERVAL ? SECOND", []any{spans[0].OffsetSeconds}
+	}
+	var b strings.Builder
+	args := make([]any, 0, 2*len(spans)-1)
+	b.WriteString(col + " + INTERVAL CASE")
+	for i := len(spans) - 1; i > 0; i-- {
+		b.WriteString(" WHEN " + col + " >= ? THEN ?")
+		args = append(args, spans[i].FromUTC, spans[i].OffsetSeconds)
 	}
+	b.WriteString(" ELSE ? END SECOND")
+	return b.String(), append(args, spans[0].OffsetSeconds)
+}
 
-	var items []model.AttendanceHistory
-	if err := q.
-		Select("attendance_histories.*").
-		Order("attendance_histories.date_attendance ASC, attendance_histories.id ASC").
-		Find(&items).Error; err != nil {
+type SummaryRow struct {
+	DepartmentID   uint64    `gorm:"column:department_id"`
+	DepartmentName string    `gorm:"column:department_name"`
+	Day            time.Time `gorm:"column:day"`
+	Headcount      int64     `gorm:"column:headcount"`
+	Scheduled      int64     `gorm:"column:scheduled"`
+	Present        int64     `gorm:"column:present"`
+	CheckedIn      int64     `gorm:"column:checked_in"`
+	Late           int64     `gorm:"column:late"`
+	EarlyLeave     int64     `gorm:"column:early_leave"`
+	Absent         int64     `gorm:"column:absent"`
+	OnLeave        int64     `gorm:"column:on_leave"`
+	AvgLateMinutes *float64  `gorm:"column:avg_late_minutes"`
+}
+
+// stintsSQL lists every department assignment with the dates it covers. The
+// first one also covers the days before it, so history from before the
+// assignments were recorded stays with the first department.
+const stintsSQL = `
+    SELECT a.employee_id, a.department_id,
+      CASE WHEN EXISTS (
+        SELECT 1 FROM employee_department_assignments pa
+        WHERE pa.employee_id = a.employee_id AND pa.effective_from < a.effective_from
+      ) THEN a.effective_from END AS starts,
+      a.effective_to AS ends
+    FROM employee_department_assignments a`
+
+// The calendar comes from a recursive CTE, every employee is crossed with
+// the days of employment, hire to termination or archiving, and matched
+// against the day's first in and last out, see summaryPunchesSQL and
+// summaryDailyPunchesSQL. Each day counts for the department the employee
+// was assigned to on that day.
+const departmentDailySummarySQL = `
+WITH RECURSIVE days AS (
+  SELECT CAST(? AS DATE) AS d
+  UNION ALL
+  SELECT d + INTERVAL 1 DAY FROM days WHERE d < ?
+),
+punches AS (%s),
+roster AS (
+  SELECT e.employee_id, st.department_id, dp.department_name,
+    dp.max_clock_in_time, dp.max_clock_out_time, days.d,
+    FIND_IN_SET(WEEKDAY(days.d) + 1, REPLACE(dp.working_days, ' ', '')) > 0 AS working,
+    EXISTS (
+      SELECT 1 FROM holidays ho
+      WHERE ho.holiday_date = days.d
+        AND (ho.department_id IS NULL OR ho.department_id = st.department_id)
+    ) AS holiday,
+    EXISTS (
+      SELECT 1 FROM leave_requests lr
+      WHERE lr.employee_id = e.employee_id AND lr.status = 'approved'
+        AND days.d BETWEEN lr.start_date AND lr.end_date
+    ) AS on_leave
+  FROM employees e
+  JOIN (` + stintsSQL + `
+  ) st ON st.employee_id = e.employee_id
+  JOIN departments dp ON dp.id = st.department_id
+  JOIN days ON days.d >= e.hire_date
+    AND (st.starts IS NULL OR days.d >= st.starts)
+    AND (st.ends IS NULL OR days.d <= st.ends)
+    AND (e.termination_date IS NULL OR days.d <= e.termination_date)
+    AND (e.deleted_at IS NULL OR days.d < DATE(%s))
+  WHERE (? IS NULL OR st.department_id = ?)
+)
+SELECT r.department_id, r.department_name, r.d AS day,
+  COUNT(*) AS headcount,
+  COALESCE(SUM(r.working AND NOT r.holiday), 0) AS scheduled,
+  COALESCE(SUM(p.employee_id IS NOT NULL), 0) AS present,
+  COALESCE(SUM(NOT r.holiday AND p.first_in IS NOT NULL), 0) AS checked_in,
+  COALESCE(SUM(NOT r.holiday AND p.first_in > TIMESTAMP(r.d, r.max_clock_in_time)), 0) AS late,
+  COALESCE(SUM(NOT r.holiday AND p.last_out < TIMESTAMP(r.d, r.max_clock_out_time)), 0) AS early_leave,
+  COALESCE(SUM(r.working AND NOT r.holiday AND p.employee_id IS NULL AND NOT r.on_leave
+    AND (r.d < ? OR (r.d = ? AND ? >= r.max_clock_out_time))), 0) AS absent,
+  COALESCE(SUM(r.working AND NOT r.holiday AND p.employee_id IS NULL AND r.on_leave), 0) AS on_leave,
+  AVG(CASE WHEN NOT r.holiday AND p.first_in > TIMESTAMP(r.d, r.max_clock_in_time)
+    THEN CEIL(TIMESTAMPDIFF(SECOND, TIMESTAMP(r.d, r.max_clock_in_time), p.first_in) / 60) END) AS avg_late_minutes
+FROM roster r
+LEFT JOIN punches p ON p.employee_id = r.employee_id AND p.d = r.d
+GROUP BY r.department_id, r.department_name, r.d
+ORDER BY r.d ASC, r.department_name ASC`
+
+// Manual (corrected) punches win over terminal ones like in the Go aggregation.
+// First in and last out are picked in UTC and then shifted to local time, the
+// %s are localTime expressions.
+const summaryPunchesSQL = `
+  SELECT x.employee_id, x.d, %s AS first_in, %s AS last_out
+  FROM (
+    SELECT h.employee_id,
+      DATE(%s) AS d,
+      COALESCE(
+        MIN(CASE WHEN h.attendance_type = 1 AND h.source = 'manual' THEN h.date_attendance END),
+        MIN(CASE WHEN h.attendance_type = 1 THEN h.date_attendance END)
+      ) AS first_in,
+      COALESCE(
+        MAX(CASE WHEN h.attendance_type = 2 AND h.source = 'manual' THEN h.date_attendance END),
+        MAX(CASE WHEN h.attendance_type = 2 THEN h.date_attendance END)
+      ) AS last_out
+    FROM attendance_histories h
+    WHERE h.date_attendance BETWEEN ? AND ?
+    GROUP BY h.employee_id, d
+  ) x
+`
+
+// The same picks read from attendance_daily
+const summaryDailyPunchesSQL = `
+  SELECT ad.employee_id, ad.date_local AS d,
+    %s AS first_in,
+    %s AS last_out
+  FROM attendance_daily ad
+  WHERE ad.date_local BETWEEN ? AND ?
+`
+
+func (r *repository) DepartmentDailySummary(ctx context.Context, p SummaryParams) ([]SummaryRow, error) {
+	from := p.FromDate.Format("2006-01-02")
+	to := p.ToDate.Format("2006-01-02")
+	today := p.TodayLocal.Format("2006-01-02")
+
+	var punches string
+	args := []any{from, to}
+	if p.Daily {
+		in, inArgs := localTime("ad.first_in", p.Offsets)
+		out, outArgs := localTime("ad.last_out", p.Offsets)
+		punches = fmt.Sprintf(summaryDailyPunchesSQL, in, out)
+		args = append(append(append(args, inArgs...), outArgs...), from, to)
+	} else {
+		in, inArgs := localTime("x.first_in", p.Offsets)
+		out, outArgs := localTime("x.last_out", p.Offsets)
+		day, dayArgs := localTime("h.date_attendance", p.Offsets)
+		punches = fmt.Sprintf(summaryPunchesSQL, in, out, day)
+		args = append(append(append(append(args, inArgs...), outArgs...), dayArgs...), p.FromUTC, p.ToUTC)
+	}
+	deleted, deletedArgs := localTime("e.deleted_at", p.Offsets)
+	args = append(args, deletedArgs...)
+	args = append(args, p.DepartmentID, p.DepartmentID, today, today, p.NowLocalTime)
+
+	var rows []SummaryRow
+	if err := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(departmentDailySummarySQL, punches, deleted), args...).
+		Scan(&rows).Error; err != nil {
 		return nil, err
 	}
+	return rows, nil
+}
+
+type HistoryDayParams struct {
+	// local calendar dates, inclusive
+	FromDate time.Time
+	ToDate   time.Time
+	// UTC bounds of the local range
+	FromUTC time.Time
+	ToUTC   time.Time
+	// local zone offsets over [FromUTC, ToUTC], applied to stored UTC times
+	Offsets      []ZoneSpan
+	EmployeeID   *string
+	DepartmentID *uint64
+	// a scheduled day without punches is only listed once it is over, see
+	// SummaryParams
+	TodayLocal   time.Time
+	NowLocalTime string
+	// read the days from attendance_daily, only when the dates are APP_TZ
+	Daily bool
+	Limit int
+	Page  int
+}
+
+// HistoryDayRow is one employee on one local day: the picked first in and
+// last out when there were punches, otherwise a scheduled day (absent or
+// on leave). Times are UTC. Statuses and deltas are only set when read from
+// attendance_daily.
+type HistoryDayRow struct {
+	EmployeeID      string     `gorm:"column:employee_id"`
+	EmployeeName    string     `gorm:"column:employee_name"`
+	DepartmentID    uint64     `gorm:"column:department_id"`
+	DepartmentName  string     `gorm:"column:department_name"`
+	MaxClockInTime  string     `gorm:"column:max_clock_in_time"`
+	MaxClockOutTime string     `gorm:"column:max_clock_out_time"`
+	Day             time.Time  `gorm:"column:day"`
+	Punched         bool       `gorm:"column:punched"`
+	FirstIn         *time.Time `gorm:"column:first_in"`
+	LastOut         *time.Time `gorm:"column:last_out"`
+	AttendanceID    string     `gorm:"column:attendance_id"`
+	Corrected       bool       `gorm:"column:corrected"`
+	NeedsReview     bool       `gorm:"column:needs_review"`
+	StatusIn        *string    `gorm:"column:status_in"`
+	StatusOut       *string    `gorm:"column:status_out"`
+	DeltaInMinutes  *int       `gorm:"column:delta_in_minutes"`
+	DeltaOutMinutes *int       `gorm:"column:delta_out_minutes"`
+	Holiday         *string    `gorm:"column:holiday"`
+	LeaveType       *string    `gorm:"column:leave_type"`
+}
+
+// Same calendar and roster as departmentDailySummarySQL but kept per
+// employee, with the day picks from historyPunchesSQL or
+// historyDailyPunchesSQL. A department closure wins over a company wide
+// holiday on the same date.
+const historyDaysSQL = `
+WITH RECURSIVE days AS (
+  SELECT CAST(? AS DATE) AS d
+  UNION ALL
+  SELECT d + INTERVAL 1 DAY FROM days WHERE d < ?
+),
+scope AS (
+  SELECT e.employee_id, e.name, e.hire_date, e.termination_date, e.deleted_at, st.department_id, dp.department_name,
+    dp.working_days, dp.max_clock_in_time, dp.max_clock_out_time, st.starts, st.ends
+  FROM employees e
+  JOIN (` + stintsSQL + `
+  ) st ON st.employee_id = e.employee_id
+  JOIN departments dp ON dp.id = st.department_id
+  WHERE (? IS NULL OR e.employee_id = ?)
+    AND (? IS NULL OR st.department_id = ?)
+),
+punches AS (%s),
+roster AS (
+  SELECT s.employee_id, s.name AS employee_name, s.department_id, s.department_name,
+    s.max_clock_in_time, s.max_clock_out_time, days.d,
+    FIND_IN_SET(WEEKDAY(days.d) + 1, REPLACE(s.working_days, ' ', '')) > 0 AS working,
+    days.d >= s.hire_date
+      AND (s.termination_date IS NULL OR days.d <= s.termination_date)
+      AND (s.deleted_at IS NULL OR days.d < DATE(%s)) AS employed,
+    (
+      SELECT ho.name FROM holidays ho
+      WHERE ho.holiday_date = days.d
+        AND (ho.department_id IS NULL OR ho.department_id = s.department_id)
+      ORDER BY ho.department_id IS NULL, ho.id
+      LIMIT 1
+    ) AS holiday
+  FROM scope s
+  JOIN days ON (s.starts IS NULL OR days.d >= s.starts)
+    AND (s.ends IS NULL OR days.d <= s.ends)
+),
+listing AS (
+  SELECT r.*, p.employee_id IS NOT NULL AS punched,
+    p.first_in, p.last_out, p.attendance_id, p.corrected, p.needs_review,
+    p.status_in, p.status_out, p.delta_in_minutes, p.delta_out_minutes
+  FROM roster r
+  LEFT JOIN punches p ON p.employee_id = r.employee_id AND p.d = r.d
+  WHERE p.employee_id IS NOT NULL
+    OR (r.working AND r.employed AND r.holiday IS NULL
+      AND (r.d < ? OR (r.d = ? AND ? >= r.max_clock_out_time)))
+)`
+
+// The picks follow takePunch: manual before terminal, then earliest in and
+// latest out, ties to the lower id. The day is a localTime expression.
+const historyPunchesSQL = `
+  SELECT h.employee_id,
+    DATE(%s) AS d,
+    COALESCE(
+      MIN(CASE WHEN h.attendance_type = 1 AND h.source = 'manual' THEN h.date_attendance END),
+      MIN(CASE WHEN h.attendance_type = 1 THEN h.date_attendance END)
+    ) AS first_in,
+    COALESCE(
+      MAX(CASE WHEN h.attendance_type = 2 AND h.source = 'manual' THEN h.date_attendance END),
+      MAX(CASE WHEN h.attendance_type = 2 THEN h.date_attendance END)
+    ) AS last_out,
+    SUBSTRING_INDEX(GROUP_CONCAT(CASE WHEN h.attendance_type = 1 THEN h.attendance_id END
+      ORDER BY h.source = 'manual' DESC, h.date_attendance ASC, h.id ASC), ',', 1) AS attendance_id,
+    MAX(h.source = 'manual') AS corrected,
+    SUBSTRING_INDEX(GROUP_CONCAT(CASE WHEN h.attendance_type = 2 THEN h.source END
+      ORDER BY h.source = 'manual' DESC, h.date_attendance DESC, h.id ASC), ',', 1) = 'auto_closed' AS needs_review,
+    CAST(NULL AS CHAR) AS status_in, CAST(NULL AS CHAR) AS status_out,
+    CAST(NULL AS SIGNED) AS delta_in_minutes, CAST(NULL AS SIGNED) AS delta_out_minutes
+  FROM attendance_histories h
+  WHERE h.employee_id IN (SELECT employee_id FROM scope)
+    AND h.date_attendance BETWEEN ? AND ?
+  GROUP BY h.employee_id, d
+`
+
+const historyDailyPunchesSQL = `
+  SELECT ad.employee_id, ad.date_local AS d, ad.first_in, ad.last_out, ad.attendance_id,
+    ad.corrected, ad.needs_review, ad.status_in, ad.status_out,
+    ad.delta_in_minutes, ad.delta_out_minutes
+  FROM attendance_daily ad
+  WHERE ad.employee_id IN (SELECT employee_id FROM scope)
+    AND ad.date_local BETWEEN ? AND ?
+`
+
+func historyDaysQuery(p HistoryDayParams, tail string) (string, []any) {
+	from := p.FromDate.Format("2006-01-02")
+	to := p.ToDate.Format("2006-01-02")
+	today := p.TodayLocal.Format("2006-01-02")
+
+	punches, punchArgs := historyDailyPunchesSQL, []any{from, to}
+	if !p.Daily {
+		day, dayArgs := localTime("h.date_attendance", p.Offsets)
+		punches, punchArgs = fmt.Sprintf(historyPunchesSQL, day), append(dayArgs, p.FromUTC, p.ToUTC)
+	}
+	deleted, deletedArgs := localTime("s.deleted_at", p.Offsets)
+
+	args := []any{from, to, p.EmployeeID, p.EmployeeID, p.DepartmentID, p.DepartmentID}
+	args = append(args, punchArgs...)
+	args = append(args, deletedArgs...)
+	args = append(args, today, today, p.NowLocalTime)
+	return fmt.Sprintf(historyDaysSQL, punches, deleted) + tail, args
+}
+
+// Days ordered by date then employee, a page at a time. Leave types are only
+// looked up for the rows of the page.
+func (r *repository) ListHistoryDays(ctx context.Context, p HistoryDayParams) ([]HistoryDayRow, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q, args := historyDaysQuery(p, `
+SELECT l.employee_id, l.employee_name, l.department_id, l.department_name,
+  l.max_clock_in_time, l.max_clock_out_time, l.d AS day, l.punched,
+  l.first_in, l.last_out, COALESCE(l.attendance_id, '') AS attendance_id,
+  COALESCE(l.corrected, 0) AS corrected, COALESCE(l.needs_review, 0) AS needs_review,
+  l.status_in, l.status_out, l.delta_in_minutes, l.delta_out_minutes,
+  l.holiday,
+  CASE WHEN NOT l.punched THEN (
+    SELECT lt.name FROM leave_requests lr
+    JOIN leave_types lt ON lt.id = lr.leave_type_id
+    WHERE lr.employee_id = l.employee_id AND lr.status = 'approved'
+      AND l.d BETWEEN lr.start_date AND lr.end_date
+    ORDER BY lr.id DESC
+    LIMIT 1
+  ) END AS leave_type
+FROM listing l
+ORDER BY l.d ASC, l.employee_id ASC
+LIMIT ? OFFSET ?`)
+	args = append(args, p.Limit, (p.Page-1)*p.Limit)
+
+	var rows []HistoryDayRow
+	if err := r.db.WithContext(ctx).Raw(q, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repository) CountHistoryDays(ctx context.Context, p HistoryDayParams) (int64, error) {
+	q, args := historyDaysQuery(p, "\nSELECT COUNT(*) FROM listing")
 
+	var total int64
+	if err := r.db.WithContext(ctx).Raw(q, args...).Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Replaces the employee's day, keyed by (employee_id, date_local)
+func (r *repository) UpsertDaily(ctx context.Context, d *model.AttendanceDaily) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "employee_id"}, {Name: "date_local"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"attendance_id", "first_in", "last_out", "status_in", "status_out",
+			"delta_in_minutes", "delta_out_minutes", "worked_minutes", "holiday",
+			"corrected", "needs_review", "rule_version", "updated_at",
+		}),
+	}).Create(d).Error
+}
+
+func (r *repository) CreateDaily(ctx context.Context, items []model.AttendanceDaily) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(items, 200).Error
+}
+
+// Dates are calendar dates, compared without a zone shift
+func (r *repository) DeleteDailyInRange(ctx context.Context, employeeID string, fromDate, toDate time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("employee_id = ? AND date_local BETWEEN ? AND ?",
+			employeeID, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02")).
+		Delete(&model.AttendanceDaily{}).Error
+}
+
+func (r *repository) ListDailyByEmpId(ctx context.Context, employeeID string, fromDate, toDate time.Time) ([]model.AttendanceDaily, error) {
+	var items []model.AttendanceDaily
+	if err := r.db.WithContext(ctx).
+		Where("employee_id = ? AND date_local BETWEEN ? AND ?",
+			employeeID, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02")).
+		Order("date_local ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
 	return items, nil
+}
+
+func (r *repository) FirstDailyDate(ctx context.Context) (*time.Time, error) {
+	var first *time.Time
+	if err := r.db.WithContext(ctx).
+		Model(&model.AttendanceDaily{}).
+		Select("MIN(date_local)").
+		Scan(&first).Error; err != nil {
+		return nil, err
+	}
+	return first, nil
+}
 
+// withArchived lets a preload reach soft-deleted employees, their past rows
+// still point at them
+func withArchived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
 }
@@ -0,0 +1,176 @@
+package correction
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+type Repository interface {
+	// WithTx also hands out an attendance repository bound to the same
+	// transaction, approvals write both tables atomically.
+	WithTx(ctx context.Context, fn func(txRepo Repository, atdTx atdrepo.Repository) error) error
+
+	Create(ctx context.Context, d *model.AttendanceCorrection) error
+	GetByCorrectionID(ctx context.Context, correctionID string) (*model.AttendanceCorrection, error)
+	GetForUpdate(ctx context.Context, correctionID string) (*model.AttendanceCorrection, error)
+	ExistsPending(ctx context.Context, employeeID string, date time.Time) (bool, error)
+	List(ctx context.Context, p ListParams) ([]model.AttendanceCorrection, int64, error)
+	UpdateDecision(ctx context.Context, correctionID string, p DecisionParams) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository, atdTx atdrepo.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repository{db: tx}, atdrepo.New(tx))
+	})
+}
+
+func (r *repository) Create(ctx context.Context, d *model.AttendanceCorrection) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *repository) GetByCorrectionID(ctx context.Context, correctionID string) (*model.AttendanceCorrection, error) {
+	var out model.AttendanceCorrection
+	if err := r.db.WithContext(ctx).
+		Preload("Employee", withArchived).
+		First(&out, "correction_id = ?", correctionID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) GetForUpdate(ctx context.Context, correctionID string) (*model.AttendanceCorrection, error) {
+	var out model.AttendanceCorrection
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&out, "correction_id = ?", correctionID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) ExistsPending(ctx context.Context, employeeID string, date time.Time) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.AttendanceCorrection{}).
+		Where("employee_id = ? AND date_local = ? AND status = ?", employeeID, date.Format("2006-01-02"), StatusPending).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+type ListParams struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	Status       string
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	Page         int
+}
+
+func (r *repository) List(ctx context.Context, p ListParams) ([]model.AttendanceCorrection, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.AttendanceCorrection{})
+
+	if e := strings.TrimSpace(p.EmployeeID); e != "" {
+		q = q.Where("attendance_corrections.employee_id = ?", e)
+	}
+	if p.DepartmentID != nil {
+		q = q.Joins("JOIN employees e ON e.employee_id = attendance_corrections.employee_id").
+			Where("e.department_id = ?", *p.DepartmentID)
+	}
+	if s := strings.TrimSpace(p.Status); s != "" {
+		q = q.Where("attendance_corrections.status = ?", s)
+	}
+	if p.From != nil {
+		q = q.Where("attendance_corrections.date_local >= ?", p.From.Format("2006-01-02"))
+	}
+	if p.To != nil {
+		q = q.Where("attendance_corrections.date_local <= ?", p.To.Format("2006-01-02"))
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.AttendanceCorrection
+	if err := q.
+		Select("attendance_corrections.*").
+		Preload("Employee", withArchived).
+		Order("attendance_corrections.date_local DESC, attendance_corrections.id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+type DecisionParams struct {
+	Status       string
+	ApproverID   string
+	Note         *string
+	DecidedAt    time.Time
+	AttendanceID *string
+	PrevClockIn  *time.Time
+	PrevClockOut *time.Time
+}
+
+func (r *repository) UpdateDecision(ctx context.Context, correctionID string, p DecisionParams) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.AttendanceCorrection{}).
+		Where("correction_id = ? AND status = ?", correctionID, StatusPending).
+		Updates(map[string]any{
+			"status":         p.Status,
+			"approver_id":    p.ApproverID,
+			"decision_note":  p.Note,
+			"decided_at":     p.DecidedAt,
+			"attendance_id":  p.AttendanceID,
+			"prev_clock_in":  p.PrevClockIn,
+			"prev_clock_out": p.PrevClockOut,
+		})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// withArchived lets a preload reach soft-deleted employees, their past rows
+// still point at them
+func withArchived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
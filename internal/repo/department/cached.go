@@ -0,0 +1,133 @@
+package department
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/cache"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+)
+
+// CacheName is the cache namespace of departments. The employee repository
+// caches departments by id under it, so updates here reach those entries.
+const CacheName = "department"
+
+func CacheKeyID(id uint64) string {
+	return fmt.Sprintf("id:%d", id)
+}
+
+func cacheKeyName(name string) string {
+	return "name:" + name
+}
+
+type cached struct {
+	Repository
+	depts *cache.Cache[model.Department]
+}
+
+// NewCached puts a read-through cache in front of GetByName. Updates and
+// deletes invalidate the department by name and by id once committed. A nil
+// store returns inner unchanged.
+func NewCached(inner Repository, store cache.Store, ttl time.Duration) Repository {
+	if store == nil {
+		return inner
+	}
+	return &cached{Repository: inner, depts: cache.New[model.Department](CacheName, store, ttl)}
+}
+
+func (c *cached) GetByName(ctx context.Context, name string) (*model.Department, error) {
+	d, err := c.depts.Get(ctx, cacheKeyName(name), func(ctx context.Context) (model.Department, error) {
+		d, err := c.Repository.GetByName(ctx, name)
+		if err != nil {
+			return model.Department{}, err
+		}
+		return *d, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (c *cached) UpdateByName(ctx context.Context, name string, p UpdateParams) error {
+	keys, err := cacheKeys(ctx, c.Repository, name)
+	if err != nil {
+		return err
+	}
+	if err := c.Repository.UpdateByName(ctx, name, p); err != nil {
+		return err
+	}
+	c.depts.Invalidate(ctx, keys...)
+	return nil
+}
+
+func (c *cached) DeleteByName(ctx context.Context, name string) error {
+	keys, err := cacheKeys(ctx, c.Repository, name)
+	if err != nil {
+		return err
+	}
+	if err := c.Repository.DeleteByName(ctx, name); err != nil {
+		return err
+	}
+	c.depts.Invalidate(ctx, keys...)
+	return nil
+}
+
+// Reads inside the transaction skip the cache, writes are invalidated after
+// the commit.
+func (c *cached) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	var touched []string
+	if err := c.Repository.WithTx(ctx, func(tx Repository) error {
+		return fn(&txCached{Repository: tx, touched: &touched})
+	}); err != nil {
+		return err
+	}
+	c.depts.Invalidate(ctx, touched...)
+	return nil
+}
+
+type txCached struct {
+	Repository
+	touched *[]string
+}
+
+func (t *txCached) UpdateByName(ctx context.Context, name string, p UpdateParams) error {
+	keys, err := cacheKeys(ctx, t.Repository, name)
+	if err != nil {
+		return err
+	}
+	*t.touched = append(*t.touched, keys...)
+	return t.Repository.UpdateByName(ctx, name, p)
+}
+
+func (t *txCached) DeleteByName(ctx context.Context, name string) error {
+	keys, err := cacheKeys(ctx, t.Repository, name)
+	if err != nil {
+		return err
+	}
+	*t.touched = append(*t.touched, keys...)
+	return t.Repository.DeleteByName(ctx, name)
+}
+
+func (t *txCached) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return t.Repository.WithTx(ctx, func(tx Repository) error {
+		return fn(&txCached{Repository: tx, touched: t.touched})
+	})
+}
+
+// cacheKeys names the entries of the department, its id is read first since
+// callers only know the name.
+func cacheKeys(ctx context.Context, r Repository, name string) ([]string, error) {
+	keys := []string{cacheKeyName(name)}
+	d, err := r.GetByName(ctx, name)
+	switch {
+	case err == nil:
+		keys = append(keys, CacheKeyID(d.ID))
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+	return keys, nil
+}
@@ -5,10 +5,15 @@ import (
 	"strings"
 
 	"github.com/itsaFan/fleetify-be/internal/model"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+	// Outbox shares this repository's connection, inside WithTx the same transaction
+	Outbox() outboxrepo.Repository
+
 	Create(ctx context.Context, d *model.Department) error
 	ExistsByName(ctx context.Context, name string) (bool, error)
 	ExistsByID(ctx context.Context, id uint64) (bool, error)
@@ -26,6 +31,18 @@ func New(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+func (r *repository) Outbox() outboxrepo.Repository {
+	return outboxrepo.New(r.db)
+}
+
 func (r *repository) Create(ctx context.Context, d *model.Department) error {
 	return r.db.WithContext(ctx).Create(d).Error
 }
@@ -57,6 +74,17 @@ type ListParams struct {
 	Page    int
 	SortBy  string
 	SortDir string
+	// when set Page is ignored and up to Limit+1 rows past the cursor are
+	// returned, in walking order (reversed for Before), the extra one only
+	// tells there are more
+	Cursor *Keyset
+}
+
+// Keyset is the sort key and id of the row a page starts next to.
+type Keyset struct {
+	Key    string
+	ID     uint64
+	Before bool
 }
 
 func (r *repository) List(ctx context.Context, p ListParams) ([]model.Department, int64, error) {
@@ -76,10 +104,7 @@ func (r *repository) List(ctx context.Context, p ListParams) ([]model.Department
 		sortBy = "department_name"
 	}
 
-	dir := "ASC"
-	if strings.EqualFold(p.SortDir, "desc") {
-		dir = "DESC"
-	}
+	desc := strings.EqualFold(p.SortDir, "desc")
 
 	q := r.db.WithContext(ctx).Model(&model.Department{})
 	if s := strings.TrimSpace(p.Search); s != "" {
@@ -91,12 +116,38 @@ func (r *repository) List(ctx context.Context, p ListParams) ([]model.Department
 		return nil, 0, err
 	}
 
+	if p.Cursor != nil {
+		desc = desc != p.Cursor.Before
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		if sortBy == "id" {
+			q = q.Where("id "+op+" ?", p.Cursor.ID)
+		} else {
+			q = q.Where("(department_name "+op+" ? OR (department_name = ? AND id "+op+" ?))",
+				p.Cursor.Key, p.Cursor.Key, p.Cursor.ID)
+		}
+	}
+
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	order := sortBy + " " + dir
+	if sortBy != "id" {
+		order += ", id " + dir
+	}
+
+	q = q.Order(order)
+	if p.Cursor != nil {
+		q = q.Limit(p.Limit + 1)
+	} else {
+		q = q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit)
+	}
+
 	var items []model.Department
-	if err := q.
-		Order(sortBy + " " + dir).
-		Limit(p.Limit).
-		Offset((p.Page - 1) * p.Limit).
-		Find(&items).Error; err != nil {
+	if err := q.Find(&items).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -119,6 +170,10 @@ type UpdateParams struct {
 	DepartmentName  *string
 	MaxClockInTime  *string
 	MaxClockOutTime *string
+	WorkingDays     *string
+	BreakMinutes    *int
+	// "" = clear
+	ManagerEmployeeID *string
 }
 
 func (r *repository) UpdateByName(ctx context.Context, name string, p UpdateParams) error {
@@ -136,6 +191,22 @@ func (r *repository) UpdateByName(ctx context.Context, name string, p UpdatePara
 		updates["max_clock_out_time"] = *p.MaxClockOutTime
 	}
 
+	if p.WorkingDays != nil {
+		updates["working_days"] = strings.TrimSpace(*p.WorkingDays)
+	}
+
+	if p.BreakMinutes != nil {
+		updates["break_minutes"] = *p.BreakMinutes
+	}
+
+	if p.ManagerEmployeeID != nil {
+		if m := strings.TrimSpace(*p.ManagerEmployeeID); m != "" {
+			updates["manager_employee_id"] = m
+		} else {
+			updates["manager_employee_id"] = nil
+		}
+	}
+
 	if len(updates) == 0 {
 		return nil
 	}
@@ -0,0 +1,179 @@
+package employee
+
+import (
+	"context"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/cache"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+)
+
+type cached struct {
+	Repository
+	// employees are cached without their department, which lives in the
+	// department namespace by id and is invalidated by department writes
+	emps  *cache.Cache[model.Employee]
+	depts *cache.Cache[model.Department]
+}
+
+// NewCached puts a read-through cache in front of GetByEmployeeIDJoinDept,
+// the lookup behind every punch. Every write invalidates the employee once
+// committed. A nil store returns inner unchanged.
+func NewCached(inner Repository, store cache.Store, ttl time.Duration) Repository {
+	if store == nil {
+		return inner
+	}
+	return &cached{
+		Repository: inner,
+		emps:       cache.New[model.Employee]("employee", store, ttl),
+		depts:      cache.New[model.Department](deptrepo.CacheName, store, ttl),
+	}
+}
+
+func (c *cached) GetByEmployeeIDJoinDept(ctx context.Context, employeeID string) (*model.Employee, error) {
+	var joined *model.Employee
+	load := func(ctx context.Context) (*model.Employee, error) {
+		if joined != nil {
+			return joined, nil
+		}
+		e, err := c.Repository.GetByEmployeeIDJoinDept(ctx, employeeID)
+		if err != nil {
+			return nil, err
+		}
+		joined = e
+		return e, nil
+	}
+
+	emp, err := c.emps.Get(ctx, employeeID, func(ctx context.Context) (model.Employee, error) {
+		e, err := load(ctx)
+		if err != nil {
+			return model.Employee{}, err
+		}
+		slim := *e
+		slim.Department = model.Department{}
+		return slim, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if joined != nil {
+		c.depts.Set(ctx, deptrepo.CacheKeyID(joined.DepartmentID), joined.Department)
+		return joined, nil
+	}
+
+	dept, err := c.depts.Get(ctx, deptrepo.CacheKeyID(emp.DepartmentID), func(ctx context.Context) (model.Department, error) {
+		e, err := load(ctx)
+		if err != nil {
+			return model.Department{}, err
+		}
+		return e.Department, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	emp.Department = dept
+	return &emp, nil
+}
+
+func (c *cached) UpdateByEmployeeID(ctx context.Context, employeeID string, p UpdateParams) error {
+	if err := c.Repository.UpdateByEmployeeID(ctx, employeeID, p); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, employeeID)
+	return nil
+}
+
+func (c *cached) DeleteByEmployeeID(ctx context.Context, employeeID string) error {
+	if err := c.Repository.DeleteByEmployeeID(ctx, employeeID); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, employeeID)
+	return nil
+}
+
+func (c *cached) ChangeStatus(ctx context.Context, employeeID string, p StatusParams) error {
+	if err := c.Repository.ChangeStatus(ctx, employeeID, p); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, employeeID)
+	return nil
+}
+
+func (c *cached) Transfer(ctx context.Context, employeeID string, p TransferParams) error {
+	if err := c.Repository.Transfer(ctx, employeeID, p); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, employeeID)
+	return nil
+}
+
+func (c *cached) RestoreByEmployeeID(ctx context.Context, employeeID string) error {
+	if err := c.Repository.RestoreByEmployeeID(ctx, employeeID); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, employeeID)
+	return nil
+}
+
+func (c *cached) PurgeByEmployeeID(ctx context.Context, employeeID string) error {
+	if err := c.Repository.PurgeByEmployeeID(ctx, employeeID); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, employeeID)
+	return nil
+}
+
+// Reads inside the transaction skip the cache, writes are invalidated after
+// the commit.
+func (c *cached) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	var touched []string
+	if err := c.Repository.WithTx(ctx, func(tx Repository) error {
+		return fn(&txCached{Repository: tx, touched: &touched})
+	}); err != nil {
+		return err
+	}
+	c.emps.Invalidate(ctx, touched...)
+	return nil
+}
+
+type txCached struct {
+	Repository
+	touched *[]string
+}
+
+func (t *txCached) UpdateByEmployeeID(ctx context.Context, employeeID string, p UpdateParams) error {
+	*t.touched = append(*t.touched, employeeID)
+	return t.Repository.UpdateByEmployeeID(ctx, employeeID, p)
+}
+
+func (t *txCached) DeleteByEmployeeID(ctx context.Context, employeeID string) error {
+	*t.touched = append(*t.touched, employeeID)
+	return t.Repository.DeleteByEmployeeID(ctx, employeeID)
+}
+
+func (t *txCached) ChangeStatus(ctx context.Context, employeeID string, p StatusParams) error {
+	*t.touched = append(*t.touched, employeeID)
+	return t.Repository.ChangeStatus(ctx, employeeID, p)
+}
+
+func (t *txCached) Transfer(ctx context.Context, employeeID string, p TransferParams) error {
+	*t.touched = append(*t.touched, employeeID)
+	return t.Repository.Transfer(ctx, employeeID, p)
+}
+
+func (t *txCached) RestoreByEmployeeID(ctx context.Context, employeeID string) error {
+	*t.touched = append(*t.touched, employeeID)
+	return t.Repository.RestoreByEmployeeID(ctx, employeeID)
+}
+
+func (t *txCached) PurgeByEmployeeID(ctx context.Context, employeeID string) error {
+	*t.touched = append(*t.touched, employeeID)
+	return t.Repository.PurgeByEmployeeID(ctx, employeeID)
+}
+
+func (t *txCached) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return t.Repository.WithTx(ctx, func(tx Repository) error {
+		return fn(&txCached{Repository: tx, touched: t.touched})
+	})
+}
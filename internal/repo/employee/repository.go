@@ -2,19 +2,53 @@ package employee
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/itsaFan/fleetify-be/internal/appErr"
 	"github.com/itsaFan/fleetify-be/internal/model"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+	// Outbox shares this repository's connection, inside WithTx the same transaction
+	Outbox() outboxrepo.Repository
+
 	Create(ctx context.Context, d *model.Employee) error
 	ListJoinDept(ctx context.Context, p ListParams) ([]model.Employee, int64, error)
 	GetEmpByIdJoinDept(ctx context.Context, id uint64) (*model.Employee, error)
 	GetByEmployeeIDJoinDept(ctx context.Context, employeeID string) (*model.Employee, error)
+	// locks the row until the transaction ends, writers read through it
+	GetByEmployeeIDForUpdate(ctx context.Context, employeeID string) (*model.Employee, error)
+	ListByDepartment(ctx context.Context, departmentID *uint64) ([]model.Employee, error)
+	ListByDepartmentJoinDept(ctx context.Context, departmentID *uint64) ([]model.Employee, error)
 	UpdateByEmployeeID(ctx context.Context, employeeID string, p UpdateParams) error
+	// only applies while the status is still from, otherwise gorm.ErrRecordNotFound
+	ChangeStatus(ctx context.Context, employeeID string, p StatusParams) error
+
+	CreateAssignment(ctx context.Context, a *model.EmployeeDepartmentAssignment) error
+	// department stints of the employees oldest first, departments preloaded
+	ListAssignments(ctx context.Context, employeeIDs ...string) ([]model.EmployeeDepartmentAssignment, error)
+	// department the employee belonged to on day, archived employees included
+	DepartmentOn(ctx context.Context, employeeID string, day time.Time) (*model.Department, error)
+	// closes the open stint the day before p.EffectiveFrom and opens the new
+	// one, employees.department_id follows. An open stint starting on
+	// p.EffectiveFrom is moved instead
+	Transfer(ctx context.Context, employeeID string, p TransferParams) error
+	// employees with a stint in the department overlapping [from, to], with
+	// their current department joined. nil = everyone, like ListByDepartmentJoinDept
+	ListAssignedJoinDept(ctx context.Context, departmentID *uint64, from, to time.Time) ([]model.Employee, error)
+	// archives, the row and its attendance stay
 	DeleteByEmployeeID(ctx context.Context, employeeID string) error
+	// includes archived employees, for reading their history
+	GetByEmployeeIDWithArchived(ctx context.Context, employeeID string) (*model.Employee, error)
+	RestoreByEmployeeID(ctx context.Context, employeeID string) error
+	// removes an archived employee for good, the foreign keys cascade
+	PurgeByEmployeeID(ctx context.Context, employeeID string) error
 }
 
 type repository struct {
@@ -25,6 +59,18 @@ func New(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+func (r *repository) Outbox() outboxrepo.Repository {
+	return outboxrepo.New(r.db)
+}
+
 func (r *repository) Create(ctx context.Context, d *model.Employee) error {
 	return r.db.WithContext(ctx).Create(d).Error
 }
@@ -45,10 +91,42 @@ type ListParams struct {
 	Page    int
 	SortBy  string
 	SortDir string
+
+	DepartmentIDs []uint64
+	// UTC, both ends inclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	// has an attendance without a clock out
+	ClockedIn         *bool
+	Status            string
+	ManagerEmployeeID string
+	// "" leaves archived employees out, "include" or "only"
+	Archived string
+
+	// when set Page is ignored and up to Limit+1 rows past the cursor are
+	// returned, in walking order (reversed for Before), the extra one only
+	// tells there are more
+	Cursor *Keyset
+}
+
+// Keyset is the sort key and id of the row a page starts next to.
+type Keyset struct {
+	// string, time.Time for created_at
+	Key    any
+	ID     uint64
+	Before bool
 }
 
 func (r *repository) ListJoinDept(ctx context.Context, p ListParams) ([]model.Employee, int64, error) {
 	q := r.db.WithContext(ctx).Model(&model.Employee{})
+	switch p.Archived {
+	case "include":
+		q = q.Unscoped()
+	case "only":
+		q = q.Unscoped().Where("employees.deleted_at IS NOT NULL")
+	}
 
 	if p.Limit <= 0 || p.Limit > 100 {
 		p.Limit = 10
@@ -61,31 +139,62 @@ func (r *repository) ListJoinDept(ctx context.Context, p ListParams) ([]model.Em
 	switch strings.ToLower(strings.TrimSpace(p.SortBy)) {
 	case "id":
 		sortBy = "employees.id"
+	case "department_name":
+		sortBy = "departments.department_name"
+	case "created_at":
+		sortBy = "employees.created_at"
 	case "name", "":
 		sortBy = "employees.name"
 	}
 
-	dir := "ASC"
-	if strings.EqualFold(p.SortDir, "desc") {
-		dir = "DESC"
-	}
+	desc := strings.EqualFold(p.SortDir, "desc")
 
-	if s := strings.TrimSpace(p.Search); s != "" {
-		q = q.Joins("JOIN departments ON employees.department_id = departments.id").
-			Where("employees.name LIKE ? OR departments.department_name LIKE ?", "%"+s+"%", "%"+s+"%")
+	s := strings.TrimSpace(p.Search)
+	if s != "" || sortBy == "departments.department_name" {
+		q = q.Joins("JOIN departments ON employees.department_id = departments.id")
+	}
+	if s != "" {
+		q = q.Where("employees.name LIKE ? OR departments.department_name LIKE ?", "%"+s+"%", "%"+s+"%")
 	}
+	q = applyFilters(q, p)
 
 	var total int64
 	if err := q.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if p.Cursor != nil {
+		desc = desc != p.Cursor.Before
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		if sortBy == "employees.id" {
+			q = q.Where("employees.id "+op+" ?", p.Cursor.ID)
+		} else {
+			q = q.Where("("+sortBy+" "+op+" ? OR ("+sortBy+" = ? AND employees.id "+op+" ?))",
+				p.Cursor.Key, p.Cursor.Key, p.Cursor.ID)
+		}
+	}
+
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	order := sortBy + " " + dir
+	if sortBy != "employees.id" {
+		order += ", employees.id " + dir
+	}
+
+	q = q.Preload("Department").Order(order)
+	if p.Cursor != nil {
+		q = q.Limit(p.Limit + 1)
+	} else {
+		q = q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit)
+	}
+
 	var items []model.Employee
-	if err := q.Preload("Department").
-		Order(sortBy + " " + dir).
-		Limit(p.Limit).
-		Offset((p.Page - 1) * p.Limit).
-		Find(&items).Error; err != nil {
+	if err := q.Find(&items).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -93,10 +202,46 @@ func (r *repository) ListJoinDept(ctx context.Context, p ListParams) ([]model.Em
 
 }
 
+func applyFilters(q *gorm.DB, p ListParams) *gorm.DB {
+	if len(p.DepartmentIDs) > 0 {
+		q = q.Where("employees.department_id IN ?", p.DepartmentIDs)
+	}
+	if p.CreatedFrom != nil {
+		q = q.Where("employees.created_at >= ?", *p.CreatedFrom)
+	}
+	if p.CreatedTo != nil {
+		q = q.Where("employees.created_at <= ?", *p.CreatedTo)
+	}
+	if p.UpdatedFrom != nil {
+		q = q.Where("employees.updated_at >= ?", *p.UpdatedFrom)
+	}
+	if p.UpdatedTo != nil {
+		q = q.Where("employees.updated_at <= ?", *p.UpdatedTo)
+	}
+	if p.Status != "" {
+		q = q.Where("employees.employment_status = ?", p.Status)
+	}
+	if p.ManagerEmployeeID != "" {
+		q = q.Where("employees.department_id IN (SELECT id FROM departments WHERE manager_employee_id = ?)", p.ManagerEmployeeID)
+	}
+	if p.ClockedIn != nil {
+		open := "EXISTS (SELECT 1 FROM attendances a WHERE a.employee_id = employees.employee_id AND a.clock_out IS NULL)"
+		if !*p.ClockedIn {
+			open = "NOT " + open
+		}
+		q = q.Where(open)
+	}
+	return q
+}
+
 type UpdateParams struct {
-	Name       *string
-	Address    *string
-	Department uint64
+	Name *string
+	// "" = clear
+	Email   *string
+	Address *string
+	// local calendar date
+	HireDate       *time.Time
+	EmploymentType *string
 }
 
 func (r *repository) UpdateByEmployeeID(ctx context.Context, employeeID string, p UpdateParams) error {
@@ -106,13 +251,23 @@ func (r *repository) UpdateByEmployeeID(ctx context.Context, employeeID string,
 		updates["name"] = strings.TrimSpace(*p.Name)
 	}
 
+	if p.Email != nil {
+		if e := strings.TrimSpace(*p.Email); e != "" {
+			updates["email"] = e
+		} else {
+			updates["email"] = nil
+		}
+	}
+
 	if p.Address != nil {
 		updates["address"] = strings.TrimSpace(*p.Address)
 	}
 
-	// 0 = "no change"
-	if p.Department != 0 {
-		updates["department_id"] = p.Department
+	if p.HireDate != nil {
+		updates["hire_date"] = p.HireDate.Format("2006-01-02")
+	}
+	if p.EmploymentType != nil {
+		updates["employment_type"] = *p.EmploymentType
 	}
 
 	if len(updates) == 0 {
@@ -134,6 +289,33 @@ func (r *repository) UpdateByEmployeeID(ctx context.Context, employeeID string,
 	return nil
 }
 
+type StatusParams struct {
+	From string
+	To   string
+	// local calendar date, set when terminating
+	TerminationDate *time.Time
+}
+
+func (r *repository) ChangeStatus(ctx context.Context, employeeID string, p StatusParams) error {
+	updates := map[string]any{"employment_status": p.To}
+	if p.TerminationDate != nil {
+		updates["termination_date"] = p.TerminationDate.Format("2006-01-02")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&model.Employee{}).
+		Where("employee_id = ? AND employment_status = ?", employeeID, p.From).
+		Updates(updates)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *repository) GetByEmployeeIDJoinDept(ctx context.Context, employeeID string) (*model.Employee, error) {
 	var out model.Employee
 	if err := r.db.WithContext(ctx).
@@ -144,16 +326,214 @@ func (r *repository) GetByEmployeeIDJoinDept(ctx context.Context, employeeID str
 	return &out, nil
 }
 
+func (r *repository) GetByEmployeeIDForUpdate(ctx context.Context, employeeID string) (*model.Employee, error) {
+	var out model.Employee
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Department").
+		First(&out, "employee_id = ?", employeeID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Roster only, departments are not preloaded
+func (r *repository) ListByDepartment(ctx context.Context, departmentID *uint64) ([]model.Employee, error) {
+	q := r.db.WithContext(ctx).Model(&model.Employee{})
+	if departmentID != nil {
+		q = q.Where("department_id = ?", *departmentID)
+	}
+
+	var items []model.Employee
+	if err := q.
+		Select("id", "employee_id", "department_id", "name", "hire_date", "termination_date", "created_at").
+		Order("employee_id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Full rows with the department joined in the same query, for callers that
+// need every employee's working days and cutoffs
+func (r *repository) ListByDepartmentJoinDept(ctx context.Context, departmentID *uint64) ([]model.Employee, error) {
+	q := r.db.WithContext(ctx).Model(&model.Employee{}).Joins("Department")
+	if departmentID != nil {
+		q = q.Where("employees.department_id = ?", *departmentID)
+	}
+
+	var items []model.Employee
+	if err := q.
+		Order("employees.employee_id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// An employee still clocked in is not archived, the open attendance would
+// never be closed
 func (r *repository) DeleteByEmployeeID(ctx context.Context, employeeID string) error {
 	tx := r.db.WithContext(ctx).
 		Where("employee_id = ?", employeeID).
+		Where("NOT EXISTS (SELECT 1 FROM attendances a WHERE a.employee_id = employees.employee_id AND a.clock_out IS NULL)").
 		Delete(&model.Employee{})
 
 	if tx.Error != nil {
 		return tx.Error
 	}
 	if tx.RowsAffected == 0 {
+		var count int64
+		if err := r.db.WithContext(ctx).
+			Model(&model.Employee{}).
+			Where("employee_id = ?", employeeID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: employee %q is clocked in, clock out first", appErr.ErrConflict, employeeID)
+		}
 		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
+
+func (r *repository) GetByEmployeeIDWithArchived(ctx context.Context, employeeID string) (*model.Employee, error) {
+	var out model.Employee
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Preload("Department").
+		First(&out, "employee_id = ?", employeeID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) RestoreByEmployeeID(ctx context.Context, employeeID string) error {
+	tx := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Employee{}).
+		Where("employee_id = ? AND deleted_at IS NOT NULL", employeeID).
+		Update("deleted_at", nil)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) PurgeByEmployeeID(ctx context.Context, employeeID string) error {
+	tx := r.db.WithContext(ctx).
+		Unscoped().
+		Where("employee_id = ? AND deleted_at IS NOT NULL", employeeID).
+		Delete(&model.Employee{})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) CreateAssignment(ctx context.Context, a *model.EmployeeDepartmentAssignment) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *repository) ListAssignments(ctx context.Context, employeeIDs ...string) ([]model.EmployeeDepartmentAssignment, error) {
+	var out []model.EmployeeDepartmentAssignment
+	if len(employeeIDs) == 0 {
+		return out, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("Department").
+		Where("employee_id IN ?", employeeIDs).
+		Order("employee_id ASC, effective_from ASC").
+		Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *repository) DepartmentOn(ctx context.Context, employeeID string, day time.Time) (*model.Department, error) {
+	emp, err := r.GetByEmployeeIDWithArchived(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	stints, err := r.ListAssignments(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	dept := model.DepartmentOn(stints, emp.Department, day)
+	return &dept, nil
+}
+
+type TransferParams struct {
+	DepartmentID uint64
+	// local calendar date, first day in the new department
+	EffectiveFrom time.Time
+}
+
+func (r *repository) Transfer(ctx context.Context, employeeID string, p TransferParams) error {
+	db := r.db.WithContext(ctx)
+	from := p.EffectiveFrom.Format("2006-01-02")
+
+	moved := db.Model(&model.EmployeeDepartmentAssignment{}).
+		Where("employee_id = ? AND effective_to IS NULL AND effective_from = ?", employeeID, from).
+		Update("department_id", p.DepartmentID)
+	if moved.Error != nil {
+		return moved.Error
+	}
+	if moved.RowsAffected == 0 {
+		if err := db.Model(&model.EmployeeDepartmentAssignment{}).
+			Where("employee_id = ? AND effective_to IS NULL", employeeID).
+			Update("effective_to", p.EffectiveFrom.AddDate(0, 0, -1).Format("2006-01-02")).Error; err != nil {
+			return err
+		}
+		y, m, d := p.EffectiveFrom.Date()
+		if err := db.Create(&model.EmployeeDepartmentAssignment{
+			EmployeeID:   employeeID,
+			DepartmentID: p.DepartmentID,
+			// a DATE column, keep the calendar date through the UTC connection
+			EffectiveFrom: time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	tx := db.Model(&model.Employee{}).
+		Where("employee_id = ?", employeeID).
+		Update("department_id", p.DepartmentID)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) ListAssignedJoinDept(ctx context.Context, departmentID *uint64, from, to time.Time) ([]model.Employee, error) {
+	if departmentID == nil {
+		return r.ListByDepartmentJoinDept(ctx, nil)
+	}
+
+	var items []model.Employee
+	if err := r.db.WithContext(ctx).
+		Model(&model.Employee{}).
+		Joins("Department").
+		Where(`employees.employee_id IN (
+			SELECT a.employee_id FROM employee_department_assignments a
+			WHERE a.department_id = ? AND a.effective_from <= ?
+				AND (a.effective_to IS NULL OR a.effective_to >= ?))`,
+			*departmentID, to.Format("2006-01-02"), from.Format("2006-01-02")).
+		Order("employees.employee_id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
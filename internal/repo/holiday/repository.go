@@ -0,0 +1,193 @@
+package holiday
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+
+	Create(ctx context.Context, d *model.Holiday) error
+	// one holiday per day and scope, nil being company wide
+	Exists(ctx context.Context, date time.Time, departmentID *uint64) (bool, error)
+	List(ctx context.Context, p ListParams) ([]model.Holiday, int64, error)
+	ListInRange(ctx context.Context, from, to time.Time) ([]model.Holiday, error)
+	GetByID(ctx context.Context, id uint64) (*model.Holiday, error)
+	UpdateByID(ctx context.Context, id uint64, p UpdateParams) error
+	DeleteByID(ctx context.Context, id uint64) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+func (r *repository) Create(ctx context.Context, d *model.Holiday) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *repository) Exists(ctx context.Context, date time.Time, departmentID *uint64) (bool, error) {
+	q := r.db.WithContext(ctx).
+		Model(&model.Holiday{}).
+		Where("holiday_date = ?", date.Format("2006-01-02"))
+
+	if departmentID != nil {
+		q = q.Where("department_id = ?", *departmentID)
+	} else {
+		q = q.Where("department_id IS NULL")
+	}
+
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+type ListParams struct {
+	From         *time.Time
+	To           *time.Time
+	DepartmentID *uint64
+	Kind         string
+	Limit        int
+	Page         int
+}
+
+func (r *repository) List(ctx context.Context, p ListParams) ([]model.Holiday, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.Holiday{})
+
+	if p.From != nil {
+		q = q.Where("holiday_date >= ?", p.From.Format("2006-01-02"))
+	}
+	if p.To != nil {
+		q = q.Where("holiday_date <= ?", p.To.Format("2006-01-02"))
+	}
+	// department filter keeps company wide holidays, they apply to everyone
+	if p.DepartmentID != nil {
+		q = q.Where("department_id = ? OR department_id IS NULL", *p.DepartmentID)
+	}
+	if k := strings.TrimSpace(p.Kind); k != "" {
+		q = q.Where("kind = ?", k)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.Holiday
+	if err := q.
+		Preload("Department").
+		Order("holiday_date ASC, id ASC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// Company wide and department specific holidays between from and to (inclusive dates)
+func (r *repository) ListInRange(ctx context.Context, from, to time.Time) ([]model.Holiday, error) {
+	var items []model.Holiday
+	if err := r.db.WithContext(ctx).
+		Where("holiday_date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("holiday_date ASC, id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint64) (*model.Holiday, error) {
+	var out model.Holiday
+	if err := r.db.WithContext(ctx).
+		Preload("Department").
+		First(&out, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type UpdateParams struct {
+	HolidayDate *time.Time
+	Name        *string
+	Kind        *string
+	// set ClearDepartment to make the holiday company wide again
+	DepartmentID    *uint64
+	ClearDepartment bool
+}
+
+func (r *repository) UpdateByID(ctx context.Context, id uint64, p UpdateParams) error {
+	updates := map[string]any{}
+
+	if p.HolidayDate != nil {
+		updates["holiday_date"] = p.HolidayDate.Format("2006-01-02")
+	}
+	if p.Name != nil {
+		updates["name"] = strings.TrimSpace(*p.Name)
+	}
+	if p.Kind != nil {
+		updates["kind"] = *p.Kind
+	}
+	if p.ClearDepartment {
+		updates["department_id"] = nil
+	} else if p.DepartmentID != nil {
+		updates["department_id"] = *p.DepartmentID
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&model.Holiday{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) DeleteByID(ctx context.Context, id uint64) error {
+	tx := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&model.Holiday{})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
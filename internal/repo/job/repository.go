@@ -0,0 +1,279 @@
+package job
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	RunRunning   = "running"
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+	// the replica running it went away before recording an outcome
+	RunAbandoned = "abandoned"
+
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+type Repository interface {
+	// Register adds a job, or updates schedule, timezone and description of an
+	// existing one. next_run_at is only replaced when the schedule changed.
+	Register(ctx context.Context, j *model.ScheduledJob) error
+	List(ctx context.Context) ([]model.ScheduledJob, error)
+	Get(ctx context.Context, name string) (*model.ScheduledJob, error)
+	SetPaused(ctx context.Context, name string, paused bool, nextRunAt *time.Time) error
+	RequestTrigger(ctx context.Context, name string) error
+
+	// AcquireLease takes the job for owner if it is due and nobody else holds
+	// it. manual reports whether the run was requested by an admin.
+	AcquireLease(ctx context.Context, name, owner string, now, until time.Time) (ok, manual bool, err error)
+	RenewLease(ctx context.Context, name, owner string, until time.Time) error
+	ReleaseLease(ctx context.Context, name, owner string, p ReleaseParams) error
+
+	CreateRun(ctx context.Context, r *model.JobRun) error
+	FinishRun(ctx context.Context, runID string, p FinishParams) error
+	// AbandonRuns closes runs of name still marked running, except keepRunID
+	AbandonRuns(ctx context.Context, name, keepRunID string) error
+	ListRuns(ctx context.Context, p RunListParams) ([]model.JobRun, int64, error)
+	PurgeRunsBefore(ctx context.Context, before time.Time, batch int) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Register(ctx context.Context, j *model.ScheduledJob) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(j)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 1 {
+			return nil
+		}
+
+		var cur model.ScheduledJob
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&cur, "name = ?", j.Name).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]any{"description": j.Description}
+		if cur.Schedule != j.Schedule || cur.Timezone != j.Timezone || cur.NextRunAt == nil {
+			updates["schedule"] = j.Schedule
+			updates["timezone"] = j.Timezone
+			updates["next_run_at"] = j.NextRunAt
+		}
+		return tx.Model(&model.ScheduledJob{}).
+			Where("id = ?", cur.ID).
+			Updates(updates).Error
+	})
+}
+
+func (r *repository) List(ctx context.Context) ([]model.ScheduledJob, error) {
+	var items []model.ScheduledJob
+	if err := r.db.WithContext(ctx).
+		Order("name ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repository) Get(ctx context.Context, name string) (*model.ScheduledJob, error) {
+	var out model.ScheduledJob
+	if err := r.db.WithContext(ctx).First(&out, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) SetPaused(ctx context.Context, name string, paused bool, nextRunAt *time.Time) error {
+	updates := map[string]any{"paused": paused}
+	if nextRunAt != nil {
+		updates["next_run_at"] = *nextRunAt
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&model.ScheduledJob{}).
+		Where("name = ?", name).
+		Updates(updates)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) RequestTrigger(ctx context.Context, name string) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.ScheduledJob{}).
+		Where("name = ?", name).
+		Update("trigger_requested", true)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) AcquireLease(ctx context.Context, name, owner string, now, until time.Time) (ok, manual bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var j model.ScheduledJob
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&j, "name = ?", name).Error; err != nil {
+			return err
+		}
+
+		due := j.TriggerRequested || (!j.Paused && j.NextRunAt != nil && !j.NextRunAt.After(now))
+		free := j.LeaseUntil == nil || j.LeaseUntil.Before(now)
+		if !due || !free {
+			return nil
+		}
+
+		if err := tx.Model(&model.ScheduledJob{}).
+			Where("id = ?", j.ID).
+			Updates(map[string]any{
+				"lease_owner":       owner,
+				"lease_until":       until,
+				"trigger_requested": false,
+			}).Error; err != nil {
+			return err
+		}
+		ok, manual = true, j.TriggerRequested
+		return nil
+	})
+	return ok, manual, err
+}
+
+func (r *repository) RenewLease(ctx context.Context, name, owner string, until time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&model.ScheduledJob{}).
+		Where("name = ? AND lease_owner = ?", name, owner).
+		Update("lease_until", until).Error
+}
+
+type ReleaseParams struct {
+	NextRunAt  *time.Time
+	LastRunAt  time.Time
+	LastStatus string
+}
+
+func (r *repository) ReleaseLease(ctx context.Context, name, owner string, p ReleaseParams) error {
+	return r.db.WithContext(ctx).
+		Model(&model.ScheduledJob{}).
+		Where("name = ? AND lease_owner = ?", name, owner).
+		Updates(map[string]any{
+			"lease_owner": nil,
+			"lease_until": nil,
+			"next_run_at": p.NextRunAt,
+			"last_run_at": p.LastRunAt,
+			"last_status": p.LastStatus,
+		}).Error
+}
+
+func (r *repository) CreateRun(ctx context.Context, run *model.JobRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+type FinishParams struct {
+	Status     string
+	FinishedAt time.Time
+	DurationMs int64
+	Output     *string
+	Error      *string
+}
+
+func (r *repository) FinishRun(ctx context.Context, runID string, p FinishParams) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.JobRun{}).
+		Where("run_id = ?", runID).
+		Updates(map[string]any{
+			"status":      p.Status,
+			"finished_at": p.FinishedAt,
+			"duration_ms": p.DurationMs,
+			"output":      p.Output,
+			"error":       p.Error,
+		})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) AbandonRuns(ctx context.Context, name, keepRunID string) error {
+	return r.db.WithContext(ctx).
+		Model(&model.JobRun{}).
+		Where("job_name = ? AND status = ? AND run_id <> ?", name, RunRunning, keepRunID).
+		Updates(map[string]any{
+			"status":      RunAbandoned,
+			"finished_at": time.Now().UTC(),
+		}).Error
+}
+
+type RunListParams struct {
+	JobName string
+	Status  string
+	Limit   int
+	Page    int
+}
+
+func (r *repository) ListRuns(ctx context.Context, p RunListParams) ([]model.JobRun, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.JobRun{})
+	if s := strings.TrimSpace(p.JobName); s != "" {
+		q = q.Where("job_name = ?", s)
+	}
+	if s := strings.TrimSpace(p.Status); s != "" {
+		q = q.Where("status = ?", s)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.JobRun
+	if err := q.
+		Order("started_at DESC, id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *repository) PurgeRunsBefore(ctx context.Context, before time.Time, batch int) (int64, error) {
+	tx := r.db.WithContext(ctx).
+		Exec("DELETE FROM job_runs WHERE started_at < ? AND status <> ? LIMIT ?", before, RunRunning, batch)
+	return tx.RowsAffected, tx.Error
+}
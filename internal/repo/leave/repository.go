@@ -0,0 +1,322 @@
+package leave
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+
+	// Leave types
+	CreateType(ctx context.Context, d *model.LeaveType) error
+	ListTypes(ctx context.Context) ([]model.LeaveType, error)
+	GetTypeByID(ctx context.Context, id uint64) (*model.LeaveType, error)
+	ExistsTypeByCode(ctx context.Context, code string) (bool, error)
+	UpdateTypeByID(ctx context.Context, id uint64, p UpdateTypeParams) error
+	DeleteTypeByID(ctx context.Context, id uint64) error
+
+	// Leave requests
+	// locks the employee row until the transaction ends, serializing the
+	// overlap and quota checks of one employee
+	LockEmployee(ctx context.Context, employeeID string) error
+	CreateRequest(ctx context.Context, d *model.LeaveRequest) error
+	GetRequestByRequestID(ctx context.Context, requestID string) (*model.LeaveRequest, error)
+	GetRequestForUpdate(ctx context.Context, requestID string) (*model.LeaveRequest, error)
+	ListRequests(ctx context.Context, p ListRequestParams) ([]model.LeaveRequest, int64, error)
+	HasOverlap(ctx context.Context, employeeID string, from, to time.Time) (bool, error)
+	// requests never cross a year (ck_leave_requests_one_year), so all their
+	// days count towards the year they start in
+	SumDays(ctx context.Context, employeeID string, leaveTypeID uint64, year int, statuses ...string) (int, error)
+	ListApprovedInRange(ctx context.Context, from, to time.Time, employeeID *string) ([]model.LeaveRequest, error)
+	UpdateDecision(ctx context.Context, requestID string, p DecisionParams) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+func (r *repository) CreateType(ctx context.Context, d *model.LeaveType) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *repository) ListTypes(ctx context.Context) ([]model.LeaveType, error) {
+	var items []model.LeaveType
+	if err := r.db.WithContext(ctx).Order("code ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repository) GetTypeByID(ctx context.Context, id uint64) (*model.LeaveType, error) {
+	var out model.LeaveType
+	if err := r.db.WithContext(ctx).First(&out, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) ExistsTypeByCode(ctx context.Context, code string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.LeaveType{}).
+		Where("code = ?", code).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+type UpdateTypeParams struct {
+	Code        *string
+	Name        *string
+	YearlyQuota *int
+}
+
+func (r *repository) UpdateTypeByID(ctx context.Context, id uint64, p UpdateTypeParams) error {
+	updates := map[string]any{}
+
+	if p.Code != nil {
+		updates["code"] = strings.TrimSpace(*p.Code)
+	}
+	if p.Name != nil {
+		updates["name"] = strings.TrimSpace(*p.Name)
+	}
+	if p.YearlyQuota != nil {
+		updates["yearly_quota"] = *p.YearlyQuota
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&model.LeaveType{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) DeleteTypeByID(ctx context.Context, id uint64) error {
+	tx := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&model.LeaveType{})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) LockEmployee(ctx context.Context, employeeID string) error {
+	var id uint64
+	// archived employees keep their pending requests
+	tx := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Employee{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("employee_id = ?", employeeID).
+		Scan(&id)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) CreateRequest(ctx context.Context, d *model.LeaveRequest) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *repository) GetRequestByRequestID(ctx context.Context, requestID string) (*model.LeaveRequest, error) {
+	var out model.LeaveRequest
+	if err := r.db.WithContext(ctx).
+		Preload("Employee", withArchived).
+		Preload("LeaveType").
+		First(&out, "request_id = ?", requestID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) GetRequestForUpdate(ctx context.Context, requestID string) (*model.LeaveRequest, error) {
+	var out model.LeaveRequest
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&out, "request_id = ?", requestID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type ListRequestParams struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	Status       string
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	Page         int
+}
+
+func (r *repository) ListRequests(ctx context.Context, p ListRequestParams) ([]model.LeaveRequest, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.LeaveRequest{})
+
+	if e := strings.TrimSpace(p.EmployeeID); e != "" {
+		q = q.Where("leave_requests.employee_id = ?", e)
+	}
+	if p.DepartmentID != nil {
+		q = q.Joins("JOIN employees e ON e.employee_id = leave_requests.employee_id").
+			Where("e.department_id = ?", *p.DepartmentID)
+	}
+	if s := strings.TrimSpace(p.Status); s != "" {
+		q = q.Where("leave_requests.status = ?", s)
+	}
+	// any overlap with [From, To]
+	if p.From != nil {
+		q = q.Where("leave_requests.end_date >= ?", p.From.Format("2006-01-02"))
+	}
+	if p.To != nil {
+		q = q.Where("leave_requests.start_date <= ?", p.To.Format("2006-01-02"))
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.LeaveRequest
+	if err := q.
+		Select("leave_requests.*").
+		Preload("Employee", withArchived).
+		Preload("LeaveType").
+		Order("leave_requests.start_date DESC, leave_requests.id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// Pending and approved requests block overlapping ones
+func (r *repository) HasOverlap(ctx context.Context, employeeID string, from, to time.Time) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.LeaveRequest{}).
+		Where("employee_id = ? AND status IN ?", employeeID, []string{StatusPending, StatusApproved}).
+		Where("start_date <= ? AND end_date >= ?", to.Format("2006-01-02"), from.Format("2006-01-02")).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repository) SumDays(ctx context.Context, employeeID string, leaveTypeID uint64, year int, statuses ...string) (int, error) {
+	var sum int64
+	err := r.db.WithContext(ctx).
+		Model(&model.LeaveRequest{}).
+		Select("COALESCE(SUM(days), 0)").
+		Where("employee_id = ? AND leave_type_id = ? AND YEAR(start_date) = ?", employeeID, leaveTypeID, year).
+		Where("status IN ?", statuses).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(sum), nil
+}
+
+func (r *repository) ListApprovedInRange(ctx context.Context, from, to time.Time, employeeID *string) ([]model.LeaveRequest, error) {
+	q := r.db.WithContext(ctx).
+		Preload("LeaveType").
+		Where("status = ?", StatusApproved).
+		Where("start_date <= ? AND end_date >= ?", to.Format("2006-01-02"), from.Format("2006-01-02"))
+
+	if employeeID != nil {
+		q = q.Where("employee_id = ?", *employeeID)
+	}
+
+	var items []model.LeaveRequest
+	if err := q.Order("start_date ASC, id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+type DecisionParams struct {
+	Status     string
+	ApproverID string
+	Note       *string
+	DecidedAt  time.Time
+}
+
+func (r *repository) UpdateDecision(ctx context.Context, requestID string, p DecisionParams) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.LeaveRequest{}).
+		Where("request_id = ? AND status = ?", requestID, StatusPending).
+		Updates(map[string]any{
+			"status":        p.Status,
+			"approver_id":   p.ApproverID,
+			"decision_note": p.Note,
+			"decided_at":    p.DecidedAt,
+		})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// withArchived lets a preload reach soft-deleted employees, their past rows
+// still point at them
+func withArchived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetPreference(ctx context.Context, employeeID string) (*model.NotificationPreference, error)
+	ListPreferences(ctx context.Context, employeeIDs []string) (map[string]model.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, p *model.NotificationPreference) error
+
+	ListDigestRecipients(ctx context.Context) ([]DigestRecipient, error)
+
+	// LogSent records a notification before it is sent, false when one with
+	// the same kind and ref was logged already
+	LogSent(ctx context.Context, kind, refKey, recipient string) (bool, error)
+	// Unlog drops a log row again after a failed send, so it is retried
+	Unlog(ctx context.Context, kind, refKey string) error
+	PurgeLogsBefore(ctx context.Context, before time.Time, batch int) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetPreference(ctx context.Context, employeeID string) (*model.NotificationPreference, error) {
+	var out model.NotificationPreference
+	if err := r.db.WithContext(ctx).First(&out, "employee_id = ?", employeeID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) ListPreferences(ctx context.Context, employeeIDs []string) (map[string]model.NotificationPreference, error) {
+	out := map[string]model.NotificationPreference{}
+	if len(employeeIDs) == 0 {
+		return out, nil
+	}
+
+	var items []model.NotificationPreference
+	if err := r.db.WithContext(ctx).
+		Where("employee_id IN ?", employeeIDs).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range items {
+		out[p.EmployeeID] = p
+	}
+	return out, nil
+}
+
+func (r *repository) UpsertPreference(ctx context.Context, p *model.NotificationPreference) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "employee_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"daily_digest", "clock_out_reminder", "updated_at"}),
+	}).Create(p).Error
+}
+
+// DigestRecipient is a department whose manager has an email and has not
+// turned the digest off.
+type DigestRecipient struct {
+	DepartmentID      uint64
+	DepartmentName    string
+	ManagerEmployeeID string
+	ManagerName       string
+	ManagerEmail      string
+}
+
+func (r *repository) ListDigestRecipients(ctx context.Context) ([]DigestRecipient, error) {
+	var out []DigestRecipient
+	if err := r.db.WithContext(ctx).
+		Table("departments d").
+		Select(`d.id AS department_id, d.department_name,
+			e.employee_id AS manager_employee_id, e.name AS manager_name, e.email AS manager_email`).
+		Joins("JOIN employees e ON e.employee_id = d.manager_employee_id AND e.deleted_at IS NULL").
+		Joins("LEFT JOIN notification_preferences np ON np.employee_id = e.employee_id").
+		Where("e.email IS NOT NULL AND COALESCE(np.daily_digest, 1) = 1").
+		Order("d.id ASC").
+		Scan(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *repository) LogSent(ctx context.Context, kind, refKey, recipient string) (bool, error) {
+	err := r.db.WithContext(ctx).Create(&model.NotificationLog{
+		Kind:      kind,
+		RefKey:    refKey,
+		Recipient: recipient,
+		SentAt:    time.Now().UTC(),
+	}).Error
+	if err != nil {
+		var me *mysql.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) Unlog(ctx context.Context, kind, refKey string) error {
+	return r.db.WithContext(ctx).
+		Where("kind = ? AND ref_key = ?", kind, refKey).
+		Delete(&model.NotificationLog{}).Error
+}
+
+func (r *repository) PurgeLogsBefore(ctx context.Context, before time.Time, batch int) (int64, error) {
+	tx := r.db.WithContext(ctx).
+		Exec("DELETE FROM notification_logs WHERE sent_at < ? LIMIT ?", before, batch)
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository is the transactional outbox. Domain repositories hand one out
+// bound to their own transaction, so an event is stored if and only if the
+// change that caused it commits.
+type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+
+	Add(ctx context.Context, eventType string, data any) error
+	ClaimPending(ctx context.Context, limit int) ([]model.OutboxEvent, error)
+	MarkDispatched(ctx context.Context, ids []uint64, at time.Time) error
+	PurgeDispatchedBefore(ctx context.Context, before time.Time, batch int) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// Envelope is what subscribers receive as the request body.
+type Envelope struct {
+	EventID    string    `json:"event_id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+func (r *repository) Add(ctx context.Context, eventType string, data any) error {
+	env := Envelope{
+		EventID:    uuid.NewString(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(&model.OutboxEvent{
+		EventID:   env.EventID,
+		EventType: eventType,
+		Payload:   payload,
+	}).Error
+}
+
+// ClaimPending locks undispatched events, oldest first. Rows locked by
+// another dispatcher are skipped. Call inside WithTx.
+func (r *repository) ClaimPending(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var items []model.OutboxEvent
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("dispatched_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repository) MarkDispatched(ctx context.Context, ids []uint64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&model.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Update("dispatched_at", at).Error
+}
+
+func (r *repository) PurgeDispatchedBefore(ctx context.Context, before time.Time, batch int) (int64, error) {
+	tx := r.db.WithContext(ctx).
+		Exec("DELETE FROM outbox_events WHERE dispatched_at IS NOT NULL AND dispatched_at < ? LIMIT ?", before, batch)
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,260 @@
+package overtime
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+const (
+	DayWorkday = "workday"
+	DayRest    = "rest_day"
+	DayHoliday = "holiday"
+)
+
+type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+
+	// takes the employee row lock that serializes cap checks of the employee
+	LockEmployee(ctx context.Context, employeeID string) error
+	Create(ctx context.Context, d *model.OvertimeRecord) error
+	ExistsByAttendanceID(ctx context.Context, attendanceID string) (bool, error)
+	SumMinutes(ctx context.Context, employeeID string, from, to time.Time, statuses ...string) (int, error)
+	GetByOvertimeID(ctx context.Context, overtimeID string) (*model.OvertimeRecord, error)
+	GetForUpdate(ctx context.Context, overtimeID string) (*model.OvertimeRecord, error)
+	List(ctx context.Context, p ListParams) ([]model.OvertimeRecord, int64, error)
+	UpdateDecision(ctx context.Context, overtimeID string, p DecisionParams) error
+	ApprovedTotals(ctx context.Context, p TotalsParams) ([]TotalRow, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+func (r *repository) LockEmployee(ctx context.Context, employeeID string) error {
+	var id uint64
+	// archived employees keep their attendance to compute
+	tx := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Employee{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("employee_id = ?", employeeID).
+		Scan(&id)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) Create(ctx context.Context, d *model.OvertimeRecord) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *repository) ExistsByAttendanceID(ctx context.Context, attendanceID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.OvertimeRecord{}).
+		Where("attendance_id = ?", attendanceID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SumMinutes adds up capped minutes on work dates in [from, to]
+func (r *repository) SumMinutes(ctx context.Context, employeeID string, from, to time.Time, statuses ...string) (int, error) {
+	var sum int64
+	err := r.db.WithContext(ctx).
+		Model(&model.OvertimeRecord{}).
+		Select("COALESCE(SUM(minutes), 0)").
+		Where("employee_id = ? AND work_date BETWEEN ? AND ?", employeeID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Where("status IN ?", statuses).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(sum), nil
+}
+
+func (r *repository) GetByOvertimeID(ctx context.Context, overtimeID string) (*model.OvertimeRecord, error) {
+	var out model.OvertimeRecord
+	if err := r.db.WithContext(ctx).
+		Preload("Employee", withArchived).
+		First(&out, "overtime_id = ?", overtimeID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *repository) GetForUpdate(ctx context.Context, overtimeID string) (*model.OvertimeRecord, error) {
+	var out model.OvertimeRecord
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&out, "overtime_id = ?", overtimeID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type ListParams struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	Status       string
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	Page         int
+}
+
+func (r *repository) List(ctx context.Context, p ListParams) ([]model.OvertimeRecord, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.OvertimeRecord{})
+
+	if e := strings.TrimSpace(p.EmployeeID); e != "" {
+		q = q.Where("overtime_records.employee_id = ?", e)
+	}
+	if p.DepartmentID != nil {
+		q = q.Joins("JOIN employees e ON e.employee_id = overtime_records.employee_id").
+			Where("e.department_id = ?", *p.DepartmentID)
+	}
+	if s := strings.TrimSpace(p.Status); s != "" {
+		q = q.Where("overtime_records.status = ?", s)
+	}
+	if p.From != nil {
+		q = q.Where("overtime_records.work_date >= ?", p.From.Format("2006-01-02"))
+	}
+	if p.To != nil {
+		q = q.Where("overtime_records.work_date <= ?", p.To.Format("2006-01-02"))
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.OvertimeRecord
+	if err := q.
+		Select("overtime_records.*").
+		Preload("Employee", withArchived).
+		Order("overtime_records.work_date DESC, overtime_records.id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+type DecisionParams struct {
+	Status     string
+	ApproverID string
+	Note       *string
+	DecidedAt  time.Time
+}
+
+func (r *repository) UpdateDecision(ctx context.Context, overtimeID string, p DecisionParams) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.OvertimeRecord{}).
+		Where("overtime_id = ? AND status = ?", overtimeID, StatusPending).
+		Updates(map[string]any{
+			"status":        p.Status,
+			"approver_id":   p.ApproverID,
+			"decision_note": p.Note,
+			"decided_at":    p.DecidedAt,
+		})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+type TotalsParams struct {
+	From         time.Time
+	To           time.Time
+	EmployeeID   string
+	DepartmentID *uint64
+}
+
+type TotalRow struct {
+	EmployeeID      string
+	EmployeeName    string
+	DepartmentID    uint64
+	Records         int64
+	Minutes         int64
+	WeightedMinutes float64
+}
+
+// Approved overtime per employee with work dates in [From, To]
+func (r *repository) ApprovedTotals(ctx context.Context, p TotalsParams) ([]TotalRow, error) {
+	q := r.db.WithContext(ctx).
+		Table("overtime_records o").
+		Select(`o.employee_id AS employee_id,
+			e.name AS employee_name,
+			e.department_id AS department_id,
+			COUNT(*) AS records,
+			COALESCE(SUM(o.minutes), 0) AS minutes,
+			COALESCE(SUM(o.minutes * o.multiplier), 0) AS weighted_minutes`).
+		Joins("JOIN employees e ON e.employee_id = o.employee_id").
+		Where("o.status = ?", StatusApproved).
+		Where("o.work_date BETWEEN ? AND ?", p.From.Format("2006-01-02"), p.To.Format("2006-01-02"))
+
+	if e := strings.TrimSpace(p.EmployeeID); e != "" {
+		q = q.Where("o.employee_id = ?", e)
+	}
+	if p.DepartmentID != nil {
+		q = q.Where("e.department_id = ?", *p.DepartmentID)
+	}
+
+	var rows []TotalRow
+	if err := q.
+		Group("o.employee_id, e.name, e.department_id").
+		Order("e.name ASC, o.employee_id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// withArchived lets a preload reach soft-deleted employees, their past rows
+// still point at them
+func withArchived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
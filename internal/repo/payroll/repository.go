@@ -0,0 +1,131 @@
+package payroll
+
+import (
+	"context"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, t *model.PayrollTemplate) error
+	ExistsByName(ctx context.Context, name string) (bool, error)
+	List(ctx context.Context) ([]model.PayrollTemplate, error)
+	GetByName(ctx context.Context, name string) (*model.PayrollTemplate, error)
+	UpdateByName(ctx context.Context, name string, p UpdateParams) error
+	DeleteByName(ctx context.Context, name string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, t *model.PayrollTemplate) error {
+	return r.db.WithContext(ctx).Create(t).Error
+}
+
+func (r *repository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.PayrollTemplate{}).
+		Where("name = ?", name).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Templates are few, no pagination
+func (r *repository) List(ctx context.Context) ([]model.PayrollTemplate, error) {
+	var items []model.PayrollTemplate
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repository) GetByName(ctx context.Context, name string) (*model.PayrollTemplate, error) {
+	var out model.PayrollTemplate
+	if err := r.db.WithContext(ctx).First(&out, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type UpdateParams struct {
+	Name *string
+	// "" clears
+	Description      *string
+	Delimiter        *string
+	IncludeHeader    *bool
+	DateFormat       *string
+	DecimalSeparator *string
+	UseCRLF          *bool
+	Columns          []byte
+}
+
+func (r *repository) UpdateByName(ctx context.Context, name string, p UpdateParams) error {
+	updates := map[string]any{}
+
+	if p.Name != nil {
+		updates["name"] = *p.Name
+	}
+	if p.Description != nil {
+		if *p.Description != "" {
+			updates["description"] = *p.Description
+		} else {
+			updates["description"] = nil
+		}
+	}
+	if p.Delimiter != nil {
+		updates["delimiter"] = *p.Delimiter
+	}
+	if p.IncludeHeader != nil {
+		updates["include_header"] = *p.IncludeHeader
+	}
+	if p.DateFormat != nil {
+		updates["date_format"] = *p.DateFormat
+	}
+	if p.DecimalSeparator != nil {
+		updates["decimal_separator"] = *p.DecimalSeparator
+	}
+	if p.UseCRLF != nil {
+		updates["use_crlf"] = *p.UseCRLF
+	}
+	if p.Columns != nil {
+		updates["columns"] = p.Columns
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&model.PayrollTemplate{}).
+		Where("name = ?", name).
+		Updates(updates)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) DeleteByName(ctx context.Context, name string) error {
+	tx := r.db.WithContext(ctx).
+		Where("name = ?", name).
+		Delete(&model.PayrollTemplate{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
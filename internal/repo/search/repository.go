@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TypeEmployee   = "employee"
+	TypeDepartment = "department"
+)
+
+type Repository interface {
+	Search(ctx context.Context, p Params) ([]Hit, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+type Params struct {
+	Query string
+	// empty searches every type
+	Types []string
+	Limit int
+}
+
+// Hit is one ranked match. Ref is the employee_id for employees, Detail the
+// department name of an employee.
+type Hit struct {
+	Type   string
+	ID     uint64
+	Ref    *string
+	Title  string
+	Detail *string
+	Score  float64
+}
+
+const employeeHitsSQL = `
+SELECT 'employee' AS type, e.id, e.employee_id AS ref, e.name AS title, d.department_name AS detail,
+       MATCH(e.name, e.address, e.employee_id) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+FROM employees e
+JOIN departments d ON d.id = e.department_id
+WHERE MATCH(e.name, e.address, e.employee_id) AGAINST (? IN NATURAL LANGUAGE MODE)
+  AND e.deleted_at IS NULL`
+
+const departmentHitsSQL = `
+SELECT 'department' AS type, d.id, NULL AS ref, d.department_name AS title, NULL AS detail,
+       MATCH(d.department_name) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+FROM departments d
+WHERE MATCH(d.department_name) AGAINST (? IN NATURAL LANGUAGE MODE)`
+
+// Search ranks FULLTEXT matches of every requested type together, highest
+// relevance first.
+func (r *repository) Search(ctx context.Context, p Params) ([]Hit, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 20
+	}
+
+	var parts []string
+	var args []any
+	if wants(p.Types, TypeEmployee) {
+		parts = append(parts, employeeHitsSQL)
+		args = append(args, p.Query, p.Query)
+	}
+	if wants(p.Types, TypeDepartment) {
+		parts = append(parts, departmentHitsSQL)
+		args = append(args, p.Query, p.Query)
+	}
+	if len(parts) == 0 {
+		return []Hit{}, nil
+	}
+
+	q := strings.Join(parts, "\nUNION ALL\n") + "\nORDER BY score DESC, type, id\nLIMIT ?"
+	args = append(args, p.Limit)
+
+	var out []Hit
+	if err := r.db.WithContext(ctx).Raw(q, args...).Scan(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func wants(types []string, t string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,338 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+type Repository interface {
+	WithTx(ctx context.Context, fn func(txRepo Repository) error) error
+	// Outbox shares this repository's connection, inside WithTx the same transaction
+	Outbox() outboxrepo.Repository
+
+	// Subscriptions
+	CreateSubscription(ctx context.Context, d *model.WebhookSubscription) error
+	GetSubscription(ctx context.Context, subscriptionID string) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, p ListParams) ([]model.WebhookSubscription, int64, error)
+	ListActiveSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscriptionID string, p UpdateParams) error
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	// Deliveries
+	CreateDeliveries(ctx context.Context, items []model.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, deliveryID string, p AttemptParams) error
+	GetDelivery(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, p DeliveryListParams) ([]model.WebhookDelivery, int64, error)
+	// PurgeDeliveriesBefore drops finished deliveries, pending ones are kept
+	PurgeDeliveriesBefore(ctx context.Context, before time.Time, batch int) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Transaction boundary
+func (r *repository) WithTx(ctx context.Context, fn func(txRepo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &repository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+func (r *repository) Outbox() outboxrepo.Repository {
+	return outboxrepo.New(r.db)
+}
+
+func (r *repository) CreateSubscription(ctx context.Context, d *model.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *repository) GetSubscription(ctx context.Context, subscriptionID string) (*model.WebhookSubscription, error) {
+	var out model.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&out, "subscription_id = ?", subscriptionID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type ListParams struct {
+	Active *bool
+	Limit  int
+	Page   int
+}
+
+func (r *repository) ListSubscriptions(ctx context.Context, p ListParams) ([]model.WebhookSubscription, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.WebhookSubscription{})
+	if p.Active != nil {
+		q = q.Where("active = ?", *p.Active)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.WebhookSubscription
+	if err := q.
+		Order("id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *repository) ListActiveSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	var items []model.WebhookSubscription
+	if err := r.db.WithContext(ctx).
+		Where("active = ?", true).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+type UpdateParams struct {
+	URL         *string
+	Secret      *string
+	EventTypes  *string
+	Description *string
+	Active      *bool
+}
+
+func (r *repository) UpdateSubscription(ctx context.Context, subscriptionID string, p UpdateParams) error {
+	updates := map[string]any{}
+
+	if p.URL != nil {
+		updates["url"] = strings.TrimSpace(*p.URL)
+	}
+	if p.Secret != nil {
+		updates["secret"] = *p.Secret
+	}
+	if p.EventTypes != nil {
+		updates["event_types"] = *p.EventTypes
+	}
+	if p.Description != nil {
+		updates["description"] = strings.TrimSpace(*p.Description)
+	}
+	if p.Active != nil {
+		updates["active"] = *p.Active
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&model.WebhookSubscription{}).
+		Where("subscription_id = ?", subscriptionID).
+		Updates(updates)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	tx := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Delete(&model.WebhookSubscription{})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) CreateDeliveries(ctx context.Context, items []model.WebhookDelivery) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(&items).Error
+}
+
+// ClaimDueDeliveries picks pending deliveries whose next attempt is due and
+// pushes their next attempt out by lease, so a second dispatcher does not send
+// them again while this one is working. Subscriptions are loaded.
+func (r *repository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var items []model.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(items))
+		for i := range items {
+			ids[i] = items[i].ID
+		}
+		return tx.Model(&model.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	return r.withSubscriptions(ctx, items)
+}
+
+func (r *repository) withSubscriptions(ctx context.Context, items []model.WebhookDelivery) ([]model.WebhookDelivery, error) {
+	ids := make([]string, 0, len(items))
+	for _, d := range items {
+		ids = append(ids, d.SubscriptionID)
+	}
+
+	var subs []model.WebhookSubscription
+	if err := r.db.WithContext(ctx).
+		Where("subscription_id IN ?", ids).
+		Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	bySub := make(map[string]model.WebhookSubscription, len(subs))
+	for _, s := range subs {
+		bySub[s.SubscriptionID] = s
+	}
+	for i := range items {
+		items[i].Subscription = bySub[items[i].SubscriptionID]
+	}
+	return items, nil
+}
+
+type AttemptParams struct {
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	StatusCode    *int
+	Error         *string
+	DeliveredAt   *time.Time
+}
+
+func (r *repository) RecordAttempt(ctx context.Context, deliveryID string, p AttemptParams) error {
+	tx := r.db.WithContext(ctx).
+		Model(&model.WebhookDelivery{}).
+		Where("delivery_id = ?", deliveryID).
+		Updates(map[string]any{
+			"status":           p.Status,
+			"attempts":         p.Attempts,
+			"next_attempt_at":  p.NextAttemptAt,
+			"last_status_code": p.StatusCode,
+			"last_error":       p.Error,
+			"delivered_at":     p.DeliveredAt,
+		})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) GetDelivery(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error) {
+	var out model.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Preload("Subscription").
+		First(&out, "delivery_id = ?", deliveryID).Error; err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type DeliveryListParams struct {
+	SubscriptionID string
+	Status         string
+	EventType      string
+	Limit          int
+	Page           int
+}
+
+func (r *repository) ListDeliveries(ctx context.Context, p DeliveryListParams) ([]model.WebhookDelivery, int64, error) {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 10
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	q := r.db.WithContext(ctx).Model(&model.WebhookDelivery{})
+
+	if s := strings.TrimSpace(p.SubscriptionID); s != "" {
+		q = q.Where("subscription_id = ?", s)
+	}
+	if s := strings.TrimSpace(p.Status); s != "" {
+		q = q.Where("status = ?", s)
+	}
+	if s := strings.TrimSpace(p.EventType); s != "" {
+		q = q.Where("event_type = ?", s)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.WebhookDelivery
+	if err := q.
+		Order("id DESC").
+		Limit(p.Limit).
+		Offset((p.Page - 1) * p.Limit).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *repository) PurgeDeliveriesBefore(ctx context.Context, before time.Time, batch int) (int64, error) {
+	tx := r.db.WithContext(ctx).
+		Exec("DELETE FROM webhook_deliveries WHERE status <> ? AND created_at < ? LIMIT ?", DeliveryPending, before, batch)
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Fields take *, numbers, ranges (1-5), steps (*/15, 0-30/10) and lists
+// (1,15). Months and weekdays also take names (jan, mon); Sunday is 0 or 7.
+// The usual macros work too: @hourly, @daily (@midnight), @weekly, @monthly,
+// @yearly (@annually). Like classic cron, when both day fields are restricted
+// a day matches if either does. A local time skipped by a DST change does
+// not run that day; one repeated by it runs only the first time, unless the
+// hour field is *.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	hourAny, domAny, dowAny       bool
+}
+
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is folded into 0 after parsing
+	dowBounds = bounds{0, 7, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses spec. A leading "CRON_TZ=<zone> " (or "TZ=") pins the
+// schedule to that zone and is returned as tz, "" when absent.
+func ParseCron(spec string) (sched *Schedule, tz string, err error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.IndexByte(spec, ' ')
+		if i < 0 {
+			return nil, "", fmt.Errorf("cron %q: missing fields after timezone", spec)
+		}
+		tz = spec[strings.IndexByte(spec, '=')+1 : i]
+		if _, err := time.LoadLocation(tz); err != nil {
+			return nil, "", fmt.Errorf("cron %q: unknown timezone %q", spec, tz)
+		}
+		spec = strings.TrimSpace(spec[i+1:])
+	}
+	if m, ok := macros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, "", fmt.Errorf("cron %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &Schedule{}
+	if s.minute, _, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, "", fmt.Errorf("cron %q: minute: %w", spec, err)
+	}
+	if s.hour, s.hourAny, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, "", fmt.Errorf("cron %q: hour: %w", spec, err)
+	}
+	if s.dom, s.domAny, err = parseField(fields[2], domBounds); err != nil {
+		return nil, "", fmt.Errorf("cron %q: day of month: %w", spec, err)
+	}
+	if s.month, _, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, "", fmt.Errorf("cron %q: month: %w", spec, err)
+	}
+	if s.dow, s.dowAny, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, "", fmt.Errorf("cron %q: day of week: %w", spec, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, tz, nil
+}
+
+// parseField returns the matching values as a bitset, and whether the field
+// was a bare * (or ?).
+func parseField(field string, b bounds) (uint64, bool, error) {
+	if field == "*" || field == "?" {
+		return rangeBits(b.min, b.max, 1), true, nil
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		if part == "" {
+			return 0, false, fmt.Errorf("empty list item in %q", field)
+		}
+
+		expr, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, false, fmt.Errorf("invalid step in %q", part)
+			}
+			expr, step = part[:i], n
+		}
+
+		lo, hi := b.min, b.max
+		switch {
+		case expr == "*" || expr == "?":
+		case strings.Contains(expr, "-"):
+			ends := strings.SplitN(expr, "-", 2)
+			var err error
+			if lo, err = parseValue(ends[0], b); err != nil {
+				return 0, false, err
+			}
+			if hi, err = parseValue(ends[1], b); err != nil {
+				return 0, false, err
+			}
+			if lo > hi {
+				return 0, false, fmt.Errorf("range %q runs backwards", expr)
+			}
+		default:
+			v, err := parseValue(expr, b)
+			if err != nil {
+				return 0, false, err
+			}
+			lo = v
+			// "5/15" means from 5 to the end in steps of 15
+			if step == 1 {
+				hi = v
+			}
+		}
+		bits |= rangeBits(lo, hi, step)
+	}
+	return bits, false, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+func rangeBits(lo, hi, step int) uint64 {
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+// Next returns the first matching minute strictly after t, evaluated in loc.
+// The zero time is returned if nothing matches within five years, e.g. for
+// "0 0 30 2 *".
+func (s *Schedule) Next(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 || (!s.hourAny && repeated(t)) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward returns next, unless a DST transition made time.Date land at or
+// before t, then it steps one minute so the search always moves on.
+func forward(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Minute)
+}
+
+// repeated reports whether the wall clock of t already occurred earlier that
+// day, in the hour a DST change turns the clocks back.
+func repeated(t time.Time) bool {
+	_, off := t.Zone()
+	_, prev := t.Add(-3 * time.Hour).Zone()
+	if prev <= off {
+		return false
+	}
+	e := t.Add(-time.Duration(prev-off) * time.Second)
+	return e.Day() == t.Day() && e.Hour() == t.Hour() && e.Minute() == t.Minute()
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	at := func(loc *time.Location, s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		loc  *time.Location
+		from time.Time
+		want time.Time // zero when nothing matches
+	}{
+		{"step", "*/15 * * * *", time.UTC, at(time.UTC, "2025-01-01 10:07"), at(time.UTC, "2025-01-01 10:15")},
+		{"strictly after", "@daily", time.UTC, at(time.UTC, "2025-01-01 00:00"), at(time.UTC, "2025-01-02 00:00")},
+		{"seconds are dropped", "@hourly", time.UTC, at(time.UTC, "2025-01-01 09:59").Add(59 * time.Second), at(time.UTC, "2025-01-01 10:00")},
+		{"start with step", "5/20 * * * *", time.UTC, at(time.UTC, "2025-01-01 10:05"), at(time.UTC, "2025-01-01 10:25")},
+		{"weekday names over a weekend", "0 9 * * mon-fri", time.UTC, at(time.UTC, "2025-01-03 10:00"), at(time.UTC, "2025-01-06 09:00")},
+		{"sunday as 7", "0 0 * * 7", time.UTC, at(time.UTC, "2025-01-01 00:00"), at(time.UTC, "2025-01-05 00:00")},
+		{"either restricted day field", "0 0 1,15 * fri", time.UTC, at(time.UTC, "2025-01-01 00:00"), at(time.UTC, "2025-01-03 00:00")},
+		{"month names", "0 0 1 mar,sep *", time.UTC, at(time.UTC, "2025-03-01 00:00"), at(time.UTC, "2025-09-01 00:00")},
+		{"leap day", "0 0 29 2 *", time.UTC, at(time.UTC, "2025-01-01 00:00"), at(time.UTC, "2028-02-29 00:00")},
+		{"never", "0 0 30 2 *", time.UTC, at(time.UTC, "2025-01-01 00:00"), time.Time{}},
+		{"evaluated in loc", "0 7 * * *", ny, at(time.UTC, "2025-01-01 13:00"), at(ny, "2025-01-02 07:00")},
+		{"skipped by spring forward", "30 2 * * *", ny, at(ny, "2025-03-08 03:00"), at(ny, "2025-03-10 02:30")},
+		{"after spring forward", "0 3 * * *", ny, at(ny, "2025-03-09 01:00"), at(ny, "2025-03-09 03:00")},
+		{"first of a repeated hour", "30 1 * * *", ny, at(ny, "2025-11-02 00:00"), time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC)},
+		{"repeated hour runs once", "30 1 * * *", ny, time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC), at(ny, "2025-11-03 01:30")},
+		{"hourly through a repeated hour", "0 * * * *", ny, time.Date(2025, 11, 2, 5, 0, 0, 0, time.UTC), time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, err := ParseCron(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.spec, err)
+			}
+			if got := s.Next(tt.from, tt.loc); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronTimezone(t *testing.T) {
+	tests := []struct {
+		spec string
+		tz   string
+	}{
+		{"0 7 * * *", ""},
+		{"CRON_TZ=Asia/Jakarta 0 7 * * *", "Asia/Jakarta"},
+		{"TZ=UTC @weekly", "UTC"},
+	}
+	for _, tt := range tests {
+		if _, tz, err := ParseCron(tt.spec); err != nil || tz != tt.tz {
+			t.Errorf("ParseCron(%q) tz, error = %q, %v, want %q", tt.spec, tz, err, tt.tz)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"* * * *", "expected 5 fields"},
+		{"@reboot", "expected 5 fields"},
+		{"60 * * * *", "out of range"},
+		{"* 24 * * *", "out of range"},
+		{"* * 0 * *", "out of range"},
+		{"* * * 13 *", "out of range"},
+		{"* * * * 8", "out of range"},
+		{"*/0 * * * *", "invalid step"},
+		{"1,,2 * * * *", "empty list item"},
+		{"30-10 * * * *", "runs backwards"},
+		{"* * * foo *", "invalid value"},
+		{"CRON_TZ=Mars/Base 0 7 * * *", "unknown timezone"},
+		{"CRON_TZ=UTC", "missing fields"},
+	}
+	for _, tt := range tests {
+		if _, _, err := ParseCron(tt.spec); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseCron(%q) error = %v, want one containing %q", tt.spec, err, tt.want)
+		}
+	}
+}
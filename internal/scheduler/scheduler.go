@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	jobrepo "github.com/itsaFan/fleetify-be/internal/repo/job"
+)
+
+// Job is a recurring task. Run returns a short summary that is kept in the run
+// history next to status and duration.
+type Job struct {
+	Name        string
+	Spec        string
+	Description string
+	Run         func(ctx context.Context) (string, error)
+}
+
+type entry struct {
+	job   Job
+	sched *Schedule
+	loc   *time.Location
+}
+
+// Scheduler runs registered jobs on their cron schedule. Every replica runs
+// one; the lease in scheduled_jobs makes sure only one of them starts a
+// given run. Pausing and manual triggers are stored there as well, so they
+// take effect on whichever replica picks the job up next.
+type Scheduler struct {
+	repo  jobrepo.Repository
+	cfg   config.SchedulerConfig
+	owner string
+
+	mu      sync.RWMutex
+	entries map[string]*entry
+	wg      sync.WaitGroup
+}
+
+func New(repo jobrepo.Repository, cfg config.SchedulerConfig) *Scheduler {
+	host, _ := os.Hostname()
+	return &Scheduler{
+		repo:    repo,
+		cfg:     cfg,
+		owner:   fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()[:8]),
+		entries: map[string]*entry{},
+	}
+}
+
+// Register adds a job. The schedule runs in the timezone given by a
+// CRON_TZ= prefix, APP_TZ otherwise.
+func (s *Scheduler) Register(j Job) error {
+	if j.Name == "" || j.Run == nil {
+		return errors.New("scheduler: job needs a name and a run func")
+	}
+	sched, tz, err := ParseCron(j.Spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: job %s: %w", j.Name, err)
+	}
+	loc := config.AppTimezone()
+	if tz != "" {
+		loc, _ = time.LoadLocation(tz)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, dup := s.entries[j.Name]; dup {
+		return fmt.Errorf("scheduler: job %s registered twice", j.Name)
+	}
+	s.entries[j.Name] = &entry{job: j, sched: sched, loc: loc}
+	return nil
+}
+
+// Known reports whether name is registered in this process.
+func (s *Scheduler) Known(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.entries[name]
+	return ok
+}
+
+// NextRun is the next scheduled run of name after t.
+func (s *Scheduler) NextRun(name string, t time.Time) (*time.Time, bool) {
+	s.mu.RLock()
+	e, ok := s.entries[name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return e.next(t), true
+}
+
+func (e *entry) next(t time.Time) *time.Time {
+	n := e.sched.Next(t, e.loc)
+	if n.IsZero() {
+		return nil
+	}
+	n = n.UTC()
+	return &n
+}
+
+// Run syncs the registered jobs to the database and polls for due jobs until
+// ctx is cancelled, then waits for runs in flight.
+func (s *Scheduler) Run(ctx context.Context) {
+	if !s.cfg.Enabled {
+		log.Println("scheduler disabled")
+		return
+	}
+	if err := s.sync(ctx); err != nil {
+		log.Printf("scheduler: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.Tick)
+	defer ticker.Stop()
+
+	s.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) sync(ctx context.Context) error {
+	now := time.Now().UTC()
+	for _, e := range s.sorted() {
+		var desc *string
+		if e.job.Description != "" {
+			d := e.job.Description
+			desc = &d
+		}
+		if err := s.repo.Register(ctx, &model.ScheduledJob{
+			Name:        e.job.Name,
+			Schedule:    e.job.Spec,
+			Timezone:    e.loc.String(),
+			Description: desc,
+			NextRunAt:   e.next(now),
+		}); err != nil {
+			return fmt.Errorf("register %s: %w", e.job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) sorted() []*entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].job.Name < out[j].job.Name })
+	return out
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now().UTC()
+	for _, e := range s.sorted() {
+		ok, manual, err := s.repo.AcquireLease(ctx, e.job.Name, s.owner, now, now.Add(s.cfg.Lease))
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("scheduler: lease %s: %v", e.job.Name, err)
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		trigger := jobrepo.TriggerSchedule
+		if manual {
+			trigger = jobrepo.TriggerManual
+		}
+		s.wg.Add(1)
+		go func(e *entry) {
+			defer s.wg.Done()
+			s.execute(ctx, e, trigger)
+		}(e)
+	}
+}
+
+// execute runs one job while holding its lease, renewing the lease until the
+// job returns, and records the outcome. Bookkeeping uses a context that
+// survives shutdown so a cancelled run is still written down.
+func (s *Scheduler) execute(ctx context.Context, e *entry, trigger string) {
+	name := e.job.Name
+	bg := context.WithoutCancel(ctx)
+	started := time.Now().UTC()
+
+	run := &model.JobRun{
+		RunID:     uuid.NewString(),
+		JobName:   name,
+		Trigger:   trigger,
+		Owner:     s.owner,
+		Status:    jobrepo.RunRunning,
+		StartedAt: started,
+	}
+	if err := s.repo.CreateRun(bg, run); err != nil {
+		log.Printf("scheduler: %s: record run: %v", name, err)
+	}
+	// we hold the lease, any other running row belongs to a dead replica
+	if err := s.repo.AbandonRuns(bg, name, run.RunID); err != nil {
+		log.Printf("scheduler: %s: abandon stale runs: %v", name, err)
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		t := time.NewTicker(s.cfg.Lease / 3)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				if err := s.repo.RenewLease(bg, name, s.owner, time.Now().UTC().Add(s.cfg.Lease)); err != nil {
+					log.Printf("scheduler: %s: renew lease: %v", name, err)
+				}
+			}
+		}
+	}()
+
+	output, runErr := safeRun(ctx, e.job.Run)
+	close(stop)
+
+	finished := time.Now().UTC()
+	status := jobrepo.RunSucceeded
+	fp := jobrepo.FinishParams{
+		FinishedAt: finished,
+		DurationMs: finished.Sub(started).Milliseconds(),
+	}
+	if output != "" {
+		fp.Output = &output
+	}
+	if runErr != nil {
+		status = jobrepo.RunFailed
+		msg := runErr.Error()
+		fp.Error = &msg
+		log.Printf("scheduler: %s failed: %v", name, runErr)
+	}
+	fp.Status = status
+
+	if err := s.repo.FinishRun(bg, run.RunID, fp); err != nil {
+		log.Printf("scheduler: %s: record outcome: %v", name, err)
+	}
+	if err := s.repo.ReleaseLease(bg, name, s.owner, jobrepo.ReleaseParams{
+		NextRunAt:  e.next(finished),
+		LastRunAt:  started,
+		LastStatus: status,
+	}); err != nil {
+		log.Printf("scheduler: %s: release lease: %v", name, err)
+	}
+}
+
+func safeRun(ctx context.Context, fn func(ctx context.Context) (string, error)) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
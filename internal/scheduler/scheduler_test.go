@@ -0,0 +1,270 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	jobrepo "github.com/itsaFan/fleetify-be/internal/repo/job"
+)
+
+// fakeJobRepo keeps scheduled_jobs and job_runs in memory, with the lease
+// rules of the SQL repository.
+type fakeJobRepo struct {
+	jobrepo.Repository
+
+	mu   sync.Mutex
+	jobs map[string]*model.ScheduledJob
+	runs map[string]*model.JobRun
+}
+
+func newFakeJobRepo() *fakeJobRepo {
+	return &fakeJobRepo{jobs: map[string]*model.ScheduledJob{}, runs: map[string]*model.JobRun{}}
+}
+
+func (f *fakeJobRepo) Register(ctx context.Context, j *model.ScheduledJob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.jobs[j.Name]; !ok {
+		c := *j
+		f.jobs[j.Name] = &c
+	}
+	return nil
+}
+
+func (f *fakeJobRepo) AcquireLease(ctx context.Context, name, owner string, now, until time.Time) (bool, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	j, ok := f.jobs[name]
+	if !ok {
+		return false, false, errors.New("no such job")
+	}
+	due := j.TriggerRequested || (!j.Paused && j.NextRunAt != nil && !j.NextRunAt.After(now))
+	free := j.LeaseUntil == nil || j.LeaseUntil.Before(now)
+	if !due || !free {
+		return false, false, nil
+	}
+	manual := j.TriggerRequested
+	j.LeaseOwner, j.LeaseUntil, j.TriggerRequested = &owner, &until, false
+	return true, manual, nil
+}
+
+func (f *fakeJobRepo) RenewLease(ctx context.Context, name, owner string, until time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if j := f.jobs[name]; j.LeaseOwner != nil && *j.LeaseOwner == owner {
+		j.LeaseUntil = &until
+	}
+	return nil
+}
+
+func (f *fakeJobRepo) ReleaseLease(ctx context.Context, name, owner string, p jobrepo.ReleaseParams) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	j := f.jobs[name]
+	if j.LeaseOwner == nil || *j.LeaseOwner != owner {
+		return nil
+	}
+	j.LeaseOwner, j.LeaseUntil = nil, nil
+	j.NextRunAt, j.LastRunAt, j.LastStatus = p.NextRunAt, &p.LastRunAt, &p.LastStatus
+	return nil
+}
+
+func (f *fakeJobRepo) CreateRun(ctx context.Context, r *model.JobRun) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := *r
+	f.runs[r.RunID] = &c
+	return nil
+}
+
+func (f *fakeJobRepo) FinishRun(ctx context.Context, runID string, p jobrepo.FinishParams) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r := f.runs[runID]
+	r.Status, r.FinishedAt, r.Output, r.Error = p.Status, &p.FinishedAt, p.Output, p.Error
+	return nil
+}
+
+func (f *fakeJobRepo) AbandonRuns(ctx context.Context, name, keepRunID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id, r := range f.runs {
+		if r.JobName == name && id != keepRunID && r.Status == jobrepo.RunRunning {
+			r.Status = jobrepo.RunAbandoned
+		}
+	}
+	return nil
+}
+
+func (f *fakeJobRepo) job(name string) model.ScheduledJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.jobs[name]
+}
+
+func (f *fakeJobRepo) runsOf(name string) []model.JobRun {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []model.JobRun
+	for _, r := range f.runs {
+		if r.JobName == name {
+			out = append(out, *r)
+		}
+	}
+	return out
+}
+
+// newReplicas registers job on n schedulers sharing repo and makes it due.
+func newReplicas(t *testing.T, repo *fakeJobRepo, lease time.Duration, n int, job Job) []*Scheduler {
+	t.Helper()
+	cfg := config.SchedulerConfig{Enabled: true, Tick: time.Hour, Lease: lease}
+	var out []*Scheduler
+	for range n {
+		s := New(repo, cfg)
+		if err := s.Register(job); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		if err := s.sync(context.Background()); err != nil {
+			t.Fatalf("sync() error = %v", err)
+		}
+		out = append(out, s)
+	}
+	past := time.Now().UTC().Add(-time.Minute)
+	repo.jobs[job.Name].NextRunAt = &past
+	return out
+}
+
+func TestLeaseRunsOnce(t *testing.T) {
+	tests := []struct {
+		name    string
+		manual  bool
+		paused  bool
+		held    bool
+		runs    int
+		trigger string
+	}{
+		{name: "due", runs: 1, trigger: jobrepo.TriggerSchedule},
+		{name: "paused", paused: true},
+		{name: "paused but triggered", paused: true, manual: true, runs: 1, trigger: jobrepo.TriggerManual},
+		{name: "lease held elsewhere", held: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeJobRepo()
+			var calls atomic.Int32
+			job := Job{Name: "job", Spec: "@daily", Run: func(ctx context.Context) (string, error) {
+				calls.Add(1)
+				return "done", nil
+			}}
+			replicas := newReplicas(t, repo, time.Minute, 3, job)
+			j := repo.jobs["job"]
+			j.Paused, j.TriggerRequested = tt.paused, tt.manual
+			if tt.held {
+				other, until := "other", time.Now().UTC().Add(time.Minute)
+				j.LeaseOwner, j.LeaseUntil = &other, &until
+			}
+
+			var wg sync.WaitGroup
+			for _, s := range replicas {
+				wg.Add(1)
+				go func(s *Scheduler) {
+					defer wg.Done()
+					s.tick(context.Background())
+				}(s)
+			}
+			wg.Wait()
+			for _, s := range replicas {
+				s.wg.Wait()
+			}
+
+			if got := int(calls.Load()); got != tt.runs {
+				t.Fatalf("job ran %d times, want %d", got, tt.runs)
+			}
+			if tt.runs == 0 {
+				return
+			}
+			runs := repo.runsOf("job")
+			if len(runs) != 1 || runs[0].Status != jobrepo.RunSucceeded || runs[0].Trigger != tt.trigger {
+				t.Errorf("runs = %+v, want one succeeded %s run", runs, tt.trigger)
+			}
+			got := repo.job("job")
+			if got.LeaseOwner != nil || got.TriggerRequested {
+				t.Errorf("lease owner %v, trigger requested %v after the run", got.LeaseOwner, got.TriggerRequested)
+			}
+			if got.NextRunAt == nil || !got.NextRunAt.After(time.Now()) {
+				t.Errorf("next run at %v, want the next day", got.NextRunAt)
+			}
+		})
+	}
+}
+
+func TestLeaseRenewedWhileRunning(t *testing.T) {
+	repo := newFakeJobRepo()
+	release := make(chan struct{})
+	var calls atomic.Int32
+	job := Job{Name: "slow", Spec: "@daily", Run: func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "", nil
+	}}
+	const lease = 30 * time.Millisecond
+	replicas := newReplicas(t, repo, lease, 2, job)
+
+	replicas[0].tick(context.Background())
+	// outlive the first lease several times over while the other replica polls
+	deadline := time.Now().Add(4 * lease)
+	for time.Now().Before(deadline) {
+		replicas[1].tick(context.Background())
+		time.Sleep(lease / 6)
+	}
+	close(release)
+	replicas[0].wg.Wait()
+	replicas[1].wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("job ran %d times, want 1", got)
+	}
+}
+
+func TestExecuteRecordsFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(ctx context.Context) (string, error)
+		wantErr string
+	}{
+		{"error", func(ctx context.Context) (string, error) { return "partial", errors.New("boom") }, "boom"},
+		{"panic", func(ctx context.Context) (string, error) { panic("oops") }, "panic: oops"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeJobRepo()
+			stale := &model.JobRun{RunID: "stale", JobName: "job", Status: jobrepo.RunRunning}
+			repo.runs[stale.RunID] = stale
+
+			s := newReplicas(t, repo, time.Minute, 1, Job{Name: "job", Spec: "@daily", Run: tt.run})[0]
+			s.tick(context.Background())
+			s.wg.Wait()
+
+			if stale.Status != jobrepo.RunAbandoned {
+				t.Errorf("stale run status = %s, want %s", stale.Status, jobrepo.RunAbandoned)
+			}
+			for _, r := range repo.runsOf("job") {
+				if r.RunID == stale.RunID {
+					continue
+				}
+				if r.Status != jobrepo.RunFailed || r.Error == nil || *r.Error != tt.wantErr {
+					t.Errorf("run = %+v, want failed with %q", r, tt.wantErr)
+				}
+			}
+			if got := repo.job("job"); got.LastStatus == nil || *got.LastStatus != jobrepo.RunFailed || got.LeaseOwner != nil {
+				t.Errorf("job = %+v, want released with last status failed", got)
+			}
+		})
+	}
+}
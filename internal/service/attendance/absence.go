@@ -0,0 +1,116 @@
+package attendance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+type absenceInput struct {
+	EmployeeID     string
+	EmployeeName   string
+	DepartmentName *string
+	WorkingDays    string
+	MaxClockOut    string
+	// calendar dates, only the date counts
+	HireDate time.Time
+	LastDay  *time.Time
+	Holidays map[string]string
+	Leaves   map[string]string
+}
+
+// fillAbsentDays appends an "absent" item for every scheduled working day in
+// [from, to] (local dates) that has no item yet, or "on_leave" when the day is
+// covered by approved leave. Holidays, days outside employment and days that
+// are not over yet are never reported as absent.
+func fillAbsentDays(
+	items []AttendanceHistoryItem,
+	present map[string]bool,
+	in absenceInput,
+	from, to time.Time,
+	loc *time.Location,
+) ([]AttendanceHistoryItem, error) {
+	workDays, err := helper.ParseWorkingDays(in.WorkingDays)
+	if err != nil {
+		return nil, fmt.Errorf("department working days for employee %q: %w", in.EmployeeID, err)
+	}
+
+	start := from
+	if !in.HireDate.IsZero() {
+		hired := localDate(loc, in.HireDate.Year(), in.HireDate.Month(), in.HireDate.Day())
+		if hired.After(start) {
+			start = hired
+		}
+	}
+
+	now := time.Now().In(loc)
+	last := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if h, m, sec, err := helper.ParseCutoffHHMMSS(in.MaxClockOut); err == nil {
+		if now.Before(time.Date(now.Year(), now.Month(), now.Day(), h, m, sec, 0, loc)) {
+			last = last.AddDate(0, 0, -1)
+		}
+	}
+	if in.LastDay != nil {
+		if l := localDate(loc, in.LastDay.Year(), in.LastDay.Month(), in.LastDay.Day()); l.Before(last) {
+			last = l
+		}
+	}
+	end := to
+	if last.Before(end) {
+		end = last
+	}
+
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if !workDays[d.Weekday()] {
+			continue
+		}
+		key := helper.DateKey(d)
+		if present[key] {
+			continue
+		}
+		if _, ok := in.Holidays[key]; ok {
+			continue
+		}
+		item := AttendanceHistoryItem{
+			EmployeeID:     in.EmployeeID,
+			EmployeeName:   in.EmployeeName,
+			DepartmentName: in.DepartmentName,
+			DateLocal:      key,
+			StatusIn:       "absent",
+			StatusOut:      "absent",
+		}
+		if lt, ok := in.Leaves[key]; ok {
+			item.StatusIn = "on_leave"
+			item.StatusOut = "on_leave"
+			item.LeaveType = &lt
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// lastDay is the last calendar day the employee is expected at work: the
+// termination date, or the day before archiving.
+func lastDay(emp *model.Employee) *time.Time {
+	var out *time.Time
+	if emp.TerminationDate != nil {
+		d := *emp.TerminationDate
+		out = &d
+	}
+	if emp.DeletedAt.Valid {
+		a := emp.DeletedAt.Time.In(config.AppTimezone())
+		d := time.Date(a.Year(), a.Month(), a.Day()-1, 0, 0, 0, 0, time.UTC)
+		if out == nil || d.Before(*out) {
+			out = &d
+		}
+	}
+	return out
+}
+
+// localDate returns local midnight of the given calendar date.
+func localDate(loc *time.Location, y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,104 @@
+package attendance
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+// stint is the department an employee belonged to over the local dates
+// [from, to] (YYYY-MM-DD). The first stint is open towards the past and the
+// current one towards the future, so punches before the hire date still get
+// a department.
+type stint struct {
+	from, to string
+	dept     model.Department
+}
+
+// loadStints returns the department stints of emps by employee_id in one
+// query. Employees without any keep their current department throughout.
+func (s *service) loadStints(ctx context.Context, emps ...*model.Employee) (map[string][]stint, error) {
+	ids := make([]string, 0, len(emps))
+	for _, e := range emps {
+		ids = append(ids, e.EmployeeID)
+	}
+	rows, err := s.empRepo.ListAssignments(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]stint, len(emps))
+	for _, a := range rows {
+		st := stint{from: helper.DateKey(a.EffectiveFrom), dept: a.Department}
+		if a.EffectiveTo != nil {
+			st.to = helper.DateKey(*a.EffectiveTo)
+		}
+		out[a.EmployeeID] = append(out[a.EmployeeID], st)
+	}
+	for _, e := range emps {
+		sts := out[e.EmployeeID]
+		if len(sts) == 0 {
+			out[e.EmployeeID] = []stint{{dept: e.Department}}
+			continue
+		}
+		sts[0].from = ""
+		sts[len(sts)-1].to = ""
+	}
+	return out, nil
+}
+
+// stintOn is the index of the stint covering the local date key.
+func stintOn(sts []stint, key string) int {
+	for i := len(sts) - 1; i > 0; i-- {
+		if key >= sts[i].from {
+			return i
+		}
+	}
+	return 0
+}
+
+// computeDays applies the day rules to raw history rows, each day with the
+// cutoffs and holidays of the department the employee was in.
+func computeDays(rows []model.AttendanceHistory, emp *model.Employee, sts []stint, loc *time.Location, cal holidayCalendar) []AttendanceHistoryItem {
+	parts := make([][]model.AttendanceHistory, len(sts))
+	for _, r := range rows {
+		i := stintOn(sts, helper.DateKey(r.DateAttendance.In(loc)))
+		parts[i] = append(parts[i], r)
+	}
+
+	var items []AttendanceHistoryItem
+	for i, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		d := sts[i].dept
+		its := groupAndCompute(part, loc, d.MaxClockInTime, d.MaxClockOutTime, emp.Name)
+		applyHolidays(its, cal.forDepartment(d.ID))
+		items = append(items, its...)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].DateLocal < items[j].DateLocal })
+	return items
+}
+
+// clip narrows the local range [from, to] to the stint, ok is false when
+// they do not overlap.
+func (st stint) clip(loc *time.Location, from, to time.Time) (time.Time, time.Time, bool) {
+	if st.from != "" {
+		if y, m, d, err := helper.ParseYYYYMMDD(st.from); err == nil {
+			if f := localDate(loc, y, m, d); f.After(from) {
+				from = f
+			}
+		}
+	}
+	if st.to != "" {
+		if y, m, d, err := helper.ParseYYYYMMDD(st.to); err == nil {
+			if t := localDate(loc, y, m, d); t.Before(to) {
+				to = t
+			}
+		}
+	}
+	return from, to, !to.Before(from)
+}
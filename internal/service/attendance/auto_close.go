@@ -0,0 +1,152 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+)
+
+const autoCloseBatch = 500
+
+// AutoCloseOpenAttendances closes attendances left open for longer than
+// in.OlderThan. The clock-out is set to the department's max clock-out time on
+// the clock-in day (in in.Loc), a history row with source auto_closed is
+// written, and the attendance is flagged for review.
+func (s *service) AutoCloseOpenAttendances(ctx context.Context, in AutoCloseInput) (*AutoCloseOutput, error) {
+	if in.OlderThan <= 0 {
+		return nil, fmt.Errorf("%w: older_than must be positive", appErr.ErrInvalidRange)
+	}
+	loc := in.Loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := time.Now().UTC()
+	cutoff := now.Add(-in.OlderThan)
+
+	out := &AutoCloseOutput{Items: []AutoClosedItem{}}
+
+	// batches continue after the last row seen, failed attendances stay open
+	// and cannot hold back the ones behind them
+	var last *model.Attendance
+	for {
+		open, err := s.atdRepo.ListOpenClockedInBefore(ctx, cutoff, last, autoCloseBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, att := range open {
+			item, err := s.autoCloseOne(ctx, att, loc, now, in.OlderThan)
+			if err != nil {
+				out.Errors = append(out.Errors, fmt.Sprintf("%s: %v", att.AttendanceID, err))
+				continue
+			}
+			if item == nil {
+				out.Skipped++
+				continue
+			}
+			out.Items = append(out.Items, *item)
+		}
+
+		if len(open) < autoCloseBatch {
+			break
+		}
+		last = &open[len(open)-1]
+	}
+
+	out.Closed = len(out.Items)
+	return out, nil
+}
+
+func (s *service) autoCloseOne(ctx context.Context, att model.Attendance, loc *time.Location, now time.Time, threshold time.Duration) (*AutoClosedItem, error) {
+	if att.ClockIn == nil {
+		return nil, fmt.Errorf("attendance has no clock_in")
+	}
+	closeAt := autoCloseTime(*att.ClockIn, att.Employee.Department.MaxClockOutTime, loc, now)
+
+	var item *AutoClosedItem
+	closed := newEvent(event.AttendanceClockOut, &att.Employee, att.AttendanceID, closeAt, model.HistorySourceAuto)
+	err := s.atdRepo.WithTx(ctx, func(tx atdrepo.Repository) error {
+		cur, err := tx.GetAttendanceForUpdate(ctx, att.AttendanceID)
+		if err != nil {
+			return err
+		}
+		// clocked out while we were working through the batch
+		if cur.ClockOut != nil {
+			return nil
+		}
+
+		if err := tx.AutoCloseAttendance(ctx, cur.AttendanceID, closeAt); err != nil {
+			return err
+		}
+
+		if err := tx.CreateAttendanceHistory(ctx, &model.AttendanceHistory{
+			EmployeeID:     cur.EmployeeID,
+			AttendanceID:   cur.AttendanceID,
+			DateAttendance: closeAt,
+			AttendanceType: 2,
+			Source:         model.HistorySourceAuto,
+			Description: fmt.Sprintf("Auto-closed: no clock out after %s, closed at department max clock-out time, needs review",
+				threshold),
+		}); err != nil {
+			return err
+		}
+		if err := s.RefreshDaily(ctx, tx, &att.Employee, closeAt); err != nil {
+			return err
+		}
+
+		if err := tx.Outbox().Add(ctx, closed.Type, closed); err != nil {
+			return err
+		}
+
+		item = &AutoClosedItem{
+			AttendanceID: cur.AttendanceID,
+			EmployeeID:   cur.EmployeeID,
+			ClockInUTC:   *cur.ClockIn,
+			ClockOutUTC:  closeAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if item != nil {
+		s.bus.Publish(closed)
+	}
+	return item, nil
+}
+
+// autoCloseTime is maxOut on the local clock-in day. Clock-ins after the
+// cutoff are closed at the clock-in itself, a zero length session that the
+// review will fix, never in the future.
+func autoCloseTime(clockIn time.Time, maxOut string, loc *time.Location, now time.Time) time.Time {
+	local := clockIn.In(loc)
+	closeAt := clockIn
+	if h, m, sec, err := helper.ParseCutoffHHMMSS(maxOut); err == nil {
+		closeAt = time.Date(local.Year(), local.Month(), local.Day(), h, m, sec, 0, loc).UTC()
+	}
+	if closeAt.Before(clockIn) {
+		closeAt = clockIn
+	}
+	if closeAt.After(now) {
+		closeAt = now
+	}
+	return closeAt.UTC()
+}
+
+func (s *service) ListNeedsReview(ctx context.Context, in ListReviewInput) (*ListReviewOutput, error) {
+	items, total, err := s.atdRepo.ListNeedsReview(ctx, atdrepo.ListParamsReview{
+		DepartmentID: in.DepartmentID,
+		Limit:        in.Limit,
+		Page:         in.Page,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ListReviewOutput{Items: items, Total: total}, nil
+}
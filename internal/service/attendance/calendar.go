@@ -0,0 +1,101 @@
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+)
+
+// holidayCalendar indexes holidays by local date (YYYY-MM-DD).
+type holidayCalendar struct {
+	global map[string]string
+	byDept map[uint64]map[string]string
+}
+
+func (s *service) loadHolidays(ctx context.Context, from, to time.Time) (holidayCalendar, error) {
+	cal := holidayCalendar{
+		global: map[string]string{},
+		byDept: map[uint64]map[string]string{},
+	}
+
+	rows, err := s.holidayRepo.ListInRange(ctx, from, to)
+	if err != nil {
+		return cal, err
+	}
+
+	for _, h := range rows {
+		key := helper.DateKey(h.HolidayDate)
+		if h.DepartmentID == nil {
+			cal.global[key] = h.Name
+			continue
+		}
+		if cal.byDept[*h.DepartmentID] == nil {
+			cal.byDept[*h.DepartmentID] = map[string]string{}
+		}
+		cal.byDept[*h.DepartmentID][key] = h.Name
+	}
+	return cal, nil
+}
+
+// forDepartment merges company wide holidays with the department's closures.
+func (c holidayCalendar) forDepartment(departmentID uint64) map[string]string {
+	dept := c.byDept[departmentID]
+	if len(dept) == 0 {
+		return c.global
+	}
+	out := make(map[string]string, len(c.global)+len(dept))
+	for k, v := range c.global {
+		out[k] = v
+	}
+	for k, v := range dept {
+		out[k] = v
+	}
+	return out
+}
+
+// loadLeaves returns approved leave days per employee as date -> leave type name.
+// A nil employeeID loads every employee.
+func (s *service) loadLeaves(ctx context.Context, from, to time.Time, employeeID *string) (map[string]map[string]string, error) {
+	rows, err := s.leaveRepo.ListApprovedInRange(ctx, from, to, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]map[string]string{}
+	for _, lr := range rows {
+		if out[lr.EmployeeID] == nil {
+			out[lr.EmployeeID] = map[string]string{}
+		}
+		// leave dates are calendar dates, walk them without a zone shift
+		for d := lr.StartDate; !d.After(lr.EndDate); d = d.AddDate(0, 0, 1) {
+			out[lr.EmployeeID][helper.DateKey(d)] = lr.LeaveType.Name
+		}
+	}
+	return out, nil
+}
+
+// applyHolidays flags punches made on a holiday as holiday_work. There is no
+// deadline on a holiday, so lateness deltas are dropped.
+func applyHolidays(items []AttendanceHistoryItem, holidays map[string]string) {
+	for i := range items {
+		name, ok := holidays[items[i].DateLocal]
+		if !ok {
+			continue
+		}
+		n := name
+		items[i].Holiday = &n
+		markHolidayWork(&items[i])
+	}
+}
+
+func markHolidayWork(it *AttendanceHistoryItem) {
+	if it.ClockInUTC != nil {
+		it.StatusIn = "holiday_work"
+		it.DeltaInMinutes = nil
+	}
+	if it.ClockOutUTC != nil {
+		it.StatusOut = "holiday_work"
+		it.DeltaOutMinutes = nil
+	}
+}
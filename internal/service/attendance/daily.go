@@ -0,0 +1,259 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+)
+
+// dailyRuleVersion is stored on every attendance_daily row. Bump it when the
+// day rules (picks, statuses, worked minutes) change and rebuild.
+const dailyRuleVersion = 1
+
+// dailyZone reports whether days in loc can be read from attendance_daily,
+// which is bucketed in APP_TZ.
+func dailyZone(loc *time.Location) bool {
+	return loc.String() == config.AppTimezone().String()
+}
+
+// punchedDays returns the employee's days with punches in [from, to] (local
+// dates), from attendance_daily in APP_TZ and from the raw history otherwise.
+func (s *service) punchedDays(ctx context.Context, emp *model.Employee, sts []stint, loc *time.Location, from, to time.Time, cal holidayCalendar) ([]AttendanceHistoryItem, error) {
+	if dailyZone(loc) {
+		rows, err := s.atdRepo.ListDailyByEmpId(ctx, emp.EmployeeID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]AttendanceHistoryItem, 0, len(rows))
+		for i := range rows {
+			items = append(items, fromDaily(&rows[i], emp, loc))
+		}
+		return items, nil
+	}
+
+	fromUTC, _ := helper.DayBoundsLocalToUTC(loc, from.Year(), from.Month(), from.Day())
+	_, toUTC := helper.DayBoundsLocalToUTC(loc, to.Year(), to.Month(), to.Day())
+	rows, err := s.atdRepo.ListHistoryByEmpId(ctx, atdrepo.ListParamsEmp{
+		EmployeeID: emp.EmployeeID,
+		FromUtc:    fromUTC,
+		ToUtc:      toUTC,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return computeDays(rows, emp, sts, loc, cal), nil
+}
+
+func toDaily(it AttendanceHistoryItem, breakMinutes int) (model.AttendanceDaily, error) {
+	y, m, d, err := helper.ParseYYYYMMDD(it.DateLocal)
+	if err != nil {
+		return model.AttendanceDaily{}, err
+	}
+	var attID *string
+	if it.AttendanceID != "" {
+		id := it.AttendanceID
+		attID = &id
+	}
+	return model.AttendanceDaily{
+		EmployeeID: it.EmployeeID,
+		// a DATE column, keep the calendar date through the UTC connection
+		DateLocal:       time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+		AttendanceID:    attID,
+		FirstIn:         it.ClockInUTC,
+		LastOut:         it.ClockOutUTC,
+		StatusIn:        it.StatusIn,
+		StatusOut:       it.StatusOut,
+		DeltaInMinutes:  it.DeltaInMinutes,
+		DeltaOutMinutes: it.DeltaOutMinutes,
+		WorkedMinutes:   summarizeDay(TimesheetDay{}, it, breakMinutes).WorkedMinutes,
+		Holiday:         it.Holiday,
+		Corrected:       it.Corrected,
+		NeedsReview:     it.NeedsReview,
+		RuleVersion:     dailyRuleVersion,
+	}, nil
+}
+
+func fromDaily(d *model.AttendanceDaily, emp *model.Employee, loc *time.Location) AttendanceHistoryItem {
+	it := AttendanceHistoryItem{
+		EmployeeID:      d.EmployeeID,
+		EmployeeName:    emp.Name,
+		DateLocal:       helper.DateKey(d.DateLocal),
+		StatusIn:        d.StatusIn,
+		DeltaInMinutes:  d.DeltaInMinutes,
+		StatusOut:       d.StatusOut,
+		DeltaOutMinutes: d.DeltaOutMinutes,
+		Holiday:         d.Holiday,
+		Corrected:       d.Corrected,
+		NeedsReview:     d.NeedsReview,
+	}
+	if d.AttendanceID != nil {
+		it.AttendanceID = *d.AttendanceID
+	}
+	setClockTimes(&it, d.FirstIn, d.LastOut, loc)
+	return it
+}
+
+// RefreshDaily recomputes the APP_TZ days of the given instants for emp
+// through tx, so the rows commit together with the punches that changed them.
+// emp needs its department loaded.
+func (s *service) RefreshDaily(ctx context.Context, tx atdrepo.Repository, emp *model.Employee, at ...time.Time) error {
+	loc := config.AppTimezone()
+	done := map[string]bool{}
+	stints, err := s.loadStints(ctx, emp)
+	if err != nil {
+		return err
+	}
+	sts := stints[emp.EmployeeID]
+
+	for _, t := range at {
+		local := t.In(loc)
+		day := localDate(loc, local.Year(), local.Month(), local.Day())
+		key := helper.DateKey(day)
+		if done[key] {
+			continue
+		}
+		done[key] = true
+
+		fromUTC, toUTC := helper.DayBoundsLocalToUTC(loc, day.Year(), day.Month(), day.Day())
+		rows, err := tx.ListHistoryByEmpId(ctx, atdrepo.ListParamsEmp{
+			EmployeeID: emp.EmployeeID,
+			FromUtc:    fromUTC,
+			ToUtc:      toUTC,
+		})
+		if err != nil {
+			return err
+		}
+		cal, err := s.loadHolidays(ctx, day, day)
+		if err != nil {
+			return err
+		}
+
+		items := computeDays(rows, emp, sts, loc, cal)
+		if len(items) == 0 {
+			if err := tx.DeleteDailyInRange(ctx, emp.EmployeeID, day, day); err != nil {
+				return err
+			}
+			continue
+		}
+		row, err := toDaily(items[0], sts[stintOn(sts, key)].dept.BreakMinutes)
+		if err != nil {
+			return err
+		}
+		if err := tx.UpsertDaily(ctx, &row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RebuildDaily recomputes attendance_daily for every employee in scope over
+// [FromLocal, ToLocal] (APP_TZ dates), one transaction per employee. Needed
+// after a rule version bump, department cutoff, holiday or assignment
+// changes; the department, holiday and employee services call it on those.
+func (s *service) RebuildDaily(ctx context.Context, in RebuildDailyInput) (*RebuildDailyOutput, error) {
+	loc := config.AppTimezone()
+	if in.AllDays {
+		first, err := s.atdRepo.FirstDailyDate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		today := helper.DateKey(time.Now().In(loc))
+		if first == nil {
+			return &RebuildDailyOutput{From: today, To: today, TZUsed: loc.String()}, nil
+		}
+		in.FromLocal, in.ToLocal = helper.DateKey(*first), today
+	}
+	if in.FromLocal == "" || in.ToLocal == "" {
+		return nil, fmt.Errorf("%w: from/to are required (YYYY-MM-DD)", appErr.ErrRequiredField)
+	}
+	y1, m1, d1, err := helper.ParseYYYYMMDD(in.FromLocal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'from' date", appErr.ErrInvalidInput)
+	}
+	y2, m2, d2, err := helper.ParseYYYYMMDD(in.ToLocal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'to' date", appErr.ErrInvalidInput)
+	}
+	from, to := localDate(loc, y1, m1, d1), localDate(loc, y2, m2, d2)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", appErr.ErrInvalidTimeRange)
+	}
+
+	var emps []model.Employee
+	if in.EmployeeID != "" {
+		emp, err := s.empRepo.GetByEmployeeIDWithArchived(ctx, in.EmployeeID)
+		if err != nil {
+			return nil, err
+		}
+		emps = []model.Employee{*emp}
+	} else if emps, err = s.empRepo.ListAssignedJoinDept(ctx, in.DepartmentID, from, to); err != nil {
+		return nil, err
+	}
+	return s.rebuildDaily(ctx, emps, from, to)
+}
+
+func (s *service) rebuildDaily(ctx context.Context, emps []model.Employee, from, to time.Time) (*RebuildDailyOutput, error) {
+	loc := config.AppTimezone()
+	cal, err := s.loadHolidays(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	ptrs := make([]*model.Employee, len(emps))
+	for i := range emps {
+		ptrs[i] = &emps[i]
+	}
+	stints, err := s.loadStints(ctx, ptrs...)
+	if err != nil {
+		return nil, err
+	}
+
+	fromUTC, _ := helper.DayBoundsLocalToUTC(loc, from.Year(), from.Month(), from.Day())
+	_, toUTC := helper.DayBoundsLocalToUTC(loc, to.Year(), to.Month(), to.Day())
+
+	out := &RebuildDailyOutput{From: helper.DateKey(from), To: helper.DateKey(to), TZUsed: loc.String()}
+	for i := range emps {
+		emp := &emps[i]
+		err := s.atdRepo.WithTx(ctx, func(tx atdrepo.Repository) error {
+			// delete first: the range stays locked, so a punch racing the
+			// rebuild waits and then writes its own day on top
+			if err := tx.DeleteDailyInRange(ctx, emp.EmployeeID, from, to); err != nil {
+				return err
+			}
+			rows, err := tx.ListHistoryByEmpId(ctx, atdrepo.ListParamsEmp{
+				EmployeeID: emp.EmployeeID,
+				FromUtc:    fromUTC,
+				ToUtc:      toUTC,
+			})
+			if err != nil {
+				return err
+			}
+
+			sts := stints[emp.EmployeeID]
+			items := computeDays(rows, emp, sts, loc, cal)
+			days := make([]model.AttendanceDaily, 0, len(items))
+			for _, it := range items {
+				d, err := toDaily(it, sts[stintOn(sts, it.DateLocal)].dept.BreakMinutes)
+				if err != nil {
+					return err
+				}
+				days = append(days, d)
+			}
+			if err := tx.CreateDaily(ctx, days); err != nil {
+				return err
+			}
+			out.Days += len(days)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("employee %q: %w", emp.EmployeeID, err)
+		}
+		out.Employees++
+	}
+	return out, nil
+}
@@ -0,0 +1,226 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+)
+
+// fakeAtdRepo keeps attendance_histories and attendance_daily in memory.
+type fakeAtdRepo struct {
+	atdrepo.Repository
+	history []model.AttendanceHistory
+	daily   map[string]model.AttendanceDaily
+}
+
+func dailyKey(employeeID string, day time.Time) string {
+	return employeeID + "|" + helper.DateKey(day)
+}
+
+func (f *fakeAtdRepo) WithTx(ctx context.Context, fn func(txRepo atdrepo.Repository) error) error {
+	return fn(f)
+}
+
+func (f *fakeAtdRepo) ListHistoryByEmpId(ctx context.Context, p atdrepo.ListParamsEmp) ([]model.AttendanceHistory, error) {
+	var out []model.AttendanceHistory
+	for _, h := range f.history {
+		if h.EmployeeID == p.EmployeeID && !h.DateAttendance.Before(p.FromUtc) && !h.DateAttendance.After(p.ToUtc) {
+			out = append(out, h)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].DateAttendance.Before(out[j].DateAttendance) })
+	return out, nil
+}
+
+func (f *fakeAtdRepo) UpsertDaily(ctx context.Context, d *model.AttendanceDaily) error {
+	f.daily[dailyKey(d.EmployeeID, d.DateLocal)] = *d
+	return nil
+}
+
+func (f *fakeAtdRepo) CreateDaily(ctx context.Context, items []model.AttendanceDaily) error {
+	for _, d := range items {
+		k := dailyKey(d.EmployeeID, d.DateLocal)
+		if _, dup := f.daily[k]; dup {
+			return fmt.Errorf("duplicate day %s", k)
+		}
+		f.daily[k] = d
+	}
+	return nil
+}
+
+func (f *fakeAtdRepo) DeleteDailyInRange(ctx context.Context, employeeID string, fromDate, toDate time.Time) error {
+	from, to := helper.DateKey(fromDate), helper.DateKey(toDate)
+	for k, d := range f.daily {
+		if key := helper.DateKey(d.DateLocal); d.EmployeeID == employeeID && key >= from && key <= to {
+			delete(f.daily, k)
+		}
+	}
+	return nil
+}
+
+type fakeEmpRepo struct {
+	emprepo.Repository
+	emp    model.Employee
+	stints []model.EmployeeDepartmentAssignment
+}
+
+func (f *fakeEmpRepo) GetByEmployeeIDWithArchived(ctx context.Context, employeeID string) (*model.Employee, error) {
+	e := f.emp
+	return &e, nil
+}
+
+func (f *fakeEmpRepo) ListAssignments(ctx context.Context, employeeIDs ...string) ([]model.EmployeeDepartmentAssignment, error) {
+	return f.stints, nil
+}
+
+type fakeHolidayRepo struct {
+	holidayrepo.Repository
+	items []model.Holiday
+}
+
+func (f *fakeHolidayRepo) ListInRange(ctx context.Context, from, to time.Time) ([]model.Holiday, error) {
+	var out []model.Holiday
+	for _, h := range f.items {
+		if key := helper.DateKey(h.HolidayDate); key >= helper.DateKey(from) && key <= helper.DateKey(to) {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+// TestRefreshMatchesRebuild replays punches one by one through RefreshDaily,
+// the way clock in/out and corrections do, and checks that RebuildDaily over
+// the same history writes the same rows.
+func TestRefreshMatchesRebuild(t *testing.T) {
+	t.Cleanup(func() { _ = config.LoadAppTimezone() })
+	t.Setenv("APP_TZ", "Asia/Jakarta")
+	if err := config.LoadAppTimezone(); err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	loc := config.AppTimezone()
+
+	office := model.Department{ID: 1, MaxClockInTime: "08:00:00", MaxClockOutTime: "17:00:00", WorkingDays: "1,2,3,4,5", BreakMinutes: 60}
+	night := model.Department{ID: 2, MaxClockInTime: "22:00:00", MaxClockOutTime: "06:00:00", WorkingDays: "1,2,3,4,5,6", BreakMinutes: 30}
+	officeEnd := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	officeID := office.ID
+
+	emps := &fakeEmpRepo{
+		emp: model.Employee{EmployeeID: "E1", Name: "Ayu", DepartmentID: night.ID, Department: night},
+		stints: []model.EmployeeDepartmentAssignment{
+			{EmployeeID: "E1", DepartmentID: office.ID, EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EffectiveTo: &officeEnd, Department: office},
+			{EmployeeID: "E1", DepartmentID: night.ID, EffectiveFrom: time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC), Department: night},
+		},
+	}
+	holidays := &fakeHolidayRepo{items: []model.Holiday{
+		{HolidayDate: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Name: "Office closed", DepartmentID: &officeID},
+		{HolidayDate: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), Name: "Public holiday"},
+	}}
+	repo := &fakeAtdRepo{daily: map[string]model.AttendanceDaily{}}
+	s := &service{atdRepo: repo, empRepo: emps, holidayRepo: holidays}
+
+	at := func(day, clock string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", day+" "+clock, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.UTC()
+	}
+	punch := func(id uint, att string, typ uint8, when time.Time, source string) model.AttendanceHistory {
+		return model.AttendanceHistory{ID: id, EmployeeID: "E1", AttendanceID: att, AttendanceType: typ, DateAttendance: when, Source: source}
+	}
+	const term, manual, auto = model.HistorySourceTerminal, model.HistorySourceManual, model.HistorySourceAuto
+
+	steps := []struct {
+		name   string
+		add    []model.AttendanceHistory
+		remove []uint
+	}{
+		{name: "early in, local day starts the UTC day before", add: []model.AttendanceHistory{punch(1, "a1", 1, at("2025-03-03", "06:50"), term)}},
+		{name: "early leave", add: []model.AttendanceHistory{punch(2, "a1", 2, at("2025-03-03", "16:10"), term)}},
+		{name: "work on a department closure", add: []model.AttendanceHistory{
+			punch(3, "a2", 1, at("2025-03-04", "08:30"), term),
+			punch(4, "a2", 2, at("2025-03-04", "18:00"), term),
+		}},
+		{name: "open day", add: []model.AttendanceHistory{punch(5, "a3", 1, at("2025-03-05", "07:59"), term)}},
+		{name: "night shift in the new department", add: []model.AttendanceHistory{
+			punch(6, "a4", 1, at("2025-03-06", "22:15"), term),
+			punch(7, "a4", 2, at("2025-03-06", "23:50"), term),
+		}},
+		{name: "corrected in wins over a terminal one", add: []model.AttendanceHistory{punch(8, "a4", 1, at("2025-03-06", "21:55"), manual)}},
+		{name: "public holiday", add: []model.AttendanceHistory{
+			punch(9, "a5", 1, at("2025-03-08", "21:00"), term),
+			punch(10, "a5", 2, at("2025-03-08", "23:00"), term),
+		}},
+		{name: "auto-closed day", add: []model.AttendanceHistory{
+			punch(11, "a6", 1, at("2025-03-10", "22:00"), term),
+			punch(12, "a6", 2, at("2025-03-10", "23:59"), auto),
+		}},
+		{name: "punch written and taken back", add: []model.AttendanceHistory{punch(13, "a7", 1, at("2025-03-11", "22:00"), term)}},
+		{name: "taken back", remove: []uint{13}},
+	}
+	for _, st := range steps {
+		var touched []time.Time
+		for _, h := range st.add {
+			repo.history = append(repo.history, h)
+			touched = append(touched, h.DateAttendance)
+		}
+		for _, id := range st.remove {
+			for i, h := range repo.history {
+				if h.ID == id {
+					touched = append(touched, h.DateAttendance)
+					repo.history = append(repo.history[:i], repo.history[i+1:]...)
+					break
+				}
+			}
+		}
+		emp := emps.emp
+		if err := s.RefreshDaily(context.Background(), repo, &emp, touched...); err != nil {
+			t.Fatalf("%s: RefreshDaily() error = %v", st.name, err)
+		}
+	}
+
+	refreshed := repo.daily
+	if len(refreshed) != 6 {
+		t.Errorf("refreshed %d days, want 6", len(refreshed))
+	}
+	if d := refreshed["E1|2025-03-03"]; d.StatusIn != "early" || d.StatusOut != "early_leave" || d.WorkedMinutes != 500 {
+		t.Errorf("2025-03-03 = %+v, want early in, early leave and 500 worked minutes", d)
+	}
+	if d := refreshed["E1|2025-03-06"]; !d.Corrected || d.StatusIn != "early" || d.WorkedMinutes != 115 {
+		t.Errorf("2025-03-06 = %+v, want the corrected early in and no break on a short shift", d)
+	}
+
+	repo.daily = map[string]model.AttendanceDaily{}
+	out, err := s.RebuildDaily(context.Background(), RebuildDailyInput{FromLocal: "2025-03-01", ToLocal: "2025-03-12", EmployeeID: "E1"})
+	if err != nil {
+		t.Fatalf("RebuildDaily() error = %v", err)
+	}
+	if out.Days != len(refreshed) {
+		t.Errorf("RebuildDaily() days = %d, want %d", out.Days, len(refreshed))
+	}
+	for k, want := range refreshed {
+		got, ok := repo.daily[k]
+		if !ok {
+			t.Errorf("%s: missing after rebuild", k)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: rebuilt %+v, refreshed %+v", k, got, want)
+		}
+	}
+	for k := range repo.daily {
+		if _, ok := refreshed[k]; !ok {
+			t.Errorf("%s: only written by the rebuild", k)
+		}
+	}
+}
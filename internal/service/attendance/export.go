@@ -0,0 +1,175 @@
+package attendance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+)
+
+// exports walk day by day per employee, a year keeps a single request bounded
+const maxExportDays = 366
+
+// HistoryExport is a validated export range. Nothing is read until Each,
+// so callers can still answer with an error before writing a response.
+type HistoryExport struct {
+	FromLocal string
+	ToLocal   string
+	TZUsed    string
+
+	svc          *service
+	employees    func(ctx context.Context) ([]model.Employee, error)
+	departmentID *uint64
+	loc          *time.Location
+	from, to     time.Time
+}
+
+// Each emits every item of the range ordered by employee then date, named
+// after the department of the day. Only one employee's days are held in
+// memory at a time.
+func (e *HistoryExport) Each(ctx context.Context, emit func(AttendanceHistoryItem) error) error {
+	return e.eachEmployee(ctx, func(emp *model.Employee, sts []stint, items []AttendanceHistoryItem) error {
+		for _, it := range items {
+			deptName := sts[stintOn(sts, it.DateLocal)].dept.DepartmentName
+			it.DepartmentName = &deptName
+			if err := emit(it); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// eachEmployee hands every employee's days of the range to fn, holidays,
+// leaves and department stints are loaded once for the whole run. A
+// department export only hands over the days spent in that department.
+func (e *HistoryExport) eachEmployee(ctx context.Context, fn func(emp *model.Employee, sts []stint, items []AttendanceHistoryItem) error) error {
+	emps, err := e.employees(ctx)
+	if err != nil {
+		return err
+	}
+
+	cal, err := e.svc.loadHolidays(ctx, e.from, e.to)
+	if err != nil {
+		return err
+	}
+	var onlyEmp *string
+	if len(emps) == 1 {
+		onlyEmp = &emps[0].EmployeeID
+	}
+	leaves, err := e.svc.loadLeaves(ctx, e.from, e.to, onlyEmp)
+	if err != nil {
+		return err
+	}
+
+	ptrs := make([]*model.Employee, len(emps))
+	for i := range emps {
+		ptrs[i] = &emps[i]
+	}
+	stints, err := e.svc.loadStints(ctx, ptrs...)
+	if err != nil {
+		return err
+	}
+
+	for _, emp := range ptrs {
+		sts := stints[emp.EmployeeID]
+		items, err := e.svc.punchedDays(ctx, emp, sts, e.loc, e.from, e.to, cal)
+		if err != nil {
+			return err
+		}
+		items, err = employeeDaysFrom(items, emp, sts, e.loc, e.from, e.to, cal, leaves[emp.EmployeeID])
+		if err != nil {
+			return err
+		}
+		if e.departmentID != nil {
+			kept := items[:0]
+			for _, it := range items {
+				if sts[stintOn(sts, it.DateLocal)].dept.ID == *e.departmentID {
+					kept = append(kept, it)
+				}
+			}
+			items = kept
+		}
+		if err := fn(emp, sts, items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) ExportEmployeeAtdHistories(ctx context.Context, p ListInputEmp) (*HistoryExport, error) {
+	empId := helper.NormalizeStringField(p.EmployeeID)
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+
+	emp, err := s.empRepo.GetByEmployeeIDWithArchived(ctx, empId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, empId)
+		}
+		return nil, err
+	}
+
+	exp, err := s.newHistoryExport(p.FromLocal, p.ToLocal, p.TZ)
+	if err != nil {
+		return nil, err
+	}
+	exp.employees = func(context.Context) ([]model.Employee, error) {
+		return []model.Employee{*emp}, nil
+	}
+	return exp, nil
+}
+
+func (s *service) ExportDepartmentAtdHistories(ctx context.Context, p ListInputDept) (*HistoryExport, error) {
+	exp, err := s.newHistoryExport(p.FromLocal, p.ToLocal, p.TZ)
+	if err != nil {
+		return nil, err
+	}
+
+	exp.departmentID = p.DepartmentID
+	exp.employees = func(ctx context.Context) ([]model.Employee, error) {
+		return s.empRepo.ListAssignedJoinDept(ctx, p.DepartmentID, exp.from, exp.to)
+	}
+	return exp, nil
+}
+
+func (s *service) newHistoryExport(fromLocal, toLocal, tz string) (*HistoryExport, error) {
+	if fromLocal == "" || toLocal == "" {
+		return nil, fmt.Errorf("%w: from/to are required (YYYY-MM-DD)", appErr.ErrRequiredField)
+	}
+
+	loc := helper.LoadLocationOrUTC(tz)
+
+	y1, m1, d1, err := helper.ParseYYYYMMDD(fromLocal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'from' date", appErr.ErrInvalidInput)
+	}
+	y2, m2, d2, err := helper.ParseYYYYMMDD(toLocal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'to' date", appErr.ErrInvalidInput)
+	}
+
+	from, to := localDate(loc, y1, m1, d1), localDate(loc, y2, m2, d2)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' is before 'from'", appErr.ErrInvalidInput)
+	}
+	if to.Sub(from) >= maxExportDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: export range is limited to %d days", appErr.ErrInvalidInput, maxExportDays)
+	}
+
+	return &HistoryExport{
+		FromLocal: fromLocal,
+		ToLocal:   toLocal,
+		TZUsed:    tz,
+		svc:       s,
+		loc:       loc,
+		from:      from,
+		to:        to,
+	}, nil
+}
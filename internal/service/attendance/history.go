@@ -0,0 +1,98 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+)
+
+// the day calendar of the listing is a recursive CTE, MySQL stops those at
+// 1000 levels by default
+const maxHistoryDays = 366
+
+// listHistoryDays pages through employee days aggregated in SQL and counts
+// them with a separate query. In APP_TZ the days come from attendance_daily.
+// Otherwise punches are bucketed in SQL with the offset loc had at each of
+// them, like DepartmentSummary.
+func (s *service) listHistoryDays(
+	ctx context.Context,
+	p atdrepo.HistoryDayParams,
+	loc *time.Location,
+	from, to time.Time,
+	withDepartment bool,
+) ([]AttendanceHistoryItem, int64, error) {
+	if to.Before(from) {
+		return nil, 0, fmt.Errorf("%w: 'to' must not be before 'from'", appErr.ErrInvalidTimeRange)
+	}
+	if to.Sub(from) > maxHistoryDays*24*time.Hour {
+		return nil, 0, fmt.Errorf("%w: range is limited to %d days", appErr.ErrInvalidRange, maxHistoryDays)
+	}
+
+	now := time.Now().In(loc)
+	p.FromDate = from
+	p.ToDate = to
+	p.FromUTC, _ = helper.DayBoundsLocalToUTC(loc, from.Year(), from.Month(), from.Day())
+	_, p.ToUTC = helper.DayBoundsLocalToUTC(loc, to.Year(), to.Month(), to.Day())
+	p.Offsets = zoneSpans(loc, p.FromUTC, p.ToUTC)
+	p.TodayLocal = now
+	p.NowLocalTime = now.Format("15:04:05")
+	p.Daily = dailyZone(loc)
+
+	total, err := s.atdRepo.CountHistoryDays(ctx, p)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := s.atdRepo.ListHistoryDays(ctx, p)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]AttendanceHistoryItem, 0, len(rows))
+	for _, r := range rows {
+		item := AttendanceHistoryItem{
+			EmployeeID:   r.EmployeeID,
+			EmployeeName: r.EmployeeName,
+			DateLocal:    helper.DateKey(r.Day),
+			Holiday:      r.Holiday,
+		}
+		if withDepartment {
+			deptName := r.DepartmentName
+			item.DepartmentName = &deptName
+		}
+
+		if !r.Punched {
+			item.StatusIn = "absent"
+			item.StatusOut = "absent"
+			if r.LeaveType != nil {
+				item.StatusIn = "on_leave"
+				item.StatusOut = "on_leave"
+				item.LeaveType = r.LeaveType
+			}
+			items = append(items, item)
+			continue
+		}
+
+		item.Corrected = r.Corrected
+		item.NeedsReview = r.NeedsReview
+		if r.FirstIn != nil {
+			item.AttendanceID = r.AttendanceID
+		}
+		if r.StatusIn != nil && r.StatusOut != nil {
+			// pre-computed in attendance_daily
+			setClockTimes(&item, r.FirstIn, r.LastOut, loc)
+			item.StatusIn, item.StatusOut = *r.StatusIn, *r.StatusOut
+			item.DeltaInMinutes, item.DeltaOutMinutes = r.DeltaInMinutes, r.DeltaOutMinutes
+		} else {
+			applyPunches(&item, r.FirstIn, r.LastOut, loc, r.MaxClockInTime, r.MaxClockOutTime)
+			if r.Holiday != nil {
+				markHolidayWork(&item)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, total, nil
+}
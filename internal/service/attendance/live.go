@@ -0,0 +1,95 @@
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+// LiveBoard lists who is on site right now: every open attendance grouped by
+// department, earliest clock-in first.
+func (s *service) LiveBoard(ctx context.Context, departmentID *uint64) (*LiveOutput, error) {
+	rows, err := s.atdRepo.ListOpen(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	out := &LiveOutput{AsOf: now, Departments: []LiveDepartment{}}
+	idx := map[uint64]int{}
+
+	for _, a := range rows {
+		if a.ClockIn == nil {
+			continue
+		}
+		dept := a.Employee.Department
+		i, ok := idx[dept.ID]
+		if !ok {
+			i = len(out.Departments)
+			idx[dept.ID] = i
+			out.Departments = append(out.Departments, LiveDepartment{
+				DepartmentID:   dept.ID,
+				DepartmentName: dept.DepartmentName,
+				Employees:      []LiveEmployee{},
+			})
+		}
+
+		d := &out.Departments[i]
+		d.Employees = append(d.Employees, LiveEmployee{
+			EmployeeID:    a.EmployeeID,
+			EmployeeName:  a.Employee.Name,
+			AttendanceID:  a.AttendanceID,
+			ClockInUTC:    *a.ClockIn,
+			OnSiteMinutes: int(now.Sub(*a.ClockIn).Minutes()),
+		})
+		d.Count++
+		out.Total++
+	}
+
+	return out, nil
+}
+
+// SubscribeLive streams clock-in and clock-out events, optionally for one
+// department. Call the returned func to stop.
+func (s *service) SubscribeLive(departmentID *uint64) (<-chan event.Event, func()) {
+	var filter func(event.Event) bool
+	if departmentID != nil {
+		id := *departmentID
+		filter = func(e event.Event) bool { return e.DepartmentID == id }
+	}
+	return s.bus.Subscribe(32, filter)
+}
+
+// newEvent is shared by the live bus and the webhook outbox.
+func newEvent(typ string, emp *model.Employee, attendanceID string, at time.Time, source string) event.Event {
+	return event.Event{
+		Type:           typ,
+		At:             at,
+		EmployeeID:     emp.EmployeeID,
+		EmployeeName:   emp.Name,
+		DepartmentID:   emp.DepartmentID,
+		DepartmentName: emp.Department.DepartmentName,
+		AttendanceID:   attendanceID,
+		Source:         source,
+	}
+}
+
+type lateEvent struct {
+	event.Event
+	LateMinutes int `json:"late_minutes"`
+}
+
+// minutesLate compares a clock-in with the department cutoff on its local
+// date, 0 when on time or when the cutoff cannot be parsed.
+func minutesLate(clockIn time.Time, maxIn string, loc *time.Location) int {
+	h, m, sec, err := helper.ParseCutoffHHMMSS(maxIn)
+	if err != nil {
+		return 0
+	}
+	local := clockIn.In(loc)
+	deadline := time.Date(local.Year(), local.Month(), local.Day(), h, m, sec, 0, loc)
+	return max(signedCeilMinutes(local.Sub(deadline)), 0)
+}
@@ -0,0 +1,52 @@
+package attendance
+
+import (
+	"context"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+// RangeTotals sums timesheet figures per employee over an arbitrary date
+// range, e.g. a pay period. Days are computed exactly as in the histories
+// and timesheets.
+func (s *service) RangeTotals(ctx context.Context, in RangeTotalsInput) ([]EmployeeTotals, error) {
+	exp, err := s.ExportDepartmentAtdHistories(ctx, ListInputDept{
+		DepartmentID: in.DepartmentID,
+		FromLocal:    in.FromLocal,
+		ToLocal:      in.ToLocal,
+		TZ:           in.TZ,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []EmployeeTotals
+	err = exp.eachEmployee(ctx, func(emp *model.Employee, sts []stint, items []AttendanceHistoryItem) error {
+		row := EmployeeTotals{
+			EmployeeID:     emp.EmployeeID,
+			EmployeeName:   emp.Name,
+			DepartmentID:   emp.DepartmentID,
+			DepartmentName: emp.Department.DepartmentName,
+		}
+		if in.DepartmentID != nil {
+			// the days are only those spent in the department asked for
+			for _, st := range sts {
+				if st.dept.ID == *in.DepartmentID {
+					row.DepartmentID, row.DepartmentName = st.dept.ID, st.dept.DepartmentName
+					break
+				}
+			}
+		}
+		for _, it := range items {
+			dept := sts[stintOn(sts, it.DateLocal)].dept
+			day := summarizeDay(TimesheetDay{DateLocal: it.DateLocal}, it, dept.BreakMinutes)
+			row.Totals.add(day)
+		}
+		out = append(out, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -10,16 +10,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/helper"
 	"github.com/itsaFan/fleetify-be/internal/model"
 	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
 	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
 	"gorm.io/gorm"
 )
 
 type service struct {
-	atdRepo atdrepo.Repository
-	empRepo emprepo.Repository
+	atdRepo     atdrepo.Repository
+	empRepo     emprepo.Repository
+	holidayRepo holidayrepo.Repository
+	leaveRepo   leaverepo.Repository
+	bus         *event.Bus
 }
 
 type Service interface {
@@ -28,10 +35,31 @@ type Service interface {
 
 	ListEmployeeAtdHistories(ctx context.Context, p ListInputEmp) (*AttendanceHistoryOutput, error)
 	ListDeparmentAtdHistories(ctx context.Context, p ListInputDept) (*AttendanceHistoryOutput, error)
+	ExportEmployeeAtdHistories(ctx context.Context, p ListInputEmp) (*HistoryExport, error)
+	ExportDepartmentAtdHistories(ctx context.Context, p ListInputDept) (*HistoryExport, error)
+	EmployeeTimesheet(ctx context.Context, in TimesheetInput) (*TimesheetOutput, error)
+	DepartmentSummary(ctx context.Context, in SummaryInput) (*SummaryOutput, error)
+	RangeTotals(ctx context.Context, in RangeTotalsInput) ([]EmployeeTotals, error)
+
+	AutoCloseOpenAttendances(ctx context.Context, in AutoCloseInput) (*AutoCloseOutput, error)
+	// RefreshDaily rewrites the attendance_daily rows of the days holding at,
+	// call it inside the transaction that wrote the history rows.
+	RefreshDaily(ctx context.Context, tx atdrepo.Repository, emp *model.Employee, at ...time.Time) error
+	RebuildDaily(ctx context.Context, in RebuildDailyInput) (*RebuildDailyOutput, error)
+	ListNeedsReview(ctx context.Context, in ListReviewInput) (*ListReviewOutput, error)
+
+	LiveBoard(ctx context.Context, departmentID *uint64) (*LiveOutput, error)
+	SubscribeLive(departmentID *uint64) (<-chan event.Event, func())
 }
 
-func New(atdRepo atdrepo.Repository, empRepo emprepo.Repository) Service {
-	return &service{atdRepo: atdRepo, empRepo: empRepo}
+func New(
+	atdRepo atdrepo.Repository,
+	empRepo emprepo.Repository,
+	holidayRepo holidayrepo.Repository,
+	leaveRepo leaverepo.Repository,
+	bus *event.Bus,
+) Service {
+	return &service{atdRepo: atdRepo, empRepo: empRepo, holidayRepo: holidayRepo, leaveRepo: leaveRepo, bus: bus}
 }
 
 func (s *service) CreateEmpAttendance(ctx context.Context, employeeID string) (*model.Attendance, error) {
@@ -49,10 +77,17 @@ func (s *service) CreateEmpAttendance(ctx context.Context, employeeID string) (*
 		return nil, err
 	}
 
-	_ = emp
-
 	now := time.Now().UTC()
+	if emp.EmploymentStatus != model.EmploymentActive {
+		return nil, fmt.Errorf("%w: employee %q is %s", appErr.ErrConflict, normalizedEmpId, emp.EmploymentStatus)
+	}
+	if today := now.In(config.AppTimezone()); !emp.EmployedOn(today) {
+		return nil, fmt.Errorf("%w: employee %q is not employed on %s", appErr.ErrConflict, normalizedEmpId, helper.DateKey(today))
+	}
+
 	attID := uuid.New().String()
+	clockedIn := newEvent(event.AttendanceClockIn, emp, attID, now, model.HistorySourceTerminal)
+	lateMinutes := minutesLate(now, emp.Department.MaxClockInTime, config.AppTimezone())
 
 	att := &model.Attendance{
 		EmployeeID:   normalizedEmpId,
@@ -65,6 +100,7 @@ func (s *service) CreateEmpAttendance(ctx context.Context, employeeID string) (*
 		AttendanceID:   attID,
 		DateAttendance: now,
 		AttendanceType: 1,
+		Source:         model.HistorySourceTerminal,
 		Description:    "Clock in",
 	}
 
@@ -82,11 +118,25 @@ func (s *service) CreateEmpAttendance(ctx context.Context, employeeID string) (*
 		if err := tx.CreateAttendanceHistory(ctx, hist); err != nil {
 			return err
 		}
+		if err := s.RefreshDaily(ctx, tx, emp, now); err != nil {
+			return err
+		}
+		if err := tx.Outbox().Add(ctx, clockedIn.Type, clockedIn); err != nil {
+			return err
+		}
+		if lateMinutes > 0 {
+			late := lateEvent{Event: clockedIn, LateMinutes: lateMinutes}
+			late.Type = event.AttendanceLate
+			if err := tx.Outbox().Add(ctx, late.Type, late); err != nil {
+				return err
+			}
+		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
+	s.bus.Publish(clockedIn)
 	return att, nil
 }
 
@@ -105,10 +155,9 @@ func (s *service) CloseEmpAttendance(ctx context.Context, employeeID string) (*m
 		return nil, err
 	}
 
-	_ = emp
-
 	now := time.Now().UTC()
 	var updated *model.Attendance
+	var clockedOut event.Event
 
 	if err := s.atdRepo.WithTx(ctx, func(tx atdrepo.Repository) error {
 		open, err := tx.FindEmpOpenAttendanceForUpdate(ctx, normalizedEmpId)
@@ -130,12 +179,21 @@ func (s *service) CloseEmpAttendance(ctx context.Context, employeeID string) (*m
 			AttendanceID:   open.AttendanceID,
 			DateAttendance: now,
 			AttendanceType: 2,
+			Source:         model.HistorySourceTerminal,
 			Description:    "Clock out",
 		}
 
 		if err := tx.CreateAttendanceHistory(ctx, hist); err != nil {
 			return err
 		}
+		if err := s.RefreshDaily(ctx, tx, emp, now); err != nil {
+			return err
+		}
+
+		clockedOut = newEvent(event.AttendanceClockOut, emp, open.AttendanceID, now, model.HistorySourceTerminal)
+		if err := tx.Outbox().Add(ctx, clockedOut.Type, clockedOut); err != nil {
+			return err
+		}
 
 		open.ClockOut = &now
 		updated = open
@@ -145,6 +203,7 @@ func (s *service) CloseEmpAttendance(ctx context.Context, employeeID string) (*m
 		return nil, err
 	}
 
+	s.bus.Publish(clockedOut)
 	return updated, nil
 
 }
@@ -156,7 +215,7 @@ func (s *service) ListEmployeeAtdHistories(ctx context.Context, p ListInputEmp)
 		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
 	}
 
-	emp, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, empId)
+	emp, err := s.empRepo.GetByEmployeeIDWithArchived(ctx, empId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
@@ -187,20 +246,91 @@ func (s *service) ListEmployeeAtdHistories(ctx context.Context, p ListInputEmp)
 		return nil, fmt.Errorf("%w: invalid 'to' date", appErr.ErrInvalidInput)
 	}
 
-	fromUTC, _ := helper.DayBoundsLocalToUTC(loc, y1, m1, d1)
-	_, toUTC := helper.DayBoundsLocalToUTC(loc, y2, m2, d2)
+	items, total, err := s.listHistoryDays(ctx, atdrepo.HistoryDayParams{
+		EmployeeID: &emp.EmployeeID,
+		Limit:      p.Limit,
+		Page:       p.Page,
+	}, loc, localDate(loc, y1, m1, d1), localDate(loc, y2, m2, d2), false)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := s.atdRepo.ListHistoryByEmpId(ctx, atdrepo.ListParamsEmp{
-		EmployeeID: empId,
-		FromUtc:    fromUTC,
-		ToUtc:      toUTC,
-	})
+	return &AttendanceHistoryOutput{
+		Items:     items,
+		Total:     total,
+		FromLocal: p.FromLocal,
+		ToLocal:   p.ToLocal,
+		TZUsed:    p.TZ,
+	}, nil
+}
+
+// employeeDays builds one item per local date in [from, to] for the employee:
+// punched days with lateness applied, holiday work, and absent or on_leave
+// days for scheduled days without punches. Items are sorted by date and come
+// with the employee's department stints.
+func (s *service) employeeDays(ctx context.Context, emp *model.Employee, loc *time.Location, from, to time.Time) ([]AttendanceHistoryItem, []stint, error) {
+	empId := emp.EmployeeID
 
+	cal, err := s.loadHolidays(ctx, from, to)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	leaves, err := s.loadLeaves(ctx, from, to, &empId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stints, err := s.loadStints(ctx, emp)
+	if err != nil {
+		return nil, nil, err
+	}
+	sts := stints[empId]
+
+	items, err := s.punchedDays(ctx, emp, sts, loc, from, to, cal)
+	if err != nil {
+		return nil, nil, err
 	}
+	items, err = employeeDaysFrom(items, emp, sts, loc, from, to, cal, leaves[empId])
+	return items, sts, err
+}
 
-	items := groupAndCompute(rows, loc, emp.Department.MaxClockInTime, emp.Department.MaxClockOutTime, emp.Name)
+// employeeDaysFrom completes the punched days of employeeDays with absent
+// and on_leave days, each by the schedule of the department of that day.
+func employeeDaysFrom(
+	items []AttendanceHistoryItem,
+	emp *model.Employee,
+	sts []stint,
+	loc *time.Location,
+	from, to time.Time,
+	cal holidayCalendar,
+	leaves map[string]string,
+) ([]AttendanceHistoryItem, error) {
+	empId := emp.EmployeeID
+
+	present := make(map[string]bool, len(items))
+	for _, it := range items {
+		present[it.DateLocal] = true
+	}
+	for _, st := range sts {
+		f, t, ok := st.clip(loc, from, to)
+		if !ok {
+			continue
+		}
+		var err error
+		items, err = fillAbsentDays(items, present, absenceInput{
+			EmployeeID:   empId,
+			EmployeeName: emp.Name,
+			WorkingDays:  st.dept.WorkingDays,
+			MaxClockOut:  st.dept.MaxClockOutTime,
+			HireDate:     emp.HireDate,
+			LastDay:      lastDay(emp),
+			Holidays:     cal.forDepartment(st.dept.ID),
+			Leaves:       leaves,
+		}, f, t, loc)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	sort.SliceStable(items, func(i, j int) bool {
 		if items[i].DateLocal == items[j].DateLocal {
@@ -208,28 +338,7 @@ func (s *service) ListEmployeeAtdHistories(ctx context.Context, p ListInputEmp)
 		}
 		return items[i].DateLocal < items[j].DateLocal
 	})
-
-	limit := p.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 10
-	}
-	page := p.Page
-	if page <= 0 {
-		page = 1
-	}
-
-	total := int64(len(items))
-	start := min((page-1)*limit, len(items))
-	end := min(start+limit, len(items))
-	pageItems := items[start:end]
-
-	return &AttendanceHistoryOutput{
-		Items:     pageItems,
-		Total:     total,
-		FromLocal: p.FromLocal,
-		ToLocal:   p.ToLocal,
-		TZUsed:    p.TZ,
-	}, nil
+	return items, nil
 }
 
 func (s *service) ListDeparmentAtdHistories(ctx context.Context, p ListInputDept) (*AttendanceHistoryOutput, error) {
@@ -256,183 +365,17 @@ func (s *service) ListDeparmentAtdHistories(ctx context.Context, p ListInputDept
 		return nil, fmt.Errorf("%w: invalid 'to' date", appErr.ErrInvalidInput)
 	}
 
-	fromUTC, _ := helper.DayBoundsLocalToUTC(loc, y1, m1, d1)
-	_, toUTC := helper.DayBoundsLocalToUTC(loc, y2, m2, d2)
-
-	rows, err := s.atdRepo.ListHistoryByDepartment(ctx, atdrepo.ListParamsDept{
+	items, total, err := s.listHistoryDays(ctx, atdrepo.HistoryDayParams{
 		DepartmentID: p.DepartmentID,
-		FromUtc:      fromUTC,
-		ToUtc:        toUTC,
-	})
-
+		Limit:        p.Limit,
+		Page:         p.Page,
+	}, loc, localDate(loc, y1, m1, d1), localDate(loc, y2, m2, d2), true)
 	if err != nil {
 		return nil, err
 	}
 
-	type deptTimes struct {
-		in, out  string
-		name     string
-		deptName string
-	}
-	cache := map[string]deptTimes{}
-
-	getDeptTimes := func(empId string) (deptTimes, error) {
-		if v, ok := cache[empId]; ok {
-			return v, nil
-		}
-		emp, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, empId)
-		if err != nil {
-			return deptTimes{}, err
-		}
-		v := deptTimes{
-			in:       emp.Department.MaxClockInTime,
-			out:      emp.Department.MaxClockOutTime,
-			name:     emp.Name,
-			deptName: emp.Department.DepartmentName,
-		}
-		cache[empId] = v
-		return v, nil
-	}
-
-	grouped := map[string][]model.AttendanceHistory{}
-	for _, r := range rows {
-		grouped[r.EmployeeID] = append(grouped[r.EmployeeID], r)
-	}
-
-	all := make([]AttendanceHistoryItem, 0, len(rows))
-	for empId, hist := range grouped {
-		sort.SliceStable(hist, func(i, j int) bool {
-			if hist[i].DateAttendance.Equal(hist[j].DateAttendance) {
-				return hist[i].ID < hist[j].ID
-			}
-			return hist[i].DateAttendance.Before(hist[j].DateAttendance)
-		})
-
-		type dayAgg struct {
-			firstInUTC *time.Time
-			lastOutUTC *time.Time
-			attendance string
-		}
-		byDay := map[string]*dayAgg{}
-
-		for _, h := range hist {
-			local := h.DateAttendance.In(loc)
-			key := fmt.Sprintf("%04d-%02d-%02d", local.Year(), local.Month(), local.Day())
-			if _, ok := byDay[key]; !ok {
-				byDay[key] = &dayAgg{}
-			}
-			agg := byDay[key]
-			switch h.AttendanceType {
-			case 1:
-				if agg.firstInUTC == nil || h.DateAttendance.Before(*agg.firstInUTC) {
-					t := h.DateAttendance
-					agg.firstInUTC = &t
-					agg.attendance = h.AttendanceID
-				}
-
-			case 2:
-				if agg.lastOutUTC == nil || h.DateAttendance.After(*agg.lastOutUTC) {
-					t := h.DateAttendance
-					agg.lastOutUTC = &t
-				}
-			}
-		}
-
-		dt, err := getDeptTimes(empId)
-		if err != nil {
-			continue
-		}
-
-		hIn, mIn, sIn, _ := helper.ParseCutoffHHMMSS(dt.in)
-		hOut, mOut, sOut, _ := helper.ParseCutoffHHMMSS(dt.out)
-
-		for day, agg := range byDay {
-
-			item := AttendanceHistoryItem{
-				EmployeeID:     empId,
-				EmployeeName:   dt.name,
-				DepartmentName: &dt.deptName,
-				DateLocal:      day,
-				StatusIn:       "missing_in",
-				StatusOut:      "no_out",
-			}
-
-			y, _m, _d, _ := helper.ParseYYYYMMDD(day)
-			deadlineInLocal := time.Date(y, time.Month(_m), _d, hIn, mIn, sIn, 0, loc)
-			deadlineOutLocal := time.Date(y, time.Month(_m), _d, hOut, mOut, sOut, 0, loc)
-
-			// Attendance IN
-			if agg.firstInUTC != nil {
-				local := agg.firstInUTC.In(loc)
-				str := local.Format("15:04:05")
-				item.ClockInLocal = &str
-				item.ClockInUTC = agg.firstInUTC
-
-				diffMin := signedCeilMinutes(local.Sub(deadlineInLocal))
-				if diffMin == 0 {
-					item.StatusIn = "on_time"
-					z := 0
-					item.DeltaInMinutes = &z
-				} else if diffMin > 0 {
-					item.StatusIn = "late"
-					item.DeltaInMinutes = &diffMin
-				} else {
-					item.StatusIn = "early"
-					item.DeltaInMinutes = &diffMin
-				}
-				item.AttendanceID = agg.attendance
-			}
-
-			// Attendance OUT
-			if agg.lastOutUTC != nil {
-				local := agg.lastOutUTC.In(loc)
-				str := local.Format("15:04:05")
-				item.ClockOutLocal = &str
-				item.ClockOutUTC = agg.lastOutUTC
-
-				diffMin := signedCeilMinutes(local.Sub(deadlineOutLocal))
-
-				switch {
-				case diffMin == 0:
-					item.StatusOut = "normal"
-					z := 0
-					item.DeltaOutMinutes = &z
-				case diffMin > 0:
-					item.StatusOut = "overtime"
-					item.DeltaOutMinutes = &diffMin
-				default:
-					item.StatusOut = "early_leave"
-					item.DeltaOutMinutes = &diffMin
-				}
-			}
-			all = append(all, item)
-		}
-
-	}
-
-	sort.SliceStable(all, func(i, j int) bool {
-		if all[i].DateLocal == all[j].DateLocal {
-			return all[i].EmployeeID < all[j].EmployeeID
-		}
-		return all[i].DateLocal < all[j].DateLocal
-	})
-
-	limit := p.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 10
-	}
-	page := p.Page
-	if page <= 0 {
-		page = 1
-	}
-
-	total := int64(len(all))
-	start := min((page-1)*limit, len(all))
-	end := min(start+limit, len(all))
-	pageItems := all[start:end]
-
 	return &AttendanceHistoryOutput{
-		Items:     pageItems,
+		Items:     items,
 		Total:     total,
 		FromLocal: p.FromLocal,
 		ToLocal:   p.ToLocal,
@@ -458,6 +401,9 @@ func groupAndCompute(
 		firstInUTC *time.Time
 		lastOutUTC *time.Time
 		attID      string
+		manualIn   bool
+		manualOut  bool
+		autoOut    bool
 	}
 	byDay := map[string]*dayAgg{}
 	var eid string
@@ -476,80 +422,38 @@ func groupAndCompute(
 		}
 		agg := byDay[key]
 
+		manual := r.Source == model.HistorySourceManual
 		switch r.AttendanceType {
 		case 1:
-			if agg.firstInUTC == nil || r.DateAttendance.Before(*agg.firstInUTC) {
+			if takePunch(agg.firstInUTC, agg.manualIn, manual, r.DateAttendance.Before) {
 				t := r.DateAttendance
 				agg.firstInUTC = &t
 				agg.attID = r.AttendanceID
+				agg.manualIn = manual
 			}
 		case 2:
-			if agg.lastOutUTC == nil || r.DateAttendance.After(*agg.lastOutUTC) {
+			if takePunch(agg.lastOutUTC, agg.manualOut, manual, r.DateAttendance.After) {
 				t := r.DateAttendance
 				agg.lastOutUTC = &t
+				agg.manualOut = manual
+				agg.autoOut = r.Source == model.HistorySourceAuto
 			}
 		}
 	}
 
-	hIn, mIn, sIn, _ := helper.ParseCutoffHHMMSS(maxIn)
-	hOut, mOut, sOut, _ := helper.ParseCutoffHHMMSS(maxOut)
-
 	items := make([]AttendanceHistoryItem, 0, len(byDay))
 	for day, agg := range byDay {
 		item := AttendanceHistoryItem{
 			EmployeeID:   eid,
 			EmployeeName: employeeName,
 			DateLocal:    day,
-			StatusIn:     "missing_in",
-			StatusOut:    "no_out",
+			Corrected:    agg.manualIn || agg.manualOut,
+			NeedsReview:  agg.autoOut,
 		}
-
-		y, _m, _d, _ := helper.ParseYYYYMMDD(day)
-		deadlineInLocal := time.Date(y, time.Month(_m), _d, hIn, mIn, sIn, 0, loc)
-		deadlineOutLocal := time.Date(y, time.Month(_m), _d, hOut, mOut, sOut, 0, loc)
-
+		applyPunches(&item, agg.firstInUTC, agg.lastOutUTC, loc, maxIn, maxOut)
 		if agg.firstInUTC != nil {
-			local := agg.firstInUTC.In(loc)
-			str := local.Format("15:04:05")
-			item.ClockInLocal = &str
-			item.ClockInUTC = agg.firstInUTC
-
-			diffMin := signedCeilMinutes(local.Sub(deadlineInLocal))
-			if diffMin == 0 {
-				item.StatusIn = "on_time"
-				z := 0
-				item.DeltaInMinutes = &z
-			} else if diffMin > 0 {
-				item.StatusIn = "late"
-				item.DeltaInMinutes = &diffMin
-			} else {
-				item.StatusIn = "early"
-				item.DeltaInMinutes = &diffMin
-			}
 			item.AttendanceID = agg.attID
 		}
-
-		if agg.lastOutUTC != nil {
-			local := agg.lastOutUTC.In(loc)
-			str := local.Format("15:04:05")
-			item.ClockOutLocal = &str
-			item.ClockOutUTC = agg.lastOutUTC
-
-			diffMin := signedCeilMinutes(local.Sub(deadlineOutLocal))
-
-			switch {
-			case diffMin == 0:
-				item.StatusOut = "normal"
-				z := 0
-				item.DeltaOutMinutes = &z
-			case diffMin > 0:
-				item.StatusOut = "overtime"
-				item.DeltaOutMinutes = &diffMin
-			default:
-				item.StatusOut = "early_leave"
-				item.DeltaOutMinutes = &diffMin
-			}
-		}
 		items = append(items, item)
 	}
 
@@ -557,6 +461,82 @@ func groupAndCompute(
 	return items
 }
 
+// applyPunches sets the clock in/out of it and rates them against the
+// department deadlines (HH:MM:SS in loc) on it.DateLocal.
+func applyPunches(it *AttendanceHistoryItem, firstIn, lastOut *time.Time, loc *time.Location, maxIn, maxOut string) {
+	hIn, mIn, sIn, _ := helper.ParseCutoffHHMMSS(maxIn)
+	hOut, mOut, sOut, _ := helper.ParseCutoffHHMMSS(maxOut)
+
+	y, _m, _d, _ := helper.ParseYYYYMMDD(it.DateLocal)
+	deadlineInLocal := time.Date(y, time.Month(_m), _d, hIn, mIn, sIn, 0, loc)
+	deadlineOutLocal := time.Date(y, time.Month(_m), _d, hOut, mOut, sOut, 0, loc)
+
+	it.StatusIn = "missing_in"
+	it.StatusOut = "no_out"
+
+	setClockTimes(it, firstIn, lastOut, loc)
+
+	if firstIn != nil {
+		local := firstIn.In(loc)
+		diffMin := signedCeilMinutes(local.Sub(deadlineInLocal))
+		if diffMin == 0 {
+			it.StatusIn = "on_time"
+			z := 0
+			it.DeltaInMinutes = &z
+		} else if diffMin > 0 {
+			it.StatusIn = "late"
+			it.DeltaInMinutes = &diffMin
+		} else {
+			it.StatusIn = "early"
+			it.DeltaInMinutes = &diffMin
+		}
+	}
+
+	if lastOut != nil {
+		local := lastOut.In(loc)
+		diffMin := signedCeilMinutes(local.Sub(deadlineOutLocal))
+
+		switch {
+		case diffMin == 0:
+			it.StatusOut = "normal"
+			z := 0
+			it.DeltaOutMinutes = &z
+		case diffMin > 0:
+			it.StatusOut = "overtime"
+			it.DeltaOutMinutes = &diffMin
+		default:
+			it.StatusOut = "early_leave"
+			it.DeltaOutMinutes = &diffMin
+		}
+	}
+}
+
+func setClockTimes(it *AttendanceHistoryItem, firstIn, lastOut *time.Time, loc *time.Location) {
+	if firstIn != nil {
+		str := firstIn.In(loc).Format("15:04:05")
+		it.ClockInLocal = &str
+		it.ClockInUTC = firstIn
+	}
+	if lastOut != nil {
+		str := lastOut.In(loc).Format("15:04:05")
+		it.ClockOutLocal = &str
+		it.ClockOutUTC = lastOut
+	}
+}
+
+// takePunch decides whether a punch replaces the current pick of the day.
+// Manual (corrected) punches win over terminal ones; within the same source
+// better reports whether the candidate is earlier (in) or later (out).
+func takePunch(cur *time.Time, curManual, manual bool, better func(time.Time) bool) bool {
+	if cur == nil {
+		return true
+	}
+	if manual != curManual {
+		return manual
+	}
+	return better(*cur)
+}
+
 func signedCeilMinutes(d time.Duration) int {
 	secs := d.Seconds()
 	if secs >= 0 {
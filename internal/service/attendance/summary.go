@@ -0,0 +1,149 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+)
+
+const maxSummaryDays = 93
+
+// DepartmentSummary returns per department, per local day counts computed in
+// SQL, in APP_TZ over the day picks of attendance_daily. Punch times are
+// shifted with the offset in effect at each of them, so ranges crossing a DST
+// switch bucket both sides correctly.
+func (s *service) DepartmentSummary(ctx context.Context, in SummaryInput) (*SummaryOutput, error) {
+	loc := helper.LoadLocationOrUTC(in.TZ)
+	if in.FromLocal == "" || in.ToLocal == "" {
+		return nil, fmt.Errorf("%w: from/to are required (YYYY-MM-DD)", appErr.ErrRequiredField)
+	}
+	y1, m1, d1, err := helper.ParseYYYYMMDD(in.FromLocal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'from' date", appErr.ErrInvalidInput)
+	}
+	y2, m2, d2, err := helper.ParseYYYYMMDD(in.ToLocal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'to' date", appErr.ErrInvalidInput)
+	}
+
+	from := localDate(loc, y1, m1, d1)
+	to := localDate(loc, y2, m2, d2)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", appErr.ErrInvalidTimeRange)
+	}
+	if to.Sub(from) > maxSummaryDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range is limited to %d days", appErr.ErrInvalidRange, maxSummaryDays)
+	}
+
+	fromUTC, _ := helper.DayBoundsLocalToUTC(loc, y1, m1, d1)
+	_, toUTC := helper.DayBoundsLocalToUTC(loc, y2, m2, d2)
+	now := time.Now().In(loc)
+
+	rows, err := s.atdRepo.DepartmentDailySummary(ctx, atdrepo.SummaryParams{
+		FromDate:     from,
+		ToDate:       to,
+		FromUTC:      fromUTC,
+		ToUTC:        toUTC,
+		Offsets:      zoneSpans(loc, fromUTC, toUTC),
+		DepartmentID: in.DepartmentID,
+		TodayLocal:   now,
+		NowLocalTime: now.Format("15:04:05"),
+		Daily:        dailyZone(loc),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SummaryOutput{
+		From:        helper.DateKey(from),
+		To:          helper.DateKey(to),
+		TZUsed:      loc.String(),
+		Departments: []DepartmentSummary{},
+	}
+	idx := map[uint64]int{}
+	for _, r := range rows {
+		i, ok := idx[r.DepartmentID]
+		if !ok {
+			i = len(out.Departments)
+			idx[r.DepartmentID] = i
+			out.Departments = append(out.Departments, DepartmentSummary{
+				DepartmentID:   r.DepartmentID,
+				DepartmentName: r.DepartmentName,
+				Days:           []DaySummary{},
+			})
+		}
+
+		day := DaySummary{
+			Date:       helper.DateKey(r.Day),
+			Headcount:  r.Headcount,
+			Scheduled:  r.Scheduled,
+			Present:    r.Present,
+			Late:       r.Late,
+			EarlyLeave: r.EarlyLeave,
+			Absent:     r.Absent,
+			OnLeave:    r.OnLeave,
+		}
+		if r.AvgLateMinutes != nil {
+			v := round2(*r.AvgLateMinutes)
+			day.AvgLatenessMinutes = &v
+		}
+		day.PunctualityRate = punctuality(r.CheckedIn, r.Late)
+
+		d := &out.Departments[i]
+		d.Days = append(d.Days, day)
+		d.Totals.Present += r.Present
+		d.Totals.Late += r.Late
+		d.Totals.EarlyLeave += r.EarlyLeave
+		d.Totals.Absent += r.Absent
+		d.Totals.OnLeave += r.OnLeave
+		d.Totals.checkedIn += r.CheckedIn
+		if r.AvgLateMinutes != nil {
+			d.Totals.lateMinutes += *r.AvgLateMinutes * float64(r.Late)
+		}
+	}
+
+	for i := range out.Departments {
+		t := &out.Departments[i].Totals
+		if t.Late > 0 {
+			v := round2(t.lateMinutes / float64(t.Late))
+			t.AvgLatenessMinutes = &v
+		}
+		t.PunctualityRate = punctuality(t.checkedIn, t.Late)
+	}
+
+	return out, nil
+}
+
+// punctuality is the share of clock-ins that were not late, in percent. Nil
+// when nobody clocked in.
+func punctuality(checkedIn, late int64) *float64 {
+	if checkedIn == 0 {
+		return nil
+	}
+	v := round2(float64(checkedIn-late) / float64(checkedIn) * 100)
+	return &v
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// zoneSpans lists the offsets loc goes through over [fromUTC, toUTC], one span
+// per DST period, for the SQL day bucketing.
+func zoneSpans(loc *time.Location, fromUTC, toUTC time.Time) []atdrepo.ZoneSpan {
+	var spans []atdrepo.ZoneSpan
+	for t := fromUTC; ; {
+		_, off := t.In(loc).Zone()
+		spans = append(spans, atdrepo.ZoneSpan{FromUTC: t, OffsetSeconds: off})
+		_, end := t.In(loc).ZoneBounds()
+		if end.IsZero() || end.After(toUTC) {
+			return spans
+		}
+		t = end.UTC()
+	}
+}
@@ -0,0 +1,163 @@
+package attendance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"gorm.io/gorm"
+)
+
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// breaks are only deducted from sessions longer than this
+const breakAfterMinutes = 4 * 60
+
+// EmployeeTimesheet summarizes the week (Mon-Sun) or calendar month holding
+// in.Date. Every date of the period is listed; days after today have no
+// status yet.
+func (s *service) EmployeeTimesheet(ctx context.Context, in TimesheetInput) (*TimesheetOutput, error) {
+	empId := helper.NormalizeStringField(in.EmployeeID)
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+
+	period := strings.ToLower(strings.TrimSpace(in.Period))
+	if period == "" {
+		period = PeriodWeek
+	}
+	if period != PeriodWeek && period != PeriodMonth {
+		return nil, fmt.Errorf("%w: period must be week or month", appErr.ErrInvalidInput)
+	}
+
+	loc := helper.LoadLocationOrUTC(in.TZ)
+
+	ref := time.Now().In(loc)
+	if in.Date != "" {
+		y, m, d, err := helper.ParseYYYYMMDD(in.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid 'date'", appErr.ErrInvalidInput)
+		}
+		ref = localDate(loc, y, m, d)
+	}
+	from, to := periodBounds(period, ref, loc)
+
+	emp, err := s.empRepo.GetByEmployeeIDWithArchived(ctx, empId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, empId)
+		}
+		return nil, err
+	}
+
+	items, sts, err := s.employeeDays(ctx, emp, loc, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]AttendanceHistoryItem, len(items))
+	for _, it := range items {
+		byDate[it.DateLocal] = it
+	}
+
+	out := &TimesheetOutput{
+		EmployeeID:   emp.EmployeeID,
+		EmployeeName: emp.Name,
+		Period:       period,
+		From:         helper.DateKey(from),
+		To:           helper.DateKey(to),
+		TZUsed:       loc.String(),
+		Days:         []TimesheetDay{},
+	}
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		key := helper.DateKey(d)
+		day := TimesheetDay{DateLocal: key, Weekday: d.Weekday().String()}
+
+		if it, ok := byDate[key]; ok {
+			day = summarizeDay(day, it, sts[stintOn(sts, key)].dept.BreakMinutes)
+			out.Totals.add(day)
+		}
+		out.Days = append(out.Days, day)
+	}
+
+	return out, nil
+}
+
+// summarizeDay turns a history item into timesheet numbers. Worked time needs
+// both punches; overtime on a holiday is the whole worked time.
+func summarizeDay(day TimesheetDay, it AttendanceHistoryItem, breakMinutes int) TimesheetDay {
+	day.StatusIn = it.StatusIn
+	day.StatusOut = it.StatusOut
+	day.ClockInLocal = it.ClockInLocal
+	day.ClockOutLocal = it.ClockOutLocal
+	day.Holiday = it.Holiday
+	day.LeaveType = it.LeaveType
+	day.Corrected = it.Corrected
+	day.NeedsReview = it.NeedsReview
+
+	if it.ClockInUTC != nil && it.ClockOutUTC != nil && it.ClockOutUTC.After(*it.ClockInUTC) {
+		gross := int(it.ClockOutUTC.Sub(*it.ClockInUTC).Minutes())
+		if gross > breakAfterMinutes {
+			day.BreakMinutes = breakMinutes
+		}
+		day.WorkedMinutes = gross - day.BreakMinutes
+	}
+
+	if it.StatusIn == "late" && it.DeltaInMinutes != nil {
+		day.LateMinutes = *it.DeltaInMinutes
+	}
+	switch {
+	case it.StatusOut == "early_leave" && it.DeltaOutMinutes != nil:
+		day.EarlyLeaveMinutes = -*it.DeltaOutMinutes
+	case it.StatusOut == "overtime" && it.DeltaOutMinutes != nil:
+		day.OvertimeMinutes = *it.DeltaOutMinutes
+	case it.StatusOut == "holiday_work":
+		day.OvertimeMinutes = day.WorkedMinutes
+	}
+	return day
+}
+
+func (t *TimesheetTotals) add(d TimesheetDay) {
+	t.WorkedMinutes += d.WorkedMinutes
+	t.BreakMinutes += d.BreakMinutes
+	t.OvertimeMinutes += d.OvertimeMinutes
+	t.LateMinutes += d.LateMinutes
+	t.EarlyLeaveMinutes += d.EarlyLeaveMinutes
+
+	switch d.StatusIn {
+	case "absent":
+		t.AbsentDays++
+		return
+	case "on_leave":
+		t.OnLeaveDays++
+		return
+	case "holiday_work":
+		t.HolidayWorkDays++
+	case "late":
+		t.LateCount++
+	}
+	t.PresentDays++
+	if d.StatusOut == "early_leave" {
+		t.EarlyLeaveCount++
+	}
+}
+
+// periodBounds returns local midnight of the first and last date of the
+// period holding ref.
+func periodBounds(period string, ref time.Time, loc *time.Location) (time.Time, time.Time) {
+	day := localDate(loc, ref.Year(), ref.Month(), ref.Day())
+	if period == PeriodMonth {
+		first := localDate(loc, day.Year(), day.Month(), 1)
+		return first, first.AddDate(0, 1, -1)
+	}
+	// ISO week, Monday first
+	monday := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	return monday, monday.AddDate(0, 0, 6)
+}
@@ -1,6 +1,10 @@
 package attendance
 
-import "time"
+import (
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
 
 type ListInputEmp struct {
 	EmployeeID string
@@ -27,13 +31,17 @@ type AttendanceHistoryItem struct {
 	DateLocal       string     `json:"date_local"`
 	ClockInLocal    *string    `json:"clock_in_local"`
 	ClockInUTC      *time.Time `json:"clock_in_utc"`
-	StatusIn        string     `json:"status_in"` // on_time | late | early | missing_in
+	StatusIn        string     `json:"status_in"` // on_time | late | early | missing_in | absent | on_leave | holiday_work
 	DeltaInMinutes  *int       `json:"delta_in_minutes"`
 	ClockOutLocal   *string    `json:"clock_out_local"`
 	ClockOutUTC     *time.Time `json:"clock_out_utc"`
-	StatusOut       string     `json:"status_out"` // normal | overtime | no_out
+	StatusOut       string     `json:"status_out"` // normal | overtime | early_leave | no_out | absent | on_leave | holiday_work
 	DeltaOutMinutes *int       `json:"delta_out_minutes"`
 	AttendanceID    string     `json:"attendance_id,omitempty"`
+	Holiday         *string    `json:"holiday,omitempty"`
+	LeaveType       *string    `json:"leave_type,omitempty"`
+	Corrected       bool       `json:"corrected,omitempty"`
+	NeedsReview     bool       `json:"needs_review,omitempty"`
 }
 
 type AttendanceHistoryOutput struct {
@@ -43,3 +51,190 @@ type AttendanceHistoryOutput struct {
 	ToLocal   string
 	TZUsed    string
 }
+
+type AutoCloseInput struct {
+	OlderThan time.Duration
+	// zone of the department cutoff times, UTC when nil
+	Loc *time.Location
+}
+
+type AutoClosedItem struct {
+	AttendanceID string    `json:"attendance_id"`
+	EmployeeID   string    `json:"employee_id"`
+	ClockInUTC   time.Time `json:"clock_in_utc"`
+	ClockOutUTC  time.Time `json:"clock_out_utc"`
+}
+
+type AutoCloseOutput struct {
+	Closed int `json:"closed"`
+	// clocked out by the employee before the job got to them
+	Skipped int              `json:"skipped"`
+	Items   []AutoClosedItem `json:"items"`
+	Errors  []string         `json:"errors,omitempty"`
+}
+
+type ListReviewInput struct {
+	DepartmentID *uint64
+	Limit        int
+	Page         int
+}
+
+type ListReviewOutput struct {
+	Items []model.Attendance
+	Total int64
+}
+
+type TimesheetInput struct {
+	EmployeeID string
+	// week | month, defaults to week
+	Period string
+	// "YYYY-MM-DD" inside the period, defaults to today
+	Date string
+	TZ   string
+}
+
+type TimesheetDay struct {
+	DateLocal         string  `json:"date_local"`
+	Weekday           string  `json:"weekday"`
+	StatusIn          string  `json:"status_in,omitempty"`
+	StatusOut         string  `json:"status_out,omitempty"`
+	ClockInLocal      *string `json:"clock_in_local,omitempty"`
+	ClockOutLocal     *string `json:"clock_out_local,omitempty"`
+	WorkedMinutes     int     `json:"worked_minutes"`
+	BreakMinutes      int     `json:"break_minutes"`
+	LateMinutes       int     `json:"late_minutes"`
+	EarlyLeaveMinutes int     `json:"early_leave_minutes"`
+	OvertimeMinutes   int     `json:"overtime_minutes"`
+	Holiday           *string `json:"holiday,omitempty"`
+	LeaveType         *string `json:"leave_type,omitempty"`
+	Corrected         bool    `json:"corrected,omitempty"`
+	NeedsReview       bool    `json:"needs_review,omitempty"`
+}
+
+type TimesheetTotals struct {
+	WorkedMinutes     int `json:"worked_minutes"`
+	BreakMinutes      int `json:"break_minutes"`
+	OvertimeMinutes   int `json:"overtime_minutes"`
+	LateMinutes       int `json:"late_minutes"`
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+	PresentDays       int `json:"present_days"`
+	LateCount         int `json:"late_count"`
+	EarlyLeaveCount   int `json:"early_leave_count"`
+	AbsentDays        int `json:"absent_days"`
+	OnLeaveDays       int `json:"on_leave_days"`
+	HolidayWorkDays   int `json:"holiday_work_days"`
+}
+
+type TimesheetOutput struct {
+	EmployeeID   string          `json:"employee_id"`
+	EmployeeName string          `json:"employee_name"`
+	Period       string          `json:"period"`
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	TZUsed       string          `json:"tz_used"`
+	Days         []TimesheetDay  `json:"days"`
+	Totals       TimesheetTotals `json:"totals"`
+}
+
+type RangeTotalsInput struct {
+	DepartmentID *uint64
+	FromLocal    string
+	ToLocal      string
+	TZ           string
+}
+
+type EmployeeTotals struct {
+	EmployeeID     string          `json:"employee_id"`
+	EmployeeName   string          `json:"employee_name"`
+	DepartmentID   uint64          `json:"department_id"`
+	DepartmentName string          `json:"department_name"`
+	Totals         TimesheetTotals `json:"totals"`
+}
+
+type SummaryInput struct {
+	DepartmentID *uint64
+	FromLocal    string
+	ToLocal      string
+	TZ           string
+}
+
+type DaySummary struct {
+	Date               string   `json:"date"`
+	Headcount          int64    `json:"headcount"`
+	Scheduled          int64    `json:"scheduled"`
+	Present            int64    `json:"present"`
+	Late               int64    `json:"late"`
+	EarlyLeave         int64    `json:"early_leave"`
+	Absent             int64    `json:"absent"`
+	OnLeave            int64    `json:"on_leave"`
+	AvgLatenessMinutes *float64 `json:"avg_lateness_minutes"`
+	PunctualityRate    *float64 `json:"punctuality_rate"`
+}
+
+type SummaryTotals struct {
+	Present            int64    `json:"present"`
+	Late               int64    `json:"late"`
+	EarlyLeave         int64    `json:"early_leave"`
+	Absent             int64    `json:"absent"`
+	OnLeave            int64    `json:"on_leave"`
+	AvgLatenessMinutes *float64 `json:"avg_lateness_minutes"`
+	PunctualityRate    *float64 `json:"punctuality_rate"`
+
+	checkedIn   int64
+	lateMinutes float64
+}
+
+type DepartmentSummary struct {
+	DepartmentID   uint64        `json:"department_id"`
+	DepartmentName string        `json:"department_name"`
+	Days           []DaySummary  `json:"days"`
+	Totals         SummaryTotals `json:"totals"`
+}
+
+type SummaryOutput struct {
+	From        string              `json:"from"`
+	To          string              `json:"to"`
+	TZUsed      string              `json:"tz_used"`
+	Departments []DepartmentSummary `json:"departments"`
+}
+
+type LiveEmployee struct {
+	EmployeeID    string    `json:"employee_id"`
+	EmployeeName  string    `json:"employee_name"`
+	AttendanceID  string    `json:"attendance_id"`
+	ClockInUTC    time.Time `json:"clock_in_utc"`
+	OnSiteMinutes int       `json:"on_site_minutes"`
+}
+
+type LiveDepartment struct {
+	DepartmentID   uint64         `json:"department_id"`
+	DepartmentName string         `json:"department_name"`
+	Count          int            `json:"count"`
+	Employees      []LiveEmployee `json:"employees"`
+}
+
+type LiveOutput struct {
+	AsOf        time.Time        `json:"as_of"`
+	Total       int              `json:"total"`
+	Departments []LiveDepartment `json:"departments"`
+}
+
+type RebuildDailyInput struct {
+	// APP_TZ calendar dates, inclusive
+	FromLocal string
+	ToLocal   string
+	// employees assigned to the department at some point of the range
+	DepartmentID *uint64
+	// a single employee, archived ones included
+	EmployeeID string
+	// every stored day up to today, From/ToLocal are ignored
+	AllDays bool
+}
+
+type RebuildDailyOutput struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	TZUsed    string `json:"tz_used"`
+	Employees int    `json:"employees"`
+	Days      int    `json:"days"`
+}
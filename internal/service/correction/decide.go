@@ -0,0 +1,203 @@
+package correction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	correctionrepo "github.com/itsaFan/fleetify-be/internal/repo/correction"
+	"gorm.io/gorm"
+)
+
+// Approve applies the correction in one transaction: the day's attendance is
+// updated (or created when the employee never punched), manual history rows
+// are appended and the pre-computed days are refreshed. Terminal history rows
+// are never touched and the previous attendance values are kept on the
+// correction, so the original data stays auditable.
+func (s *service) Approve(ctx context.Context, correctionID string, in DecisionInput) (*model.AttendanceCorrection, error) {
+	corrId, approverId, err := s.validateDecision(ctx, correctionID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.WithTx(ctx, func(tx correctionrepo.Repository, atdTx atdrepo.Repository) error {
+		cur, err := s.lockPending(ctx, tx, corrId, approverId)
+		if err != nil {
+			return err
+		}
+
+		emp, err := s.getEmployee(ctx, cur.EmployeeID)
+		if err != nil {
+			return err
+		}
+
+		loc := helper.LoadLocationOrUTC(cur.TZ)
+		fromUTC, toUTC := helper.DayBoundsLocalToUTC(loc, cur.DateLocal.Year(), cur.DateLocal.Month(), cur.DateLocal.Day())
+
+		att, err := atdTx.FindEmpAttendanceInRangeForUpdate(ctx, cur.EmployeeID, fromUTC, toUTC)
+		if err != nil {
+			return err
+		}
+
+		decision := correctionrepo.DecisionParams{
+			Status:     correctionrepo.StatusApproved,
+			ApproverID: approverId,
+			Note:       trimmed(in.Note),
+			DecidedAt:  time.Now().UTC(),
+		}
+
+		if att == nil {
+			if cur.ClockIn == nil || cur.ClockOut == nil {
+				return fmt.Errorf("%w: no attendance on %s, the correction needs both clock_in and clock_out", appErr.ErrInvalidInput, helper.DateKey(cur.DateLocal))
+			}
+			att = &model.Attendance{
+				EmployeeID:   cur.EmployeeID,
+				AttendanceID: uuid.New().String(),
+				ClockIn:      cur.ClockIn,
+				ClockOut:     cur.ClockOut,
+			}
+			if err := atdTx.CreateEmpAttendanceByEmpId(ctx, att); err != nil {
+				return err
+			}
+		} else {
+			decision.PrevClockIn = att.ClockIn
+			decision.PrevClockOut = att.ClockOut
+
+			newIn, newOut := att.ClockIn, att.ClockOut
+			if cur.ClockIn != nil {
+				newIn = cur.ClockIn
+			}
+			if cur.ClockOut != nil {
+				newOut = cur.ClockOut
+			}
+			if newIn != nil && newOut != nil && !newIn.Before(*newOut) {
+				return fmt.Errorf("%w: corrected clock_in must be earlier than clock_out", appErr.ErrInvalidTimeRange)
+			}
+			if err := atdTx.UpdateAttendanceTimes(ctx, att.AttendanceID, newIn, newOut); err != nil {
+				return err
+			}
+		}
+		decision.AttendanceID = &att.AttendanceID
+
+		desc := fmt.Sprintf("Manual correction %s approved by %s: %s", cur.CorrectionID, approverId, cur.Reason)
+		if cur.ClockIn != nil {
+			if err := atdTx.CreateAttendanceHistory(ctx, &model.AttendanceHistory{
+				EmployeeID:     cur.EmployeeID,
+				AttendanceID:   att.AttendanceID,
+				DateAttendance: *cur.ClockIn,
+				AttendanceType: 1,
+				Source:         model.HistorySourceManual,
+				Description:    desc,
+			}); err != nil {
+				return err
+			}
+		}
+		if cur.ClockOut != nil {
+			if err := atdTx.CreateAttendanceHistory(ctx, &model.AttendanceHistory{
+				EmployeeID:     cur.EmployeeID,
+				AttendanceID:   att.AttendanceID,
+				DateAttendance: *cur.ClockOut,
+				AttendanceType: 2,
+				Source:         model.HistorySourceManual,
+				Description:    desc,
+			}); err != nil {
+				return err
+			}
+		}
+
+		var changed []time.Time
+		for _, t := range []*time.Time{cur.ClockIn, cur.ClockOut} {
+			if t != nil {
+				changed = append(changed, *t)
+			}
+		}
+		if err := s.atdSvc.RefreshDaily(ctx, atdTx, emp, changed...); err != nil {
+			return err
+		}
+
+		return tx.UpdateDecision(ctx, corrId, decision)
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByCorrectionID(ctx, corrId)
+}
+
+func (s *service) Reject(ctx context.Context, correctionID string, in DecisionInput) (*model.AttendanceCorrection, error) {
+	corrId, approverId, err := s.validateDecision(ctx, correctionID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.WithTx(ctx, func(tx correctionrepo.Repository, _ atdrepo.Repository) error {
+		if _, err := s.lockPending(ctx, tx, corrId, approverId); err != nil {
+			return err
+		}
+		return tx.UpdateDecision(ctx, corrId, correctionrepo.DecisionParams{
+			Status:     correctionrepo.StatusRejected,
+			ApproverID: approverId,
+			Note:       trimmed(in.Note),
+			DecidedAt:  time.Now().UTC(),
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByCorrectionID(ctx, corrId)
+}
+
+func (s *service) validateDecision(ctx context.Context, correctionID string, in DecisionInput) (string, string, error) {
+	corrId := helper.NormalizeStringField(strings.TrimSpace(correctionID))
+	if corrId == "" {
+		return "", "", fmt.Errorf("%w: correction_id is required", appErr.ErrRequiredField)
+	}
+	approverId := helper.NormalizeStringField(strings.TrimSpace(in.ApproverID))
+	if approverId == "" {
+		return "", "", fmt.Errorf("%w: approver_id is required", appErr.ErrRequiredField)
+	}
+	if _, err := s.getEmployee(ctx, approverId); err != nil {
+		return "", "", err
+	}
+	return corrId, approverId, nil
+}
+
+// lockPending locks the pending correction and checks that approverId manages
+// the department the employee was in on the corrected day.
+func (s *service) lockPending(ctx context.Context, tx correctionrepo.Repository, corrId, approverId string) (*model.AttendanceCorrection, error) {
+	cur, err := tx.GetForUpdate(ctx, corrId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: correction %q", appErr.ErrNotFound, corrId)
+		}
+		return nil, err
+	}
+	if cur.Status != correctionrepo.StatusPending {
+		return nil, fmt.Errorf("%w: correction %q is already %s", appErr.ErrConflict, corrId, cur.Status)
+	}
+	if cur.EmployeeID == approverId {
+		return nil, fmt.Errorf("%w: employees cannot approve their own corrections", appErr.ErrInvalidInput)
+	}
+	dept, err := s.empRepo.DepartmentOn(ctx, cur.EmployeeID, cur.DateLocal)
+	if err != nil {
+		return nil, err
+	}
+	if dept.ManagerEmployeeID == nil || *dept.ManagerEmployeeID != approverId {
+		return nil, fmt.Errorf("%w: only the manager of department %q can decide this correction", appErr.ErrForbidden, dept.DepartmentName)
+	}
+	return cur, nil
+}
+
+func trimmed(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*s)
+	return &t
+}
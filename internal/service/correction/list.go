@@ -0,0 +1,85 @@
+package correction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	correctionrepo "github.com/itsaFan/fleetify-be/internal/repo/correction"
+	"gorm.io/gorm"
+)
+
+func (in *ListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	in.Status = strings.ToLower(strings.TrimSpace(in.Status))
+}
+
+func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
+	in.normalize()
+
+	params := correctionrepo.ListParams{
+		EmployeeID:   helper.NormalizeStringField(in.EmployeeID),
+		DepartmentID: in.DepartmentID,
+		Status:       in.Status,
+		Limit:        in.Limit,
+		Page:         in.Page,
+	}
+	if in.From != "" {
+		from, err := parseDate("from", in.From)
+		if err != nil {
+			return nil, err
+		}
+		params.From = &from
+	}
+	if in.To != "" {
+		to, err := parseDate("to", in.To)
+		if err != nil {
+			return nil, err
+		}
+		params.To = &to
+	}
+
+	items, total, err := s.repo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
+
+func (s *service) GetByCorrectionID(ctx context.Context, correctionID string) (*model.AttendanceCorrection, error) {
+	corrId := helper.NormalizeStringField(strings.TrimSpace(correctionID))
+	if corrId == "" {
+		return nil, fmt.Errorf("%w: correction_id is required", appErr.ErrRequiredField)
+	}
+
+	out, err := s.repo.GetByCorrectionID(ctx, corrId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: correction %q", appErr.ErrNotFound, corrId)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func parseDate(field, s string) (time.Time, error) {
+	y, m, d, err := helper.ParseYYYYMMDD(strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid '%s' date", appErr.ErrInvalidInput, field)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
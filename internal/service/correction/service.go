@@ -0,0 +1,28 @@
+package correction
+
+import (
+	"context"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	correctionrepo "github.com/itsaFan/fleetify-be/internal/repo/correction"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+type service struct {
+	repo    correctionrepo.Repository
+	empRepo emprepo.Repository
+	atdSvc  atdsvc.Service
+}
+
+type Service interface {
+	Submit(ctx context.Context, in SubmitInput) (*model.AttendanceCorrection, error)
+	List(ctx context.Context, in ListInput) (*ListOutput, error)
+	GetByCorrectionID(ctx context.Context, correctionID string) (*model.AttendanceCorrection, error)
+	Approve(ctx context.Context, correctionID string, in DecisionInput) (*model.AttendanceCorrection, error)
+	Reject(ctx context.Context, correctionID string, in DecisionInput) (*model.AttendanceCorrection, error)
+}
+
+func New(repo correctionrepo.Repository, empRepo emprepo.Repository, atdSvc atdsvc.Service) Service {
+	return &service{repo: repo, empRepo: empRepo, atdSvc: atdSvc}
+}
@@ -0,0 +1,115 @@
+package correction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	correctionrepo "github.com/itsaFan/fleetify-be/internal/repo/correction"
+	"gorm.io/gorm"
+)
+
+func (s *service) Submit(ctx context.Context, in SubmitInput) (*model.AttendanceCorrection, error) {
+	empId := helper.NormalizeStringField(strings.TrimSpace(in.EmployeeID))
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	reason := strings.TrimSpace(in.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", appErr.ErrRequiredField)
+	}
+	if in.ClockIn == nil && in.ClockOut == nil {
+		return nil, fmt.Errorf("%w: clock_in or clock_out is required", appErr.ErrRequiredField)
+	}
+
+	if _, err := s.getEmployee(ctx, empId); err != nil {
+		return nil, err
+	}
+
+	tz := strings.TrimSpace(in.TZ)
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown tz %q", appErr.ErrInvalidInput, tz)
+	}
+
+	y, m, d, err := helper.ParseYYYYMMDD(strings.TrimSpace(in.Date))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date (YYYY-MM-DD)", appErr.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	clockIn, err := localTimeOnDay(loc, y, m, d, in.ClockIn, "clock_in")
+	if err != nil {
+		return nil, err
+	}
+	clockOut, err := localTimeOnDay(loc, y, m, d, in.ClockOut, "clock_out")
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range []*time.Time{clockIn, clockOut} {
+		if t != nil && t.After(now) {
+			return nil, fmt.Errorf("%w: corrections cannot be in the future", appErr.ErrInvalidTimeRange)
+		}
+	}
+	if clockIn != nil && clockOut != nil && !clockIn.Before(*clockOut) {
+		return nil, fmt.Errorf("%w: clock_in must be earlier than clock_out", appErr.ErrInvalidTimeRange)
+	}
+
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	pending, err := s.repo.ExistsPending(ctx, empId, date)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, fmt.Errorf("%w: employee %q already has a pending correction for %s", appErr.ErrConflict, empId, helper.DateKey(date))
+	}
+
+	c := &model.AttendanceCorrection{
+		CorrectionID: uuid.New().String(),
+		EmployeeID:   empId,
+		DateLocal:    date,
+		TZ:           tz,
+		ClockIn:      clockIn,
+		ClockOut:     clockOut,
+		Reason:       reason,
+		Status:       correctionrepo.StatusPending,
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByCorrectionID(ctx, c.CorrectionID)
+}
+
+// localTimeOnDay turns "HH:MM:SS" on the given local day into UTC.
+func localTimeOnDay(loc *time.Location, y int, m time.Month, d int, hhmmss *string, field string) (*time.Time, error) {
+	if hhmmss == nil {
+		return nil, nil
+	}
+	h, mi, sec, err := helper.ParseCutoffHHMMSS(strings.TrimSpace(*hhmmss))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be HH:MM:SS", appErr.ErrInvalidInput, field)
+	}
+	t := time.Date(y, m, d, h, mi, sec, 0, loc).UTC()
+	return &t, nil
+}
+
+func (s *service) getEmployee(ctx context.Context, employeeID string) (*model.Employee, error) {
+	emp, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, employeeID)
+		}
+		return nil, err
+	}
+	return emp, nil
+}
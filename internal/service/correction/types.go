@@ -0,0 +1,37 @@
+package correction
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+type SubmitInput struct {
+	EmployeeID string
+	// "YYYY-MM-DD" in TZ
+	Date string
+	TZ   string
+	// "HH:MM:SS" local, at least one of them
+	ClockIn  *string
+	ClockOut *string
+	Reason   string
+}
+
+type DecisionInput struct {
+	ApproverID string
+	Note       *string
+}
+
+type ListInput struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	Status       string
+	From         string
+	To           string
+	Limit        int
+	Page         int
+}
+
+type ListOutput struct {
+	Data       []model.AttendanceCorrection `json:"data"`
+	Pagination helper.Pagination            `json:"pagination"`
+}
@@ -2,11 +2,16 @@ package department
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/go-sql-driver/mysql"
+
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/helper"
 	"github.com/itsaFan/fleetify-be/internal/model"
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
 )
 
 func (in CreateInput) validate() error {
@@ -19,6 +24,12 @@ func (in CreateInput) validate() error {
 	if _, err := helper.ParseTimeOfDay(in.MaxClockOut); err != nil {
 		return fmt.Errorf("%w: max_clock_out_time invalid: %v", appErr.ErrInvalidInput, err)
 	}
+	if _, err := helper.ParseWorkingDays(in.WorkingDays); err != nil {
+		return fmt.Errorf("%w: working_days invalid: %v", appErr.ErrInvalidInput, err)
+	}
+	if in.BreakMinutes != nil && (*in.BreakMinutes < 0 || *in.BreakMinutes > helper.MaxBreakMinutes) {
+		return fmt.Errorf("%w: break_minutes must be between 0 and %d", appErr.ErrInvalidInput, helper.MaxBreakMinutes)
+	}
 	return nil
 }
 
@@ -56,11 +67,41 @@ func (s *service) Create(ctx context.Context, in CreateInput) (*model.Department
 		DepartmentName:  helper.NormalizeStringField(in.DepartmentName),
 		MaxClockInTime:  in.MaxClockIn,
 		MaxClockOutTime: in.MaxClockOut,
+		WorkingDays:     in.WorkingDays,
+		BreakMinutes:    helper.DefaultBreakMinutes,
+	}
+
+	if dept.WorkingDays == "" {
+		dept.WorkingDays = helper.DefaultWorkingDays
+	}
+	if in.BreakMinutes != nil {
+		dept.BreakMinutes = *in.BreakMinutes
+	}
+	if in.ManagerEmployeeID != nil {
+		if m := helper.NormalizeStringField(*in.ManagerEmployeeID); m != "" {
+			dept.ManagerEmployeeID = &m
+		}
 	}
 
-	if err := s.repo.Create(ctx, dept); err != nil {
+	if err := s.repo.WithTx(ctx, func(tx deptrepo.Repository) error {
+		if err := tx.Create(ctx, dept); err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.DepartmentCreated, toEvent(dept))
+	}); err != nil {
+		if isForeignKeyConstraint(err) {
+			return nil, fmt.Errorf("%w: manager_employee_id %q is not an employee", appErr.ErrInvalidInput, *dept.ManagerEmployeeID)
+		}
 		return nil, err
 	}
 
 	return dept, nil
 }
+
+func isForeignKeyConstraint(err error) bool {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		return me.Number == 1452 || me.Number == 1451
+	}
+	return false
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/helper"
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
 	"gorm.io/gorm"
 )
 
@@ -16,7 +18,12 @@ func (s *service) DeleteByName(ctx context.Context, name string) error {
 		return fmt.Errorf("%w: department_name is required", appErr.ErrRequiredField)
 	}
 
-	if err := s.repo.DeleteByName(ctx, norm); err != nil {
+	if err := s.repo.WithTx(ctx, func(tx deptrepo.Repository) error {
+		if err := tx.DeleteByName(ctx, norm); err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.DepartmentDeleted, departmentEvent{DepartmentName: norm})
+	}); err != nil {
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("%w: department %q", appErr.ErrNotFound, norm)
@@ -0,0 +1,27 @@
+package department
+
+import "github.com/itsaFan/fleetify-be/internal/model"
+
+// departmentEvent is the webhook payload of department.* events.
+type departmentEvent struct {
+	ID             uint64  `json:"id,omitempty"`
+	DepartmentName string  `json:"department_name"`
+	PreviousName   string  `json:"previous_name,omitempty"`
+	MaxClockIn     string  `json:"max_clock_in,omitempty"`
+	MaxClockOut    string  `json:"max_clock_out,omitempty"`
+	WorkingDays    string  `json:"working_days,omitempty"`
+	BreakMinutes   int     `json:"break_minutes,omitempty"`
+	ManagerID      *string `json:"manager_employee_id,omitempty"`
+}
+
+func toEvent(d *model.Department) departmentEvent {
+	return departmentEvent{
+		ID:             d.ID,
+		DepartmentName: d.DepartmentName,
+		MaxClockIn:     d.MaxClockInTime,
+		MaxClockOut:    d.MaxClockOutTime,
+		WorkingDays:    d.WorkingDays,
+		BreakMinutes:   d.BreakMinutes,
+		ManagerID:      d.ManagerEmployeeID,
+	}
+}
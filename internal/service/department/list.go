@@ -2,8 +2,13 @@ package department
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
 	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
 )
 
@@ -30,12 +35,22 @@ func (in *ListInput) normalize() {
 func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
 	in.normalize()
 
+	var ks *deptrepo.Keyset
+	if in.Cursor != "" {
+		c, err := helper.DecodeCursor(in.Cursor)
+		if err != nil || c.SortBy != in.SortBy || c.Desc != (in.SortDir == "desc") {
+			return nil, fmt.Errorf("%w: invalid cursor for this sort", appErr.ErrInvalidInput)
+		}
+		ks = &deptrepo.Keyset{Key: c.Key, ID: c.ID, Before: c.Before}
+	}
+
 	items, total, err := s.repo.List(ctx, deptrepo.ListParams{
 		Search:  in.Search,
 		Limit:   in.Limit,
 		Page:    in.Page,
 		SortBy:  in.SortBy,
 		SortDir: in.SortDir,
+		Cursor:  ks,
 	})
 	if err != nil {
 		return nil, err
@@ -46,14 +61,47 @@ func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
 		totalPages = int((total + int64(in.Limit) - 1) / int64(in.Limit))
 	}
 
-	return &ListOutput{
-		Data: items,
-		Pagination: Pagination{
-			TotalData:   total,
-			CurrentPage: in.Page,
-			TotalPages:  totalPages,
-			HasNextPage: in.Page < totalPages,
-			HasPrevPage: in.Page > 1,
-		},
-	}, nil
+	pag := Pagination{
+		TotalData:   total,
+		CurrentPage: in.Page,
+		TotalPages:  totalPages,
+		HasNextPage: in.Page < totalPages,
+		HasPrevPage: in.Page > 1,
+	}
+	if ks != nil {
+		more := len(items) > in.Limit
+		if more {
+			items = items[:in.Limit]
+		}
+		pag.CurrentPage = 0
+		if ks.Before {
+			for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+				items[i], items[j] = items[j], items[i]
+			}
+			pag.HasNextPage, pag.HasPrevPage = true, more
+		} else {
+			pag.HasNextPage, pag.HasPrevPage = more, true
+		}
+	}
+
+	out := &ListOutput{Data: items, Pagination: pag}
+	if len(items) > 0 {
+		if pag.HasNextPage {
+			out.NextCursor = in.cursor(items[len(items)-1], false)
+		}
+		if pag.HasPrevPage {
+			out.PrevCursor = in.cursor(items[0], true)
+		}
+	}
+	return out, nil
+}
+
+func (in *ListInput) cursor(d model.Department, before bool) string {
+	c := helper.Cursor{SortBy: in.SortBy, Desc: in.SortDir == "desc", ID: d.ID, Before: before}
+	if in.SortBy == "department_name" {
+		c.Key = d.DepartmentName
+	} else {
+		c.Key = strconv.FormatUint(d.ID, 10)
+	}
+	return helper.EncodeCursor(c)
 }
@@ -5,10 +5,12 @@ import (
 
 	"github.com/itsaFan/fleetify-be/internal/model"
 	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
 )
 
 type service struct {
-	repo deptrepo.Repository
+	repo   deptrepo.Repository
+	atdSvc atdsvc.Service
 }
 
 type Service interface {
@@ -19,6 +21,6 @@ type Service interface {
 	DeleteByName(ctx context.Context, name string) error
 }
 
-func New(repo deptrepo.Repository) Service {
-	return &service{repo: repo}
+func New(repo deptrepo.Repository, atdSvc atdsvc.Service) Service {
+	return &service{repo: repo, atdSvc: atdSvc}
 }
@@ -4,7 +4,7 @@ import "github.com/itsaFan/fleetify-be/internal/model"
 
 type Pagination struct {
 	TotalData   int64 `json:"totalData"`
-	CurrentPage int   `json:"currentPage"`
+	CurrentPage int   `json:"currentPage"` // 0 when paging by cursor
 	TotalPages  int   `json:"totalPages"`
 	HasNextPage bool  `json:"hasNextPage"`
 	HasPrevPage bool  `json:"hasPrevPage"`
@@ -15,6 +15,12 @@ type CreateInput struct {
 	// "HH:MM:SS"
 	MaxClockIn  string
 	MaxClockOut string
+	// ISO weekdays "1,2,3,4,5", empty = Mon-Fri
+	WorkingDays string
+	// nil = helper.DefaultBreakMinutes
+	BreakMinutes *int
+	// employee_id of the digest recipient, optional
+	ManagerEmployeeID *string
 }
 
 type ListInput struct {
@@ -23,15 +29,23 @@ type ListInput struct {
 	Page    int
 	SortBy  string
 	SortDir string
+	// opaque token from a previous page, replaces Page
+	Cursor string
 }
 
 type ListOutput struct {
 	Data       []model.Department `json:"data"`
 	Pagination Pagination         `json:"pagination"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	PrevCursor string             `json:"prev_cursor,omitempty"`
 }
 
 type UpdateInput struct {
 	DepartmentName *string
 	MaxClockIn     *string
 	MaxClockOut    *string
+	WorkingDays    *string
+	BreakMinutes   *int
+	// "" clears the manager
+	ManagerEmployeeID *string
 }
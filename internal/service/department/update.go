@@ -4,16 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/helper"
 	"github.com/itsaFan/fleetify-be/internal/model"
 	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
 	"gorm.io/gorm"
 )
 
 func (in UpdateInput) isEmpty() bool {
-	return in.DepartmentName == nil && in.MaxClockIn == nil && in.MaxClockOut == nil
+	return in.DepartmentName == nil && in.MaxClockIn == nil && in.MaxClockOut == nil && in.WorkingDays == nil &&
+		in.BreakMinutes == nil && in.ManagerEmployeeID == nil
 }
 
 func (s *service) UpdateByName(ctx context.Context, currentName string, in UpdateInput) (*model.Department, error) {
@@ -64,6 +68,19 @@ func (s *service) UpdateByName(ctx context.Context, currentName string, in Updat
 		}
 		finalOut = *in.MaxClockOut
 	}
+	if in.WorkingDays != nil {
+		if _, err := helper.ParseWorkingDays(*in.WorkingDays); err != nil {
+			return nil, fmt.Errorf("%w: working_days invalid: %v", appErr.ErrInvalidInput, err)
+		}
+		// empty means Mon-Fri like on create, the summary SQL reads the column as is
+		if strings.TrimSpace(*in.WorkingDays) == "" {
+			def := helper.DefaultWorkingDays
+			in.WorkingDays = &def
+		}
+	}
+	if in.BreakMinutes != nil && (*in.BreakMinutes < 0 || *in.BreakMinutes > helper.MaxBreakMinutes) {
+		return nil, fmt.Errorf("%w: break_minutes must be between 0 and %d", appErr.ErrInvalidInput, helper.MaxBreakMinutes)
+	}
 
 	if in.isEmpty() {
 		return cur, nil
@@ -91,18 +108,47 @@ func (s *service) UpdateByName(ctx context.Context, currentName string, in Updat
 	if in.MaxClockOut != nil {
 		up.MaxClockOutTime = &finalOut
 	}
+	if in.WorkingDays != nil {
+		up.WorkingDays = in.WorkingDays
+	}
+	if in.BreakMinutes != nil {
+		up.BreakMinutes = in.BreakMinutes
+	}
+	if in.ManagerEmployeeID != nil {
+		up.ManagerEmployeeID = in.ManagerEmployeeID
+	}
 
-	if err := s.repo.UpdateByName(ctx, ident, up); err != nil {
+	var d *model.Department
+	if err := s.repo.WithTx(ctx, func(tx deptrepo.Repository) error {
+		if err := tx.UpdateByName(ctx, ident, up); err != nil {
+			return err
+		}
+		updated, err := tx.GetByName(ctx, finalName)
+		if err != nil {
+			return err
+		}
+		d = updated
+
+		ev := toEvent(updated)
+		if updated.DepartmentName != cur.DepartmentName {
+			ev.PreviousName = cur.DepartmentName
+		}
+		return tx.Outbox().Add(ctx, event.DepartmentUpdated, ev)
+	}); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("%w: department %q", appErr.ErrNotFound, ident)
 		}
+		if isForeignKeyConstraint(err) {
+			return nil, fmt.Errorf("%w: manager_employee_id %q is not an employee", appErr.ErrInvalidInput, *in.ManagerEmployeeID)
+		}
 		return nil, err
 	}
 
-	updatedName := finalName
-	d, err := s.repo.GetByName(ctx, updatedName)
-	if err != nil {
-		return nil, err
+	// stored days carry statuses and worked minutes rated against these
+	if d.MaxClockInTime != cur.MaxClockInTime || d.MaxClockOutTime != cur.MaxClockOutTime || d.BreakMinutes != cur.BreakMinutes {
+		if _, err := s.atdSvc.RebuildDaily(ctx, atdsvc.RebuildDailyInput{DepartmentID: &d.ID, AllDays: true}); err != nil {
+			return nil, fmt.Errorf("department %q updated, rebuilding its attendance days: %w", d.DepartmentName, err)
+		}
 	}
 	return d, nil
 }
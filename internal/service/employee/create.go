@@ -3,11 +3,16 @@ package employee
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/mail"
 	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/model"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
 )
 
 func (s *service) Create(ctx context.Context, in CreateInput) (*model.Employee, error) {
@@ -30,18 +35,53 @@ func (s *service) Create(ctx context.Context, in CreateInput) (*model.Employee,
 		return nil, appErr.ErrNotFound
 	}
 
+	email, err := normalizeEmail(in.Email)
+	if err != nil {
+		return nil, err
+	}
+
 	var addr string
 	if in.Address != nil {
 		addr = strings.TrimSpace(*in.Address)
 	}
 
+	hired := todayLocal()
+	if in.HireDate != "" {
+		if hired, err = parseDate("hire_date", in.HireDate); err != nil {
+			return nil, err
+		}
+	}
+	empType := model.EmploymentPermanent
+	if in.EmploymentType != "" {
+		if empType, err = normalizeEmploymentType(in.EmploymentType); err != nil {
+			return nil, err
+		}
+	}
+
 	emp := &model.Employee{
 		Name:         name,
+		Email:        email,
 		Address:      addr,
 		DepartmentID: in.Department,
+
+		HireDate:         time.Date(hired.Year(), hired.Month(), hired.Day(), 0, 0, 0, 0, time.UTC),
+		EmploymentType:   empType,
+		EmploymentStatus: model.EmploymentActive,
 	}
 
-	if err := s.empRepo.Create(ctx, emp); err != nil {
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		if err := tx.Create(ctx, emp); err != nil {
+			return err
+		}
+		if err := tx.CreateAssignment(ctx, &model.EmployeeDepartmentAssignment{
+			EmployeeID:    emp.EmployeeID,
+			DepartmentID:  emp.DepartmentID,
+			EffectiveFrom: emp.HireDate,
+		}); err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.EmployeeCreated, toEvent(emp))
+	}); err != nil {
 		if isDuplicateKey(err) {
 			return nil, appErr.ErrAlreadyExists
 		}
@@ -59,6 +99,22 @@ func (s *service) Create(ctx context.Context, in CreateInput) (*model.Employee,
 	return out, nil
 }
 
+// normalizeEmail trims and validates an optional email, blank means none.
+func normalizeEmail(s *string) (*string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	e := strings.ToLower(strings.TrimSpace(*s))
+	if e == "" {
+		return nil, nil
+	}
+	addr, err := mail.ParseAddress(e)
+	if err != nil || addr.Address != e || len(e) > 255 {
+		return nil, fmt.Errorf("%w: invalid email %q", appErr.ErrInvalidInput, e)
+	}
+	return &e, nil
+}
+
 func isDuplicateKey(err error) bool {
 	var me *mysql.MySQLError
 	if errors.As(err, &me) {
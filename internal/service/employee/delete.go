@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/helper"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
 	"gorm.io/gorm"
 )
 
@@ -16,7 +18,12 @@ func (s *service) DeleteByEmployeeID(ctx context.Context, name string) error {
 		return fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
 	}
 
-	if err := s.empRepo.DeleteByEmployeeID(ctx, norm); err != nil {
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		if err := tx.DeleteByEmployeeID(ctx, norm); err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.EmployeeDeleted, employeeEvent{EmployeeID: norm})
+	}); err != nil {
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("%w: employee %q", appErr.ErrNotFound, norm)
@@ -0,0 +1,28 @@
+package employee
+
+import "github.com/itsaFan/fleetify-be/internal/model"
+
+// employeeEvent is the webhook payload of employee.* events.
+type employeeEvent struct {
+	EmployeeID   string  `json:"employee_id"`
+	Name         string  `json:"name,omitempty"`
+	Email        *string `json:"email,omitempty"`
+	DepartmentID uint64  `json:"department_id,omitempty"`
+	Address      string  `json:"address,omitempty"`
+	Status       string  `json:"employment_status,omitempty"`
+	Type         string  `json:"employment_type,omitempty"`
+	// employee.transferred only, first day in the department
+	EffectiveDate string `json:"effective_date,omitempty"`
+}
+
+func toEvent(e *model.Employee) employeeEvent {
+	return employeeEvent{
+		EmployeeID:   e.EmployeeID,
+		Name:         e.Name,
+		Email:        e.Email,
+		DepartmentID: e.DepartmentID,
+		Address:      e.Address,
+		Status:       e.EmploymentStatus,
+		Type:         e.EmploymentType,
+	}
+}
@@ -0,0 +1,127 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	"gorm.io/gorm"
+)
+
+// transitions lists the statuses each status may move to, terminated is
+// final: a rehire is a new employee.
+var transitions = map[string][]string{
+	model.EmploymentActive:    {model.EmploymentSuspended, model.EmploymentTerminated},
+	model.EmploymentSuspended: {model.EmploymentActive, model.EmploymentTerminated},
+}
+
+func canTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStatus moves the employee along transitions. Terminating records the
+// last day of employment, today in APP_TZ unless given; it may not be in the
+// future nor before the hire date.
+func (s *service) ChangeStatus(ctx context.Context, employeeID string, in StatusInput) (*model.Employee, error) {
+	norm := helper.NormalizeStringField(employeeID)
+	if norm == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	to := strings.ToLower(strings.TrimSpace(in.Status))
+	switch to {
+	case model.EmploymentActive, model.EmploymentSuspended, model.EmploymentTerminated:
+	case "":
+		return nil, fmt.Errorf("%w: status is required", appErr.ErrRequiredField)
+	default:
+		return nil, fmt.Errorf("%w: unknown employment status %q", appErr.ErrInvalidInput, in.Status)
+	}
+	if in.TerminationDate != "" && to != model.EmploymentTerminated {
+		return nil, fmt.Errorf("%w: termination_date only applies when terminating", appErr.ErrInvalidInput)
+	}
+
+	today := todayLocal()
+	var lastDay *time.Time
+	if to == model.EmploymentTerminated {
+		d := today
+		if in.TerminationDate != "" {
+			var err error
+			if d, err = parseDate("termination_date", in.TerminationDate); err != nil {
+				return nil, err
+			}
+			if d.After(today) {
+				return nil, fmt.Errorf("%w: termination_date must not be in the future", appErr.ErrInvalidTimeRange)
+			}
+		}
+		lastDay = &d
+	}
+
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		cur, err := tx.GetByEmployeeIDJoinDept(ctx, norm)
+		if err != nil {
+			return err
+		}
+		if !canTransition(cur.EmploymentStatus, to) {
+			return fmt.Errorf("%w: cannot change status from %s to %s", appErr.ErrConflict, cur.EmploymentStatus, to)
+		}
+		if lastDay != nil && helper.DateKey(*lastDay) < helper.DateKey(cur.HireDate) {
+			return fmt.Errorf("%w: termination_date must not be before the hire date", appErr.ErrInvalidTimeRange)
+		}
+
+		if err := tx.ChangeStatus(ctx, norm, emprepo.StatusParams{
+			From:            cur.EmploymentStatus,
+			To:              to,
+			TerminationDate: lastDay,
+		}); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: status changed concurrently", appErr.ErrConflict)
+			}
+			return err
+		}
+		cur.EmploymentStatus = to
+		cur.TerminationDate = lastDay
+		return tx.Outbox().Add(ctx, event.EmployeeUpdated, toEvent(cur))
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, norm)
+		}
+		return nil, err
+	}
+
+	return s.empRepo.GetByEmployeeIDJoinDept(ctx, norm)
+}
+
+func normalizeEmploymentType(s string) (string, error) {
+	switch t := strings.ToLower(strings.TrimSpace(s)); t {
+	case model.EmploymentPermanent, model.EmploymentContract, model.EmploymentIntern:
+		return t, nil
+	default:
+		return "", fmt.Errorf("%w: unknown employment type %q", appErr.ErrInvalidInput, s)
+	}
+}
+
+// parseDate reads a YYYY-MM-DD calendar date in APP_TZ.
+func parseDate(field, s string) (time.Time, error) {
+	y, m, d, err := helper.ParseYYYYMMDD(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid '%s' date", appErr.ErrInvalidInput, field)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, config.AppTimezone()), nil
+}
+
+func todayLocal() time.Time {
+	now := time.Now().In(config.AppTimezone())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
@@ -2,8 +2,15 @@ package employee
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
 	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
 )
 
@@ -16,11 +23,13 @@ func (in *ListInput) normalize() {
 	}
 	switch strings.ToLower(strings.TrimSpace(in.SortBy)) {
 	case "id":
-		in.SortBy = "employees.id"
+		in.SortBy = "id"
 	case "department_name":
-		in.SortBy = "departments.department_name"
+		in.SortBy = "department_name"
+	case "created_at":
+		in.SortBy = "created_at"
 	default:
-		in.SortBy = "employees.name"
+		in.SortBy = "name"
 	}
 	if strings.EqualFold(in.SortDir, "desc") {
 		in.SortDir = "desc"
@@ -32,13 +41,13 @@ func (in *ListInput) normalize() {
 func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
 	in.normalize()
 
-	items, total, err := s.empRepo.ListJoinDept(ctx, emprepo.ListParams{
-		Search:  in.Search,
-		Limit:   in.Limit,
-		Page:    in.Page,
-		SortBy:  in.SortBy,
-		SortDir: in.SortDir,
-	})
+	params, err := in.params(config.AppTimezone())
+	if err != nil {
+		return nil, err
+	}
+	ks := params.Cursor
+
+	items, total, err := s.empRepo.ListJoinDept(ctx, params)
 
 	if err != nil {
 		return nil, err
@@ -49,15 +58,143 @@ func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
 		totalPages = int((total + int64(in.Limit) - 1) / int64(in.Limit))
 	}
 
-	return &ListOutput{
-		Data: items,
-		Pagination: Pagination{
-			TotalData:   total,
-			CurrentPage: in.Page,
-			TotalPages:  totalPages,
-			HasNextPage: in.Page < totalPages,
-			HasPrevPage: in.Page > 1,
-		},
-	}, nil
+	pag := Pagination{
+		TotalData:   total,
+		CurrentPage: in.Page,
+		TotalPages:  totalPages,
+		HasNextPage: in.Page < totalPages,
+		HasPrevPage: in.Page > 1,
+	}
+	if ks != nil {
+		more := len(items) > in.Limit
+		if more {
+			items = items[:in.Limit]
+		}
+		pag.CurrentPage = 0
+		if ks.Before {
+			for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+				items[i], items[j] = items[j], items[i]
+			}
+			pag.HasNextPage, pag.HasPrevPage = true, more
+		} else {
+			pag.HasNextPage, pag.HasPrevPage = more, true
+		}
+	}
+
+	out := &ListOutput{Data: items, Pagination: pag}
+	if len(items) > 0 {
+		if pag.HasNextPage {
+			out.NextCursor = in.cursor(items[len(items)-1], false)
+		}
+		if pag.HasPrevPage {
+			out.PrevCursor = in.cursor(items[0], true)
+		}
+	}
+	return out, nil
+
+}
+
+const maxDepartmentFilter = 100
+
+// params validates the filters, dates are whole days in loc.
+func (in *ListInput) params(loc *time.Location) (emprepo.ListParams, error) {
+	p := emprepo.ListParams{
+		Search:            in.Search,
+		Limit:             in.Limit,
+		Page:              in.Page,
+		SortBy:            in.SortBy,
+		SortDir:           in.SortDir,
+		ClockedIn:         in.ClockedIn,
+		ManagerEmployeeID: strings.TrimSpace(in.ManagerID),
+	}
+
+	if in.Cursor != "" {
+		c, err := helper.DecodeCursor(in.Cursor)
+		if err != nil || c.SortBy != in.SortBy || c.Desc != (in.SortDir == "desc") {
+			return p, fmt.Errorf("%w: invalid cursor for this sort", appErr.ErrInvalidInput)
+		}
+		ks := &emprepo.Keyset{Key: c.Key, ID: c.ID, Before: c.Before}
+		if in.SortBy == "created_at" {
+			t, err := time.Parse(time.RFC3339, c.Key)
+			if err != nil {
+				return p, fmt.Errorf("%w: invalid cursor for this sort", appErr.ErrInvalidInput)
+			}
+			ks.Key = t
+		}
+		p.Cursor = ks
+	}
+
+	if len(in.DepartmentIDs) > maxDepartmentFilter {
+		return p, fmt.Errorf("%w: at most %d departments", appErr.ErrInvalidRange, maxDepartmentFilter)
+	}
+	for _, id := range in.DepartmentIDs {
+		if id == 0 {
+			return p, fmt.Errorf("%w: invalid department id", appErr.ErrInvalidInput)
+		}
+	}
+	p.DepartmentIDs = in.DepartmentIDs
+
+	switch st := strings.ToLower(strings.TrimSpace(in.Status)); st {
+	case "":
+	case model.EmploymentActive, model.EmploymentSuspended, model.EmploymentTerminated:
+		p.Status = st
+	default:
+		return p, fmt.Errorf("%w: unknown employment status %q", appErr.ErrInvalidInput, in.Status)
+	}
+
+	switch a := strings.ToLower(strings.TrimSpace(in.Archived)); a {
+	case "", "include", "only":
+		p.Archived = a
+	default:
+		return p, fmt.Errorf("%w: archived must be include or only", appErr.ErrInvalidInput)
+	}
+
+	var err error
+	if p.CreatedFrom, p.CreatedTo, err = dayRange(loc, "created", in.CreatedFrom, in.CreatedTo); err != nil {
+		return p, err
+	}
+	if p.UpdatedFrom, p.UpdatedTo, err = dayRange(loc, "updated", in.UpdatedFrom, in.UpdatedTo); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// dayRange turns inclusive local dates, either optional, into UTC bounds.
+func dayRange(loc *time.Location, field, from, to string) (*time.Time, *time.Time, error) {
+	var fromUTC, toUTC *time.Time
+	if from != "" {
+		y, m, d, err := helper.ParseYYYYMMDD(from)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid '%s_from' date", appErr.ErrInvalidInput, field)
+		}
+		start, _ := helper.DayBoundsLocalToUTC(loc, y, m, d)
+		fromUTC = &start
+	}
+	if to != "" {
+		y, m, d, err := helper.ParseYYYYMMDD(to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid '%s_to' date", appErr.ErrInvalidInput, field)
+		}
+		_, end := helper.DayBoundsLocalToUTC(loc, y, m, d)
+		toUTC = &end
+	}
+	if fromUTC != nil && toUTC != nil && toUTC.Before(*fromUTC) {
+		return nil, nil, fmt.Errorf("%w: '%s_to' must not be before '%s_from'", appErr.ErrInvalidTimeRange, field, field)
+	}
+	return fromUTC, toUTC, nil
+}
 
+func (in *ListInput) cursor(e model.Employee, before bool) string {
+	c := helper.Cursor{SortBy: in.SortBy, Desc: in.SortDir == "desc", ID: e.ID, Before: before}
+	switch in.SortBy {
+	case "name":
+		c.Key = e.Name
+	case "department_name":
+		c.Key = e.Department.DepartmentName
+	case "created_at":
+		c.Key = e.CreatedAt.UTC().Format(time.RFC3339)
+	default:
+		c.Key = strconv.FormatUint(e.ID, 10)
+	}
+	return helper.EncodeCursor(c)
 }
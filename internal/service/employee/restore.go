@@ -0,0 +1,66 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	"gorm.io/gorm"
+)
+
+// RestoreByEmployeeID brings an archived employee back into lists and lookups.
+func (s *service) RestoreByEmployeeID(ctx context.Context, employeeID string) (*model.Employee, error) {
+	norm := helper.NormalizeStringField(employeeID)
+	if norm == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		if err := tx.RestoreByEmployeeID(ctx, norm); err != nil {
+			return err
+		}
+		emp, err := tx.GetByEmployeeIDJoinDept(ctx, norm)
+		if err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.EmployeeRestored, toEvent(emp))
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: archived employee %q", appErr.ErrNotFound, norm)
+		}
+		return nil, err
+	}
+
+	return s.empRepo.GetByEmployeeIDJoinDept(ctx, norm)
+}
+
+// PurgeByEmployeeID permanently removes an archived employee together with
+// the attendance the foreign keys cascade to. Active employees have to be
+// archived first.
+func (s *service) PurgeByEmployeeID(ctx context.Context, employeeID string) error {
+	norm := helper.NormalizeStringField(employeeID)
+	if norm == "" {
+		return fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		if err := tx.PurgeByEmployeeID(ctx, norm); err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.EmployeePurged, employeeEvent{EmployeeID: norm})
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: archived employee %q", appErr.ErrNotFound, norm)
+		}
+		if isForeignKeyConstraint(err) {
+			return fmt.Errorf("%w: employee %q is still referenced", appErr.ErrConflict, norm)
+		}
+		return err
+	}
+	return nil
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/itsaFan/fleetify-be/internal/model"
 	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
 	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
 )
 
 type service struct {
 	empRepo  emprepo.Repository
 	deptRepo deptrepo.Repository
+	atdSvc   atdsvc.Service
 }
 
 type Service interface {
@@ -18,9 +20,15 @@ type Service interface {
 	List(ctx context.Context, in ListInput) (*ListOutput, error)
 	GetByEmployeeID(ctx context.Context, name string) (*model.Employee, error)
 	UpdateEmployeeByEmployeeID(ctx context.Context, employeeID string, in UpdateInput) (*model.Employee, error)
+	ChangeStatus(ctx context.Context, employeeID string, in StatusInput) (*model.Employee, error)
+	Transfer(ctx context.Context, employeeID string, in TransferInput) (*model.Employee, error)
+	ListAssignments(ctx context.Context, employeeID string) ([]model.EmployeeDepartmentAssignment, error)
+	// archives, see RestoreByEmployeeID and PurgeByEmployeeID
 	DeleteByEmployeeID(ctx context.Context, employeeID string) error
+	RestoreByEmployeeID(ctx context.Context, employeeID string) (*model.Employee, error)
+	PurgeByEmployeeID(ctx context.Context, employeeID string) error
 }
 
-func New(empRepo emprepo.Repository, deptRepo deptrepo.Repository) Service {
-	return &service{empRepo: empRepo, deptRepo: deptRepo}
+func New(empRepo emprepo.Repository, deptRepo deptrepo.Repository, atdSvc atdsvc.Service) Service {
+	return &service{empRepo: empRepo, deptRepo: deptRepo, atdSvc: atdSvc}
 }
@@ -0,0 +1,138 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+	"gorm.io/gorm"
+)
+
+// Transfer moves the employee to another department from the effective date
+// on, today in APP_TZ unless given. Backdating is allowed down to the start of
+// the current assignment, the days before stay with the old department.
+// The stored attendance days from the effective date on are rebuilt against
+// the new department before returning.
+func (s *service) Transfer(ctx context.Context, employeeID string, in TransferInput) (*model.Employee, error) {
+	norm := helper.NormalizeStringField(employeeID)
+	if norm == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	if in.Department == 0 {
+		return nil, fmt.Errorf("%w: department is required", appErr.ErrRequiredField)
+	}
+
+	exists, err := s.deptRepo.ExistsByID(ctx, in.Department)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: department %d", appErr.ErrNotFound, in.Department)
+	}
+
+	today := todayLocal()
+	day := today
+	if in.EffectiveDate != "" {
+		if day, err = parseDate("effective_date", in.EffectiveDate); err != nil {
+			return nil, err
+		}
+		if day.After(today) {
+			return nil, fmt.Errorf("%w: effective_date must not be in the future", appErr.ErrInvalidTimeRange)
+		}
+	}
+
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		cur, err := tx.GetByEmployeeIDForUpdate(ctx, norm)
+		if err != nil {
+			return err
+		}
+		if cur.DepartmentID == in.Department {
+			return fmt.Errorf("%w: employee is already in department %d", appErr.ErrConflict, in.Department)
+		}
+		return s.transfer(ctx, tx, cur, in.Department, day)
+	}); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, norm)
+		case isDuplicateKey(err):
+			return nil, fmt.Errorf("%w: assignments changed concurrently", appErr.ErrConflict)
+		default:
+			return nil, err
+		}
+	}
+
+	if err := s.rebuildDays(ctx, norm, day); err != nil {
+		return nil, err
+	}
+	return s.empRepo.GetByEmployeeIDJoinDept(ctx, norm)
+}
+
+// rebuildDays recomputes the stored attendance days of the employee from
+// from up to today, after a change of department.
+func (s *service) rebuildDays(ctx context.Context, employeeID string, from time.Time) error {
+	if _, err := s.atdSvc.RebuildDaily(ctx, atdsvc.RebuildDailyInput{
+		FromLocal:  helper.DateKey(from),
+		ToLocal:    helper.DateKey(todayLocal()),
+		EmployeeID: employeeID,
+	}); err != nil {
+		return fmt.Errorf("employee %q transferred, rebuilding attendance days: %w", employeeID, err)
+	}
+	return nil
+}
+
+// transfer checks day against the employment and the open assignment of cur
+// and records the move through tx, together with its outbox event.
+func (s *service) transfer(ctx context.Context, tx emprepo.Repository, cur *model.Employee, departmentID uint64, day time.Time) error {
+	if cur.EmploymentStatus == model.EmploymentTerminated {
+		return fmt.Errorf("%w: employee is terminated", appErr.ErrConflict)
+	}
+	key := helper.DateKey(day)
+	if key < helper.DateKey(cur.HireDate) {
+		return fmt.Errorf("%w: effective_date must not be before the hire date", appErr.ErrInvalidTimeRange)
+	}
+
+	stints, err := tx.ListAssignments(ctx, cur.EmployeeID)
+	if err != nil {
+		return err
+	}
+	if n := len(stints); n > 0 {
+		if start := helper.DateKey(stints[n-1].EffectiveFrom); key < start {
+			return fmt.Errorf("%w: effective_date must not be before the current assignment started on %s", appErr.ErrInvalidTimeRange, start)
+		}
+	}
+
+	if err := tx.Transfer(ctx, cur.EmployeeID, emprepo.TransferParams{
+		DepartmentID:  departmentID,
+		EffectiveFrom: day,
+	}); err != nil {
+		return err
+	}
+
+	ev := toEvent(cur)
+	ev.DepartmentID = departmentID
+	ev.EffectiveDate = key
+	return tx.Outbox().Add(ctx, event.EmployeeTransferred, ev)
+}
+
+// ListAssignments returns the department history of the employee, oldest
+// first. Archived employees keep theirs.
+func (s *service) ListAssignments(ctx context.Context, employeeID string) ([]model.EmployeeDepartmentAssignment, error) {
+	norm := helper.NormalizeStringField(employeeID)
+	if norm == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	if _, err := s.empRepo.GetByEmployeeIDWithArchived(ctx, norm); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, norm)
+		}
+		return nil, err
+	}
+	return s.empRepo.ListAssignments(ctx, norm)
+}
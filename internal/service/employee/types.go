@@ -4,7 +4,7 @@ import "github.com/itsaFan/fleetify-be/internal/model"
 
 type Pagination struct {
 	TotalData   int64 `json:"totalData"`
-	CurrentPage int   `json:"currentPage"`
+	CurrentPage int   `json:"currentPage"` // 0 when paging by cursor
 	TotalPages  int   `json:"totalPages"`
 	HasNextPage bool  `json:"hasNextPage"`
 	HasPrevPage bool  `json:"hasPrevPage"`
@@ -12,8 +12,13 @@ type Pagination struct {
 
 type CreateInput struct {
 	Name       string
+	Email      *string
 	Address    *string
 	Department uint64
+	// YYYY-MM-DD, today in APP_TZ when empty
+	HireDate string
+	// permanent when empty
+	EmploymentType string
 }
 
 type ListInput struct {
@@ -22,14 +27,48 @@ type ListInput struct {
 	Page    int
 	SortBy  string
 	SortDir string
+	// opaque token from a previous page, replaces Page
+	Cursor string
+
+	DepartmentIDs []uint64
+	// YYYY-MM-DD in APP_TZ, both ends inclusive
+	CreatedFrom string
+	CreatedTo   string
+	UpdatedFrom string
+	UpdatedTo   string
+	ClockedIn   *bool
+	Status      string
+	ManagerID   string
+	// "" hides archived employees, "include" or "only"
+	Archived string
 }
 type ListOutput struct {
 	Data       []model.Employee `json:"data"`
 	Pagination Pagination       `json:"pagination"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	PrevCursor string           `json:"prev_cursor,omitempty"`
 }
 
 type UpdateInput struct {
-	Name       string
-	Address    *string
+	Name string
+	// "" clears the email
+	Email   *string
+	Address *string
+	// 0 = no change, otherwise a transfer effective today
+	Department uint64
+	// YYYY-MM-DD, "" = no change
+	HireDate       string
+	EmploymentType *string
+}
+
+type TransferInput struct {
 	Department uint64
+	// YYYY-MM-DD, today in APP_TZ when empty
+	EffectiveDate string
+}
+
+type StatusInput struct {
+	Status string
+	// YYYY-MM-DD, only when terminating
+	TerminationDate string
 }
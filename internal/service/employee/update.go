@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
 	"github.com/itsaFan/fleetify-be/internal/helper"
 	"github.com/itsaFan/fleetify-be/internal/model"
 	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
@@ -29,6 +31,18 @@ func (s *service) UpdateEmployeeByEmployeeID(ctx context.Context, employeeID str
 		in.Address = &a
 	}
 
+	if in.Email != nil {
+		email, err := normalizeEmail(in.Email)
+		if err != nil {
+			return nil, err
+		}
+		cleared := ""
+		if email == nil {
+			email = &cleared
+		}
+		in.Email = email
+	}
+
 	if in.Department != 0 {
 		exists, err := s.deptRepo.ExistsByID(ctx, in.Department)
 		if err != nil {
@@ -39,21 +53,77 @@ func (s *service) UpdateEmployeeByEmployeeID(ctx context.Context, employeeID str
 		}
 	}
 
-	if err := s.empRepo.UpdateByEmployeeID(ctx, employeeID, emprepo.UpdateParams{
-		Name:       &name,
-		Address:    in.Address,
-		Department: in.Department,
+	var hired *time.Time
+	if in.HireDate != "" {
+		d, err := parseDate("hire_date", in.HireDate)
+		if err != nil {
+			return nil, err
+		}
+		hired = &d
+	}
+	if in.EmploymentType != nil {
+		t, err := normalizeEmploymentType(*in.EmploymentType)
+		if err != nil {
+			return nil, err
+		}
+		in.EmploymentType = &t
+	}
+
+	transferred := false
+	if err := s.empRepo.WithTx(ctx, func(tx emprepo.Repository) error {
+		prev, err := tx.GetByEmployeeIDForUpdate(ctx, employeeID)
+		if err != nil {
+			return err
+		}
+		if hired != nil && prev.TerminationDate != nil && helper.DateKey(*hired) > helper.DateKey(*prev.TerminationDate) {
+			return fmt.Errorf("%w: hire_date must not be after the termination date", appErr.ErrInvalidTimeRange)
+		}
+		if err := tx.UpdateByEmployeeID(ctx, employeeID, emprepo.UpdateParams{
+			Name:           &name,
+			Email:          in.Email,
+			Address:        in.Address,
+			HireDate:       hired,
+			EmploymentType: in.EmploymentType,
+		}); err != nil {
+			return err
+		}
+		// a department change is a transfer from today, the days before
+		// stay with the old department
+		if in.Department != 0 && in.Department != prev.DepartmentID {
+			if hired != nil {
+				prev.HireDate = *hired
+			}
+			if err := s.transfer(ctx, tx, prev, in.Department, todayLocal()); err != nil {
+				return err
+			}
+			transferred = true
+		}
+		cur, err := tx.GetByEmployeeIDJoinDept(ctx, employeeID)
+		if err != nil {
+			return err
+		}
+		return tx.Outbox().Add(ctx, event.EmployeeUpdated, toEvent(cur))
 	}); err != nil {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			return nil, appErr.ErrNotFound
 		case isForeignKeyConstraint(err):
 			return nil, appErr.ErrInvalidInput
+		case isDuplicateKey(err) && in.Email != nil:
+			return nil, fmt.Errorf("%w: email %q", appErr.ErrAlreadyExists, *in.Email)
+		case isDuplicateKey(err):
+			return nil, fmt.Errorf("%w: assignments changed concurrently", appErr.ErrConflict)
 		default:
 			return nil, err
 		}
 	}
 
+	if transferred {
+		if err := s.rebuildDays(ctx, employeeID, todayLocal()); err != nil {
+			return nil, err
+		}
+	}
+
 	emp, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, employeeID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
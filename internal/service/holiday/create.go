@@ -0,0 +1,111 @@
+package holiday
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+func (s *service) Create(ctx context.Context, in CreateInput) (*model.Holiday, error) {
+	name := strings.TrimSpace(helper.NormalizeStringField(in.Name))
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", appErr.ErrRequiredField)
+	}
+	if utf8.RuneCountInString(name) > 255 {
+		return nil, fmt.Errorf("%w: name is too long", appErr.ErrInvalidRange)
+	}
+
+	date, err := parseDate(in.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, err := normalizeKind(in.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDepartment(ctx, in.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.Exists(ctx, date, in.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: a holiday on %s", appErr.ErrAlreadyExists, helper.DateKey(date))
+	}
+
+	h := &model.Holiday{
+		HolidayDate:  date,
+		Name:         name,
+		Kind:         kind,
+		DepartmentID: in.DepartmentID,
+		Source:       SourceManual,
+	}
+	if err := s.repo.Create(ctx, h); err != nil {
+		// a concurrent create took the day
+		if isDuplicateKey(err) {
+			return nil, fmt.Errorf("%w: a holiday on %s", appErr.ErrAlreadyExists, helper.DateKey(date))
+		}
+		return nil, err
+	}
+	if err := s.rebuildDays(ctx, h.DepartmentID, h.HolidayDate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, h.ID)
+}
+
+func parseDate(s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, fmt.Errorf("%w: date is required (YYYY-MM-DD)", appErr.ErrRequiredField)
+	}
+	y, m, d, err := helper.ParseYYYYMMDD(strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", appErr.ErrInvalidInput, s)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+func normalizeKind(kind string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", KindPublic:
+		return KindPublic, nil
+	case KindClosure:
+		return KindClosure, nil
+	default:
+		return "", fmt.Errorf("%w: kind must be public or closure", appErr.ErrInvalidInput)
+	}
+}
+
+func isDuplicateKey(err error) bool {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		return me.Number == 1062
+	}
+	return false
+}
+
+func (s *service) checkDepartment(ctx context.Context, id *uint64) error {
+	if id == nil {
+		return nil
+	}
+	exists, err := s.deptRepo.ExistsByID(ctx, *id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: department %d", appErr.ErrNotFound, *id)
+	}
+	return nil
+}
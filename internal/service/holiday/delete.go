@@ -0,0 +1,28 @@
+package holiday
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"gorm.io/gorm"
+)
+
+func (s *service) DeleteByID(ctx context.Context, id uint64) error {
+	if id == 0 {
+		return fmt.Errorf("%w: id is required", appErr.ErrRequiredField)
+	}
+
+	cur, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: holiday %d", appErr.ErrNotFound, id)
+		}
+		return err
+	}
+	return s.rebuildDays(ctx, cur.DepartmentID, cur.HolidayDate)
+}
@@ -0,0 +1,26 @@
+package holiday
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+)
+
+func (s *service) GetByID(ctx context.Context, id uint64) (*model.Holiday, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: id is required", appErr.ErrRequiredField)
+	}
+
+	h, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: holiday %d", appErr.ErrNotFound, id)
+		}
+		return nil, err
+	}
+	return h, nil
+}
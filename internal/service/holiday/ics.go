@@ -0,0 +1,154 @@
+package holiday
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icsEvent is the subset of a VEVENT we care about. End is exclusive, the
+// same way RFC 5545 defines DTEND for all-day events.
+type icsEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// maxEventDays guards against malformed events spanning years.
+const maxEventDays = 31
+
+func parseICS(r io.Reader) ([]icsEvent, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events []icsEvent
+		cur    *icsEvent
+	)
+
+	for i, line := range lines {
+		name, params, value := splitICSLine(line)
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			cur = &icsEvent{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if cur == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN", i+1)
+			}
+			if cur.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", i+1, cur.Summary)
+			}
+			if cur.End.IsZero() || !cur.End.After(cur.Start) {
+				cur.End = cur.Start.AddDate(0, 0, 1)
+			}
+			events = append(events, *cur)
+			cur = nil
+		case cur == nil:
+			// calendar level properties (VERSION, PRODID, X-WR-*) are ignored
+		case name == "UID":
+			cur.UID = value
+		case name == "SUMMARY":
+			cur.Summary = unescapeICSText(value)
+		case name == "DTSTART", name == "DTEND":
+			t, err := parseICSDate(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if name == "DTSTART" {
+				cur.Start = t
+			} else {
+				cur.End = t
+			}
+		}
+	}
+
+	if cur != nil {
+		return nil, fmt.Errorf("unterminated VEVENT %q", cur.Summary)
+	}
+	return events, nil
+}
+
+// unfoldICS joins continuation lines (RFC 5545 3.1, lines starting with a space or tab).
+func unfoldICS(r io.Reader) ([]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func splitICSLine(line string) (name string, params map[string]string, value string) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return strings.ToUpper(line), nil, ""
+	}
+
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	params = map[string]string{}
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return name, params, value
+}
+
+// parseICSDate returns the calendar date of a DTSTART/DTEND value. Holidays are
+// whole days, so date-times are reduced to their date in their own zone.
+func parseICSDate(params map[string]string, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		return t, nil
+	}
+
+	loc := time.UTC
+	if tz := params["TZID"]; tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+
+	var (
+		t   time.Time
+		err error
+	)
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+	} else {
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date-time %q", value)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+func unescapeICSText(s string) string {
+	r := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(r.Replace(s))
+}
@@ -0,0 +1,98 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseICS(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+		want []icsEvent
+	}{
+		{
+			name: "all-day event with exclusive end",
+			ics: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:ny-2025\r\nSUMMARY:Tahun Baru\r\n" +
+				"DTSTART;VALUE=DATE:20250101\r\nDTEND;VALUE=DATE:20250102\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: []icsEvent{{UID: "ny-2025", Summary: "Tahun Baru", Start: day(2025, 1, 1), End: day(2025, 1, 2)}},
+		},
+		{
+			name: "multi-day event",
+			ics:  "BEGIN:VEVENT\nSUMMARY:Idul Fitri\nDTSTART;VALUE=DATE:20250331\nDTEND;VALUE=DATE:20250402\nEND:VEVENT\n",
+			want: []icsEvent{{Summary: "Idul Fitri", Start: day(2025, 3, 31), End: day(2025, 4, 2)}},
+		},
+		{
+			name: "missing or backwards end covers one day",
+			ics: "BEGIN:VEVENT\nSUMMARY:A\nDTSTART:20250817\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nSUMMARY:B\nDTSTART:20250817\nDTEND:20250816\nEND:VEVENT\n",
+			want: []icsEvent{
+				{Summary: "A", Start: day(2025, 8, 17), End: day(2025, 8, 18)},
+				{Summary: "B", Start: day(2025, 8, 17), End: day(2025, 8, 18)},
+			},
+		},
+		{
+			name: "folded and escaped summary",
+			ics:  "BEGIN:VEVENT\nSUMMARY:Cuti Bersama\\, Hari\n  Raya\\; Natal\nDTSTART:20251226\nEND:VEVENT\n",
+			want: []icsEvent{{Summary: "Cuti Bersama, Hari Raya; Natal", Start: day(2025, 12, 26), End: day(2025, 12, 27)}},
+		},
+		{
+			name: "date-times keep the date of their own zone",
+			ics: "BEGIN:VEVENT\nSUMMARY:Local\nDTSTART;TZID=Asia/Jakarta:20250501T233000\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nSUMMARY:UTC\nDTSTART:20250501T233000Z\nEND:VEVENT\n",
+			want: []icsEvent{
+				{Summary: "Local", Start: day(2025, 5, 1), End: day(2025, 5, 2)},
+				{Summary: "UTC", Start: day(2025, 5, 1), End: day(2025, 5, 2)},
+			},
+		},
+		{
+			name: "calendar without events",
+			ics:  "BEGIN:VCALENDAR\nVERSION:2.0\nPRODID:-//test//EN\nEND:VCALENDAR\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseICS(strings.NewReader(tt.ics))
+			if err != nil {
+				t.Fatalf("parseICS() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseICS() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				g, w := got[i], tt.want[i]
+				if g.UID != w.UID || g.Summary != w.Summary || !g.Start.Equal(w.Start) || !g.End.Equal(w.End) {
+					t.Errorf("event %d = %+v, want %+v", i, g, w)
+				}
+			}
+		})
+	}
+}
+
+func TestParseICSErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+		want string
+	}{
+		{"no start", "BEGIN:VEVENT\nSUMMARY:X\nEND:VEVENT\n", "has no DTSTART"},
+		{"end without begin", "END:VEVENT\n", "without BEGIN"},
+		{"unterminated", "BEGIN:VEVENT\nSUMMARY:X\nDTSTART:20250101\n", "unterminated"},
+		{"bad date", "BEGIN:VEVENT\nDTSTART:2025011\nEND:VEVENT\n", "invalid date-time"},
+		{"bad all-day date", "BEGIN:VEVENT\nDTSTART;VALUE=DATE:20251301\nEND:VEVENT\n", "invalid date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseICS(strings.NewReader(tt.ics))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseICS() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package holiday
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+)
+
+// ImportICS creates one holiday per day covered by each VEVENT, all in one
+// transaction. Days that already have a holiday in the same scope are
+// skipped, so the same yearly calendar can be imported again safely.
+func (s *service) ImportICS(ctx context.Context, r io.Reader, in ImportInput) (*ImportOutput, error) {
+	kind, err := normalizeKind(in.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDepartment(ctx, in.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	events, err := parseICS(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid iCalendar file: %v", appErr.ErrInvalidInput, err)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: iCalendar file has no events", appErr.ErrInvalidInput)
+	}
+
+	var out *ImportOutput
+	var created []time.Time
+	if err := s.repo.WithTx(ctx, func(tx holidayrepo.Repository) error {
+		out, created = &ImportOutput{}, nil
+		return importEvents(ctx, tx, events, kind, in.DepartmentID, out, &created)
+	}); err != nil {
+		// a concurrent create took one of the days
+		if isDuplicateKey(err) {
+			return nil, fmt.Errorf("%w: holidays changed during the import, try again", appErr.ErrConflict)
+		}
+		return nil, err
+	}
+
+	if err := s.rebuildDays(ctx, in.DepartmentID, created...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// importEvents stores the days of events through tx, counting them in out and
+// collecting the created ones.
+func importEvents(ctx context.Context, tx holidayrepo.Repository, events []icsEvent, kind string, departmentID *uint64, out *ImportOutput, created *[]time.Time) error {
+	for _, ev := range events {
+		name := strings.TrimSpace(helper.NormalizeStringField(ev.Summary))
+		if name == "" {
+			out.Skipped++
+			out.Errors = append(out.Errors, fmt.Sprintf("event %q on %s has no summary", ev.UID, helper.DateKey(ev.Start)))
+			continue
+		}
+		// the column limit counts characters, cut on a rune boundary
+		if utf8.RuneCountInString(name) > 255 {
+			name = string([]rune(name)[:255])
+		}
+
+		var uid *string
+		if ev.UID != "" {
+			u := ev.UID
+			uid = &u
+		}
+
+		for i, d := 0, ev.Start; d.Before(ev.End); i, d = i+1, d.AddDate(0, 0, 1) {
+			if i >= maxEventDays {
+				out.Errors = append(out.Errors, fmt.Sprintf("event %q truncated to %d days", name, maxEventDays))
+				break
+			}
+
+			exists, err := tx.Exists(ctx, d, departmentID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				out.Skipped++
+				continue
+			}
+
+			if err := tx.Create(ctx, &model.Holiday{
+				HolidayDate:  d,
+				Name:         name,
+				Kind:         kind,
+				DepartmentID: departmentID,
+				Source:       SourceICS,
+				ExternalUID:  uid,
+			}); err != nil {
+				return err
+			}
+			out.Created++
+			*created = append(*created, d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package holiday
+
+import (
+	"context"
+	"strings"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+)
+
+func (in *ListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	in.Kind = strings.ToLower(strings.TrimSpace(in.Kind))
+}
+
+func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
+	in.normalize()
+
+	params := holidayrepo.ListParams{
+		DepartmentID: in.DepartmentID,
+		Kind:         in.Kind,
+		Limit:        in.Limit,
+		Page:         in.Page,
+	}
+
+	if in.From != "" {
+		from, err := parseDate(in.From)
+		if err != nil {
+			return nil, err
+		}
+		params.From = &from
+	}
+	if in.To != "" {
+		to, err := parseDate(in.To)
+		if err != nil {
+			return nil, err
+		}
+		params.To = &to
+	}
+
+	if in.Kind != "" {
+		if _, err := normalizeKind(in.Kind); err != nil {
+			return nil, err
+		}
+	}
+
+	items, total, err := s.repo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
@@ -0,0 +1,59 @@
+package holiday
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+type service struct {
+	repo     holidayrepo.Repository
+	deptRepo deptrepo.Repository
+	atdSvc   atdsvc.Service
+}
+
+type Service interface {
+	Create(ctx context.Context, in CreateInput) (*model.Holiday, error)
+	List(ctx context.Context, in ListInput) (*ListOutput, error)
+	GetByID(ctx context.Context, id uint64) (*model.Holiday, error)
+	UpdateByID(ctx context.Context, id uint64, in UpdateInput) (*model.Holiday, error)
+	DeleteByID(ctx context.Context, id uint64) error
+	ImportICS(ctx context.Context, r io.Reader, in ImportInput) (*ImportOutput, error)
+}
+
+func New(repo holidayrepo.Repository, deptRepo deptrepo.Repository, atdSvc atdsvc.Service) Service {
+	return &service{repo: repo, deptRepo: deptRepo, atdSvc: atdSvc}
+}
+
+// rebuildDays recomputes the stored attendance days between the earliest and
+// latest of dates for the holiday scope, nil being every department. Punches
+// on those days are flagged as holiday work or no longer.
+func (s *service) rebuildDays(ctx context.Context, departmentID *uint64, dates ...time.Time) error {
+	if len(dates) == 0 {
+		return nil
+	}
+	from, to := dates[0], dates[0]
+	for _, d := range dates[1:] {
+		if d.Before(from) {
+			from = d
+		}
+		if d.After(to) {
+			to = d
+		}
+	}
+	if _, err := s.atdSvc.RebuildDaily(ctx, atdsvc.RebuildDailyInput{
+		FromLocal:    helper.DateKey(from),
+		ToLocal:      helper.DateKey(to),
+		DepartmentID: departmentID,
+	}); err != nil {
+		return fmt.Errorf("holidays saved, rebuilding attendance days: %w", err)
+	}
+	return nil
+}
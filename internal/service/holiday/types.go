@@ -0,0 +1,56 @@
+package holiday
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+const (
+	KindPublic  = "public"
+	KindClosure = "closure"
+
+	SourceManual = "manual"
+	SourceICS    = "ics"
+)
+
+type CreateInput struct {
+	// "YYYY-MM-DD"
+	Date         string
+	Name         string
+	Kind         string
+	DepartmentID *uint64
+}
+
+type ListInput struct {
+	From         string
+	To           string
+	DepartmentID *uint64
+	Kind         string
+	Limit        int
+	Page         int
+}
+
+type ListOutput struct {
+	Data       []model.Holiday   `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
+
+type UpdateInput struct {
+	Date            *string
+	Name            *string
+	Kind            *string
+	DepartmentID    *uint64
+	ClearDepartment bool
+}
+
+type ImportInput struct {
+	// applied to every imported event, defaults to public
+	Kind         string
+	DepartmentID *uint64
+}
+
+type ImportOutput struct {
+	Created int      `json:"created"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,116 @@
+package holiday
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	"gorm.io/gorm"
+)
+
+func (in UpdateInput) isEmpty() bool {
+	return in.Date == nil && in.Name == nil && in.Kind == nil && in.DepartmentID == nil && !in.ClearDepartment
+}
+
+func (s *service) UpdateByID(ctx context.Context, id uint64, in UpdateInput) (*model.Holiday, error) {
+	cur, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.isEmpty() {
+		return cur, nil
+	}
+
+	up := holidayrepo.UpdateParams{ClearDepartment: in.ClearDepartment}
+
+	finalDate := cur.HolidayDate
+	if in.Date != nil {
+		d, err := parseDate(*in.Date)
+		if err != nil {
+			return nil, err
+		}
+		finalDate = d
+		up.HolidayDate = &d
+	}
+
+	if in.Name != nil {
+		nm := strings.TrimSpace(helper.NormalizeStringField(*in.Name))
+		if nm == "" {
+			return nil, fmt.Errorf("%w: name is required", appErr.ErrRequiredField)
+		}
+		if utf8.RuneCountInString(nm) > 255 {
+			return nil, fmt.Errorf("%w: name is too long", appErr.ErrInvalidRange)
+		}
+		up.Name = &nm
+	}
+
+	if in.Kind != nil {
+		k, err := normalizeKind(*in.Kind)
+		if err != nil {
+			return nil, err
+		}
+		up.Kind = &k
+	}
+
+	finalDept := cur.DepartmentID
+	if in.ClearDepartment {
+		finalDept = nil
+	} else if in.DepartmentID != nil {
+		if err := s.checkDepartment(ctx, in.DepartmentID); err != nil {
+			return nil, err
+		}
+		finalDept = in.DepartmentID
+		up.DepartmentID = in.DepartmentID
+	}
+
+	moved := !sameSlot(cur, finalDate, finalDept)
+	if moved {
+		exists, err := s.repo.Exists(ctx, finalDate, finalDept)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: a holiday on %s", appErr.ErrAlreadyExists, helper.DateKey(finalDate))
+		}
+	}
+
+	if err := s.repo.UpdateByID(ctx, id, up); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, fmt.Errorf("%w: holiday %d", appErr.ErrNotFound, id)
+		case isDuplicateKey(err):
+			return nil, fmt.Errorf("%w: a holiday on %s", appErr.ErrAlreadyExists, helper.DateKey(finalDate))
+		}
+		return nil, err
+	}
+
+	// the old day loses the holiday, the new one gains it
+	if err := s.rebuildDays(ctx, cur.DepartmentID, cur.HolidayDate); err != nil {
+		return nil, err
+	}
+	if moved {
+		if err := s.rebuildDays(ctx, finalDept, finalDate); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func sameSlot(cur *model.Holiday, date time.Time, dept *uint64) bool {
+	if helper.DateKey(cur.HolidayDate) != helper.DateKey(date) {
+		return false
+	}
+	if cur.DepartmentID == nil || dept == nil {
+		return cur.DepartmentID == nil && dept == nil
+	}
+	return *cur.DepartmentID == *dept
+}
@@ -0,0 +1,155 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	jobrepo "github.com/itsaFan/fleetify-be/internal/repo/job"
+	"github.com/itsaFan/fleetify-be/internal/scheduler"
+	"gorm.io/gorm"
+)
+
+type service struct {
+	repo  jobrepo.Repository
+	sched *scheduler.Scheduler
+}
+
+type Service interface {
+	List(ctx context.Context) ([]model.ScheduledJob, error)
+	Get(ctx context.Context, name string) (*model.ScheduledJob, error)
+	Pause(ctx context.Context, name string) (*model.ScheduledJob, error)
+	// Resume picks the schedule up from now, runs missed while paused are skipped
+	Resume(ctx context.Context, name string) (*model.ScheduledJob, error)
+	// Trigger asks for a run on the next scheduler tick, paused or not
+	Trigger(ctx context.Context, name string) (*model.ScheduledJob, error)
+	ListRuns(ctx context.Context, in RunListInput) (*RunListOutput, error)
+}
+
+func New(repo jobrepo.Repository, sched *scheduler.Scheduler) Service {
+	return &service{repo: repo, sched: sched}
+}
+
+type RunListInput struct {
+	JobName string
+	Status  string
+	Limit   int
+	Page    int
+}
+
+type RunListOutput struct {
+	Data       []model.JobRun    `json:"data"`
+	Pagination helper.Pagination `json:"pagination"`
+}
+
+func (in *RunListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	in.Status = strings.ToLower(strings.TrimSpace(in.Status))
+}
+
+func (s *service) List(ctx context.Context) ([]model.ScheduledJob, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *service) Get(ctx context.Context, name string) (*model.ScheduledJob, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: job name is required", appErr.ErrRequiredField)
+	}
+
+	out, err := s.repo.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: job %q", appErr.ErrNotFound, name)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *service) Pause(ctx context.Context, name string) (*model.ScheduledJob, error) {
+	j, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetPaused(ctx, j.Name, true, nil); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, j.Name)
+}
+
+func (s *service) Resume(ctx context.Context, name string) (*model.ScheduledJob, error) {
+	j, err := s.registered(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	next, _ := s.sched.NextRun(j.Name, time.Now().UTC())
+	if err := s.repo.SetPaused(ctx, j.Name, false, next); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, j.Name)
+}
+
+func (s *service) Trigger(ctx context.Context, name string) (*model.ScheduledJob, error) {
+	j, err := s.registered(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RequestTrigger(ctx, j.Name); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, j.Name)
+}
+
+// registered guards actions that need this build to know how to run the job,
+// rows of jobs removed from the code stay listed until deleted by hand.
+func (s *service) registered(ctx context.Context, name string) (*model.ScheduledJob, error) {
+	j, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !s.sched.Known(j.Name) {
+		return nil, fmt.Errorf("%w: job %q is no longer registered", appErr.ErrConflict, j.Name)
+	}
+	return j, nil
+}
+
+func (s *service) ListRuns(ctx context.Context, in RunListInput) (*RunListOutput, error) {
+	in.normalize()
+
+	switch in.Status {
+	case "", jobrepo.RunRunning, jobrepo.RunSucceeded, jobrepo.RunFailed, jobrepo.RunAbandoned:
+	default:
+		return nil, fmt.Errorf("%w: status must be running, succeeded, failed or abandoned", appErr.ErrInvalidInput)
+	}
+	if in.JobName != "" {
+		if _, err := s.Get(ctx, in.JobName); err != nil {
+			return nil, err
+		}
+	}
+
+	items, total, err := s.repo.ListRuns(ctx, jobrepo.RunListParams{
+		JobName: in.JobName,
+		Status:  in.Status,
+		Limit:   in.Limit,
+		Page:    in.Page,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
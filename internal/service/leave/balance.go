@@ -0,0 +1,61 @@
+package leave
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+)
+
+func (s *service) Balance(ctx context.Context, employeeID string, year int) (*BalanceOutput, error) {
+	empId := helper.NormalizeStringField(strings.TrimSpace(employeeID))
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	if year == 0 {
+		year = time.Now().UTC().Year()
+	}
+	if year < 2000 || year > 2100 {
+		return nil, fmt.Errorf("%w: year out of range", appErr.ErrInvalidRange)
+	}
+
+	if _, err := s.getEmployee(ctx, empId); err != nil {
+		return nil, err
+	}
+
+	types, err := s.repo.ListTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &BalanceOutput{EmployeeID: empId, Year: year, Items: make([]BalanceItem, 0, len(types))}
+	for _, lt := range types {
+		used, err := s.repo.SumDays(ctx, empId, lt.ID, year, leaverepo.StatusApproved)
+		if err != nil {
+			return nil, err
+		}
+		pending, err := s.repo.SumDays(ctx, empId, lt.ID, year, leaverepo.StatusPending)
+		if err != nil {
+			return nil, err
+		}
+
+		item := BalanceItem{
+			LeaveTypeID: lt.ID,
+			Code:        lt.Code,
+			Name:        lt.Name,
+			YearlyQuota: lt.YearlyQuota,
+			Used:        used,
+			Pending:     pending,
+		}
+		if lt.YearlyQuota > 0 {
+			rem := lt.YearlyQuota - used
+			item.Remaining = &rem
+		}
+		out.Items = append(out.Items, item)
+	}
+	return out, nil
+}
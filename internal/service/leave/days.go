@@ -0,0 +1,56 @@
+package leave
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+// countWorkingDays counts the days in [from, to] the employee is scheduled to
+// work: working days of the department of each day minus company holidays and
+// closures of that department.
+func (s *service) countWorkingDays(ctx context.Context, emp *model.Employee, from, to time.Time) (int, error) {
+	stints, err := s.empRepo.ListAssignments(ctx, emp.EmployeeID)
+	if err != nil {
+		return 0, err
+	}
+
+	holidays, err := s.holidayRepo.ListInRange(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	global := map[string]bool{}
+	closed := map[uint64]map[string]bool{}
+	for _, h := range holidays {
+		key := helper.DateKey(h.HolidayDate)
+		if h.DepartmentID == nil {
+			global[key] = true
+			continue
+		}
+		if closed[*h.DepartmentID] == nil {
+			closed[*h.DepartmentID] = map[string]bool{}
+		}
+		closed[*h.DepartmentID][key] = true
+	}
+
+	workDays := map[uint64][7]bool{}
+	n := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dept := model.DepartmentOn(stints, emp.Department, d)
+		wd, ok := workDays[dept.ID]
+		if !ok {
+			if wd, err = helper.ParseWorkingDays(dept.WorkingDays); err != nil {
+				return 0, fmt.Errorf("department working days: %w", err)
+			}
+			workDays[dept.ID] = wd
+		}
+		key := helper.DateKey(d)
+		if wd[d.Weekday()] && !global[key] && !closed[dept.ID][key] {
+			n++
+		}
+	}
+	return n, nil
+}
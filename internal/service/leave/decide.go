@@ -0,0 +1,107 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	"gorm.io/gorm"
+)
+
+func (s *service) Approve(ctx context.Context, requestID string, in DecisionInput) (*model.LeaveRequest, error) {
+	return s.decide(ctx, requestID, leaverepo.StatusApproved, in)
+}
+
+func (s *service) Reject(ctx context.Context, requestID string, in DecisionInput) (*model.LeaveRequest, error) {
+	return s.decide(ctx, requestID, leaverepo.StatusRejected, in)
+}
+
+func (s *service) decide(ctx context.Context, requestID, status string, in DecisionInput) (*model.LeaveRequest, error) {
+	reqId := helper.NormalizeStringField(strings.TrimSpace(requestID))
+	if reqId == "" {
+		return nil, fmt.Errorf("%w: request_id is required", appErr.ErrRequiredField)
+	}
+	approverId := helper.NormalizeStringField(strings.TrimSpace(in.ApproverID))
+	if approverId == "" {
+		return nil, fmt.Errorf("%w: approver_id is required", appErr.ErrRequiredField)
+	}
+	if _, err := s.getEmployee(ctx, approverId); err != nil {
+		return nil, err
+	}
+
+	req, err := s.repo.GetRequestByRequestID(ctx, reqId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: leave request %q", appErr.ErrNotFound, reqId)
+		}
+		return nil, err
+	}
+
+	if err := s.repo.WithTx(ctx, func(tx leaverepo.Repository) error {
+		// employee first, like Submit, so the quota sum below cannot race
+		// another approval or submit of the same employee
+		if err := tx.LockEmployee(ctx, req.EmployeeID); err != nil {
+			return err
+		}
+		cur, err := tx.GetRequestForUpdate(ctx, reqId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: leave request %q", appErr.ErrNotFound, reqId)
+			}
+			return err
+		}
+		if cur.Status != leaverepo.StatusPending {
+			return fmt.Errorf("%w: leave request %q is already %s", appErr.ErrConflict, reqId, cur.Status)
+		}
+		if cur.EmployeeID == approverId {
+			return fmt.Errorf("%w: employees cannot decide their own leave", appErr.ErrInvalidInput)
+		}
+		// the manager of the department the leave starts in decides it
+		dept, err := s.empRepo.DepartmentOn(ctx, cur.EmployeeID, cur.StartDate)
+		if err != nil {
+			return err
+		}
+		if dept.ManagerEmployeeID == nil || *dept.ManagerEmployeeID != approverId {
+			return fmt.Errorf("%w: only the manager of department %q can decide this leave", appErr.ErrForbidden, dept.DepartmentName)
+		}
+
+		if status == leaverepo.StatusApproved {
+			lt, err := tx.GetTypeByID(ctx, cur.LeaveTypeID)
+			if err != nil {
+				return err
+			}
+			if lt.YearlyQuota > 0 {
+				used, err := tx.SumDays(ctx, cur.EmployeeID, lt.ID, cur.StartDate.Year(), leaverepo.StatusApproved)
+				if err != nil {
+					return err
+				}
+				if used+cur.Days > lt.YearlyQuota {
+					return fmt.Errorf("%w: %s balance is %d day(s), request needs %d", appErr.ErrConflict, lt.Code, lt.YearlyQuota-used, cur.Days)
+				}
+			}
+		}
+
+		var note *string
+		if in.Note != nil {
+			n := strings.TrimSpace(*in.Note)
+			note = &n
+		}
+
+		return tx.UpdateDecision(ctx, reqId, leaverepo.DecisionParams{
+			Status:     status,
+			ApproverID: approverId,
+			Note:       note,
+			DecidedAt:  time.Now().UTC(),
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRequestByRequestID(ctx, reqId)
+}
@@ -0,0 +1,140 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	"gorm.io/gorm"
+)
+
+func (s *service) CreateType(ctx context.Context, in CreateTypeInput) (*model.LeaveType, error) {
+	code := strings.ToLower(strings.TrimSpace(in.Code))
+	name := strings.TrimSpace(helper.NormalizeStringField(in.Name))
+	if code == "" {
+		return nil, fmt.Errorf("%w: code is required", appErr.ErrRequiredField)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", appErr.ErrRequiredField)
+	}
+	if in.YearlyQuota < 0 || in.YearlyQuota > 366 {
+		return nil, fmt.Errorf("%w: yearly_quota must be between 0 and 366", appErr.ErrInvalidRange)
+	}
+
+	exists, err := s.repo.ExistsTypeByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: leave type %q", appErr.ErrAlreadyExists, code)
+	}
+
+	lt := &model.LeaveType{Code: code, Name: name, YearlyQuota: in.YearlyQuota}
+	if err := s.repo.CreateType(ctx, lt); err != nil {
+		if isDuplicateKey(err) {
+			return nil, fmt.Errorf("%w: leave type %q", appErr.ErrAlreadyExists, code)
+		}
+		return nil, err
+	}
+	return lt, nil
+}
+
+func (s *service) ListTypes(ctx context.Context) ([]model.LeaveType, error) {
+	return s.repo.ListTypes(ctx)
+}
+
+func (s *service) UpdateTypeByID(ctx context.Context, id uint64, in UpdateTypeInput) (*model.LeaveType, error) {
+	cur, err := s.getType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	up := leaverepo.UpdateTypeParams{}
+	if in.Code != nil {
+		code := strings.ToLower(strings.TrimSpace(*in.Code))
+		if code == "" {
+			return nil, fmt.Errorf("%w: code is required", appErr.ErrRequiredField)
+		}
+		if code != cur.Code {
+			exists, err := s.repo.ExistsTypeByCode(ctx, code)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: leave type %q", appErr.ErrAlreadyExists, code)
+			}
+			up.Code = &code
+		}
+	}
+	if in.Name != nil {
+		name := strings.TrimSpace(helper.NormalizeStringField(*in.Name))
+		if name == "" {
+			return nil, fmt.Errorf("%w: name is required", appErr.ErrRequiredField)
+		}
+		up.Name = &name
+	}
+	if in.YearlyQuota != nil {
+		if *in.YearlyQuota < 0 || *in.YearlyQuota > 366 {
+			return nil, fmt.Errorf("%w: yearly_quota must be between 0 and 366", appErr.ErrInvalidRange)
+		}
+		up.YearlyQuota = in.YearlyQuota
+	}
+
+	if err := s.repo.UpdateTypeByID(ctx, id, up); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: leave type %d", appErr.ErrNotFound, id)
+		}
+		return nil, err
+	}
+	return s.repo.GetTypeByID(ctx, id)
+}
+
+func (s *service) DeleteTypeByID(ctx context.Context, id uint64) error {
+	if err := s.repo.DeleteTypeByID(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return fmt.Errorf("%w: leave type %d", appErr.ErrNotFound, id)
+		case isForeignKeyConstraint(err):
+			return fmt.Errorf("%w: leave type %d is used by leave requests", appErr.ErrConflict, id)
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) getType(ctx context.Context, id uint64) (*model.LeaveType, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: leave_type_id is required", appErr.ErrRequiredField)
+	}
+	lt, err := s.repo.GetTypeByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: leave type %d", appErr.ErrNotFound, id)
+		}
+		return nil, err
+	}
+	return lt, nil
+}
+
+func isDuplicateKey(err error) bool {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		return me.Number == 1062
+	}
+	return false
+}
+
+func isForeignKeyConstraint(err error) bool {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		return me.Number == 1452 || me.Number == 1451
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	"gorm.io/gorm"
+)
+
+func (in *ListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	in.Status = strings.ToLower(strings.TrimSpace(in.Status))
+}
+
+func (s *service) ListRequests(ctx context.Context, in ListInput) (*ListOutput, error) {
+	in.normalize()
+
+	params := leaverepo.ListRequestParams{
+		EmployeeID:   helper.NormalizeStringField(in.EmployeeID),
+		DepartmentID: in.DepartmentID,
+		Status:       in.Status,
+		Limit:        in.Limit,
+		Page:         in.Page,
+	}
+	if in.From != "" {
+		from, err := parseDate("from", in.From)
+		if err != nil {
+			return nil, err
+		}
+		params.From = &from
+	}
+	if in.To != "" {
+		to, err := parseDate("to", in.To)
+		if err != nil {
+			return nil, err
+		}
+		params.To = &to
+	}
+
+	items, total, err := s.repo.ListRequests(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
+
+func (s *service) GetByRequestID(ctx context.Context, requestID string) (*model.LeaveRequest, error) {
+	reqId := helper.NormalizeStringField(strings.TrimSpace(requestID))
+	if reqId == "" {
+		return nil, fmt.Errorf("%w: request_id is required", appErr.ErrRequiredField)
+	}
+
+	out, err := s.repo.GetRequestByRequestID(ctx, reqId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: leave request %q", appErr.ErrNotFound, reqId)
+		}
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package leave
+
+import (
+	"context"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+)
+
+type service struct {
+	repo        leaverepo.Repository
+	empRepo     emprepo.Repository
+	holidayRepo holidayrepo.Repository
+}
+
+type Service interface {
+	CreateType(ctx context.Context, in CreateTypeInput) (*model.LeaveType, error)
+	ListTypes(ctx context.Context) ([]model.LeaveType, error)
+	UpdateTypeByID(ctx context.Context, id uint64, in UpdateTypeInput) (*model.LeaveType, error)
+	DeleteTypeByID(ctx context.Context, id uint64) error
+
+	Submit(ctx context.Context, in SubmitInput) (*model.LeaveRequest, error)
+	ListRequests(ctx context.Context, in ListInput) (*ListOutput, error)
+	GetByRequestID(ctx context.Context, requestID string) (*model.LeaveRequest, error)
+	Approve(ctx context.Context, requestID string, in DecisionInput) (*model.LeaveRequest, error)
+	Reject(ctx context.Context, requestID string, in DecisionInput) (*model.LeaveRequest, error)
+
+	Balance(ctx context.Context, employeeID string, year int) (*BalanceOutput, error)
+}
+
+func New(repo leaverepo.Repository, empRepo emprepo.Repository, holidayRepo holidayrepo.Repository) Service {
+	return &service{repo: repo, empRepo: empRepo, holidayRepo: holidayRepo}
+}
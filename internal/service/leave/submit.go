@@ -0,0 +1,124 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	"gorm.io/gorm"
+)
+
+func (s *service) Submit(ctx context.Context, in SubmitInput) (*model.LeaveRequest, error) {
+	empId := helper.NormalizeStringField(strings.TrimSpace(in.EmployeeID))
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+
+	emp, err := s.getEmployee(ctx, empId)
+	if err != nil {
+		return nil, err
+	}
+
+	lt, err := s.getType(ctx, in.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := parseDate("start_date", in.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate("end_date", in.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: end_date must not be before start_date", appErr.ErrInvalidTimeRange)
+	}
+	// quotas are yearly, a request must stay inside one year
+	if from.Year() != to.Year() {
+		return nil, fmt.Errorf("%w: split leave crossing the new year into two requests", appErr.ErrInvalidTimeRange)
+	}
+
+	days, err := s.countWorkingDays(ctx, emp, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if days == 0 {
+		return nil, fmt.Errorf("%w: no working days between %s and %s", appErr.ErrInvalidRange, in.StartDate, in.EndDate)
+	}
+
+	req := &model.LeaveRequest{
+		RequestID:   uuid.New().String(),
+		EmployeeID:  empId,
+		LeaveTypeID: lt.ID,
+		StartDate:   from,
+		EndDate:     to,
+		Days:        days,
+		Reason:      strings.TrimSpace(in.Reason),
+		Status:      leaverepo.StatusPending,
+	}
+	// the employee lock keeps concurrent submits and approvals from both
+	// passing the checks below
+	if err := s.repo.WithTx(ctx, func(tx leaverepo.Repository) error {
+		if err := tx.LockEmployee(ctx, empId); err != nil {
+			return err
+		}
+
+		overlap, err := tx.HasOverlap(ctx, empId, from, to)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return fmt.Errorf("%w: employee %q already has leave between %s and %s", appErr.ErrConflict, empId, in.StartDate, in.EndDate)
+		}
+
+		if lt.YearlyQuota > 0 {
+			taken, err := tx.SumDays(ctx, empId, lt.ID, from.Year(), leaverepo.StatusApproved, leaverepo.StatusPending)
+			if err != nil {
+				return err
+			}
+			if taken+days > lt.YearlyQuota {
+				return fmt.Errorf("%w: %s balance is %d day(s), requested %d", appErr.ErrConflict, lt.Code, lt.YearlyQuota-taken, days)
+			}
+		}
+
+		return tx.CreateRequest(ctx, req)
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, empId)
+		}
+		return nil, err
+	}
+
+	return s.repo.GetRequestByRequestID(ctx, req.RequestID)
+}
+
+func (s *service) getEmployee(ctx context.Context, employeeID string) (*model.Employee, error) {
+	emp, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, employeeID)
+		}
+		return nil, err
+	}
+	return emp, nil
+}
+
+func parseDate(field, s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, fmt.Errorf("%w: %s is required (YYYY-MM-DD)", appErr.ErrRequiredField, field)
+	}
+	y, m, d, err := helper.ParseYYYYMMDD(strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s %q", appErr.ErrInvalidInput, field, s)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
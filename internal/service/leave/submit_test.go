@@ -0,0 +1,311 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	leaverepo "github.com/itsaFan/fleetify-be/internal/repo/leave"
+	"gorm.io/gorm"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// fakeLeaveRepo keeps requests in memory with the same overlap and sum rules
+// as the SQL.
+type fakeLeaveRepo struct {
+	leaverepo.Repository
+	types    map[uint64]model.LeaveType
+	requests []model.LeaveRequest
+}
+
+func (f *fakeLeaveRepo) WithTx(ctx context.Context, fn func(txRepo leaverepo.Repository) error) error {
+	return fn(f)
+}
+
+func (f *fakeLeaveRepo) LockEmployee(ctx context.Context, employeeID string) error { return nil }
+
+func (f *fakeLeaveRepo) GetTypeByID(ctx context.Context, id uint64) (*model.LeaveType, error) {
+	lt, ok := f.types[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &lt, nil
+}
+
+func (f *fakeLeaveRepo) HasOverlap(ctx context.Context, employeeID string, from, to time.Time) (bool, error) {
+	for _, r := range f.requests {
+		if r.EmployeeID == employeeID && r.Status != leaverepo.StatusRejected &&
+			!r.StartDate.After(to) && !r.EndDate.Before(from) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeLeaveRepo) SumDays(ctx context.Context, employeeID string, leaveTypeID uint64, year int, statuses ...string) (int, error) {
+	sum := 0
+	for _, r := range f.requests {
+		if r.EmployeeID != employeeID || r.LeaveTypeID != leaveTypeID || r.StartDate.Year() != year {
+			continue
+		}
+		for _, st := range statuses {
+			if r.Status == st {
+				sum += r.Days
+			}
+		}
+	}
+	return sum, nil
+}
+
+func (f *fakeLeaveRepo) CreateRequest(ctx context.Context, d *model.LeaveRequest) error {
+	f.requests = append(f.requests, *d)
+	return nil
+}
+
+func (f *fakeLeaveRepo) GetRequestByRequestID(ctx context.Context, requestID string) (*model.LeaveRequest, error) {
+	for _, r := range f.requests {
+		if r.RequestID == requestID {
+			return &r, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeLeaveRepo) GetRequestForUpdate(ctx context.Context, requestID string) (*model.LeaveRequest, error) {
+	return f.GetRequestByRequestID(ctx, requestID)
+}
+
+func (f *fakeLeaveRepo) UpdateDecision(ctx context.Context, requestID string, p leaverepo.DecisionParams) error {
+	for i := range f.requests {
+		if f.requests[i].RequestID == requestID {
+			f.requests[i].Status = p.Status
+			f.requests[i].ApproverID = &p.ApproverID
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+type fakeEmpRepo struct {
+	emprepo.Repository
+	emps   map[string]model.Employee
+	stints []model.EmployeeDepartmentAssignment
+}
+
+func (f *fakeEmpRepo) GetByEmployeeIDJoinDept(ctx context.Context, employeeID string) (*model.Employee, error) {
+	e, ok := f.emps[employeeID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &e, nil
+}
+
+func (f *fakeEmpRepo) ListAssignments(ctx context.Context, employeeIDs ...string) ([]model.EmployeeDepartmentAssignment, error) {
+	var out []model.EmployeeDepartmentAssignment
+	for _, a := range f.stints {
+		for _, id := range employeeIDs {
+			if a.EmployeeID == id {
+				out = append(out, a)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeEmpRepo) DepartmentOn(ctx context.Context, employeeID string, day time.Time) (*model.Department, error) {
+	e, err := f.GetByEmployeeIDJoinDept(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	stints, _ := f.ListAssignments(ctx, employeeID)
+	d := model.DepartmentOn(stints, e.Department, day)
+	return &d, nil
+}
+
+type fakeHolidayRepo struct {
+	holidayrepo.Repository
+	items []model.Holiday
+}
+
+func (f *fakeHolidayRepo) ListInRange(ctx context.Context, from, to time.Time) ([]model.Holiday, error) {
+	var out []model.Holiday
+	for _, h := range f.items {
+		if !h.HolidayDate.Before(from) && !h.HolidayDate.After(to) {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+const (
+	annual uint64 = 1
+	sick   uint64 = 2
+)
+
+// newTestService has E1 in Office (Mon-Fri) until they move to Warehouse
+// (Mon-Sat) on Monday 2025-06-16, with 9 annual days taken or pending.
+func newTestService() (*service, *fakeLeaveRepo) {
+	mgr1, mgr2 := "M1", "M2"
+	office := model.Department{ID: 1, DepartmentName: "Office", WorkingDays: "1,2,3,4,5", ManagerEmployeeID: &mgr1}
+	warehouse := model.Department{ID: 2, DepartmentName: "Warehouse", WorkingDays: "1,2,3,4,5,6", ManagerEmployeeID: &mgr2}
+	to := date("2025-06-15")
+	officeID := office.ID
+
+	repo := &fakeLeaveRepo{
+		types: map[uint64]model.LeaveType{
+			annual: {ID: annual, Code: "ANNUAL", YearlyQuota: 12},
+			sick:   {ID: sick, Code: "SICK"},
+		},
+		requests: []model.LeaveRequest{
+			{RequestID: "r-approved", EmployeeID: "E1", LeaveTypeID: annual, StartDate: date("2025-03-03"), EndDate: date("2025-03-07"), Days: 5, Status: leaverepo.StatusApproved},
+			{RequestID: "r-pending", EmployeeID: "E1", LeaveTypeID: annual, StartDate: date("2025-04-01"), EndDate: date("2025-04-04"), Days: 4, Status: leaverepo.StatusPending},
+			{RequestID: "r-rejected", EmployeeID: "E1", LeaveTypeID: annual, StartDate: date("2025-05-05"), EndDate: date("2025-05-09"), Days: 5, Status: leaverepo.StatusRejected},
+		},
+	}
+	emps := &fakeEmpRepo{
+		emps: map[string]model.Employee{
+			"E1": {EmployeeID: "E1", DepartmentID: 2, Department: warehouse},
+			"M1": {EmployeeID: "M1", DepartmentID: 1, Department: office},
+			"M2": {EmployeeID: "M2", DepartmentID: 2, Department: warehouse},
+		},
+		stints: []model.EmployeeDepartmentAssignment{
+			{EmployeeID: "E1", DepartmentID: 1, EffectiveFrom: date("2024-01-01"), EffectiveTo: &to, Department: office},
+			{EmployeeID: "E1", DepartmentID: 2, EffectiveFrom: date("2025-06-16"), Department: warehouse},
+		},
+	}
+	holidays := &fakeHolidayRepo{items: []model.Holiday{
+		{HolidayDate: date("2025-06-13"), Name: "Office closed", DepartmentID: &officeID},
+		{HolidayDate: date("2025-06-17"), Name: "Public holiday"},
+	}}
+	return &service{repo: repo, empRepo: emps, holidayRepo: holidays}, repo
+}
+
+func TestSubmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       SubmitInput
+		wantDays int
+		wantErr  error
+	}{
+		{
+			// Thu office, Fri office closure, weekend, Mon warehouse, Tue
+			// public holiday, Wed warehouse; rejected days leave 12 - 9 = 3
+			name:     "working days of the department of each day fill the quota",
+			in:       SubmitInput{EmployeeID: "E1", LeaveTypeID: annual, StartDate: "2025-06-12", EndDate: "2025-06-18"},
+			wantDays: 3,
+		},
+		{
+			// Thu, Fri, Sat (warehouse works Saturdays), Mon
+			name:    "quota counts approved and pending days",
+			in:      SubmitInput{EmployeeID: "E1", LeaveTypeID: annual, StartDate: "2025-06-19", EndDate: "2025-06-23"},
+			wantErr: appErr.ErrConflict,
+		},
+		{
+			name:    "overlap with a pending request",
+			in:      SubmitInput{EmployeeID: "E1", LeaveTypeID: sick, StartDate: "2025-04-03", EndDate: "2025-04-03"},
+			wantErr: appErr.ErrConflict,
+		},
+		{
+			name:     "rejected requests do not overlap",
+			in:       SubmitInput{EmployeeID: "E1", LeaveTypeID: sick, StartDate: "2025-05-06", EndDate: "2025-05-06"},
+			wantDays: 1,
+		},
+		{
+			name:    "weekend in the old department",
+			in:      SubmitInput{EmployeeID: "E1", LeaveTypeID: sick, StartDate: "2025-06-14", EndDate: "2025-06-15"},
+			wantErr: appErr.ErrInvalidRange,
+		},
+		{
+			name:    "crossing the new year",
+			in:      SubmitInput{EmployeeID: "E1", LeaveTypeID: sick, StartDate: "2025-12-31", EndDate: "2026-01-02"},
+			wantErr: appErr.ErrInvalidTimeRange,
+		},
+		{
+			name:    "end before start",
+			in:      SubmitInput{EmployeeID: "E1", LeaveTypeID: sick, StartDate: "2025-06-12", EndDate: "2025-06-11"},
+			wantErr: appErr.ErrInvalidTimeRange,
+		},
+		{
+			name:    "unknown employee",
+			in:      SubmitInput{EmployeeID: "E9", LeaveTypeID: sick, StartDate: "2025-06-12", EndDate: "2025-06-12"},
+			wantErr: appErr.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService()
+			got, err := s.Submit(context.Background(), tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Submit() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+			if got.Days != tt.wantDays || got.Status != leaverepo.StatusPending {
+				t.Errorf("Submit() days, status = %d, %s, want %d, pending", got.Days, got.Status, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name       string
+		requestID  string
+		approver   string
+		approve    bool
+		extraTaken int
+		wantErr    error
+	}{
+		{name: "manager of the department the leave starts in", requestID: "r-pending", approver: "M1", approve: true},
+		{name: "current manager of a past stint", requestID: "r-pending", approver: "M2", approve: true, wantErr: appErr.ErrForbidden},
+		{name: "own request", requestID: "r-pending", approver: "E1", approve: true, wantErr: appErr.ErrInvalidInput},
+		{name: "approval over the quota", requestID: "r-pending", approver: "M1", approve: true, extraTaken: 4, wantErr: appErr.ErrConflict},
+		{name: "rejection ignores the quota", requestID: "r-pending", approver: "M1", extraTaken: 4},
+		{name: "already decided", requestID: "r-approved", approver: "M1", approve: true, wantErr: appErr.ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newTestService()
+			if tt.extraTaken > 0 {
+				repo.requests = append(repo.requests, model.LeaveRequest{
+					RequestID: "r-extra", EmployeeID: "E1", LeaveTypeID: annual,
+					StartDate: date("2025-02-03"), EndDate: date("2025-02-06"), Days: tt.extraTaken, Status: leaverepo.StatusApproved,
+				})
+			}
+
+			decide, want := s.Reject, leaverepo.StatusRejected
+			if tt.approve {
+				decide, want = s.Approve, leaverepo.StatusApproved
+			}
+			got, err := decide(context.Background(), tt.requestID, DecisionInput{ApproverID: tt.approver})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("decide() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decide() error = %v", err)
+			}
+			if got.Status != want {
+				t.Errorf("decide() status = %s, want %s", got.Status, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package leave
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+type CreateTypeInput struct {
+	Code        string
+	Name        string
+	YearlyQuota int
+}
+
+type UpdateTypeInput struct {
+	Code        *string
+	Name        *string
+	YearlyQuota *int
+}
+
+type SubmitInput struct {
+	EmployeeID  string
+	LeaveTypeID uint64
+	// "YYYY-MM-DD", inclusive
+	StartDate string
+	EndDate   string
+	Reason    string
+}
+
+type DecisionInput struct {
+	ApproverID string
+	Note       *string
+}
+
+type ListInput struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	Status       string
+	From         string
+	To           string
+	Limit        int
+	Page         int
+}
+
+type ListOutput struct {
+	Data       []model.LeaveRequest `json:"data"`
+	Pagination helper.Pagination    `json:"pagination"`
+}
+
+type BalanceItem struct {
+	LeaveTypeID uint64 `json:"leave_type_id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	// 0 = no quota, Remaining is then omitted
+	YearlyQuota int  `json:"yearly_quota"`
+	Used        int  `json:"used"`
+	Pending     int  `json:"pending"`
+	Remaining   *int `json:"remaining,omitempty"`
+}
+
+type BalanceOutput struct {
+	EmployeeID string        `json:"employee_id"`
+	Year       int           `json:"year"`
+	Items      []BalanceItem `json:"items"`
+}
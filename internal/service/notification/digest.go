@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"github.com/itsaFan/fleetify-be/internal/notify"
+	notifrepo "github.com/itsaFan/fleetify-be/internal/repo/notification"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+const historyPageSize = 100
+
+func (s *service) SendDailyDigests(ctx context.Context, in DigestInput) (*DigestOutput, error) {
+	loc := config.AppTimezone()
+
+	date := in.Date
+	if date == "" {
+		date = helper.DateKey(time.Now().In(loc).AddDate(0, 0, -1))
+	} else if _, _, _, err := helper.ParseYYYYMMDD(date); err != nil {
+		return nil, fmt.Errorf("%w: invalid 'date' (YYYY-MM-DD)", appErr.ErrInvalidInput)
+	}
+
+	recipients, err := s.repo.ListDigestRecipients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DigestOutput{Date: date, Errors: []string{}}
+	for _, rcpt := range recipients {
+		sent, err := s.sendDigest(ctx, rcpt, date, loc)
+		switch {
+		case err != nil:
+			out.Errors = append(out.Errors, fmt.Sprintf("department %d: %v", rcpt.DepartmentID, err))
+		case sent:
+			out.Sent++
+		default:
+			out.Skipped++
+		}
+	}
+	return out, nil
+}
+
+func (s *service) sendDigest(ctx context.Context, rcpt notifrepo.DigestRecipient, date string, loc *time.Location) (bool, error) {
+	data, err := s.buildDigest(ctx, rcpt, date, loc)
+	if err != nil {
+		return false, err
+	}
+	if len(data.Late) == 0 && len(data.MissingOut) == 0 {
+		return false, nil
+	}
+
+	subject := fmt.Sprintf("[%s] Attendance digest %s: %d late, %d missing clock-out",
+		rcpt.DepartmentName, date, len(data.Late), len(data.MissingOut))
+	ref := fmt.Sprintf("%d:%s", rcpt.DepartmentID, date)
+	return s.sendOnce(ctx, model.NotificationDailyDigest, ref, rcpt.ManagerEmail, subject, "digest", data)
+}
+
+// buildDigest reads the day from the department history, the same rows the
+// histories endpoint returns, so the digest and the UI never disagree.
+func (s *service) buildDigest(ctx context.Context, rcpt notifrepo.DigestRecipient, date string, loc *time.Location) (*notify.DigestData, error) {
+	data := &notify.DigestData{
+		ManagerName:    rcpt.ManagerName,
+		DepartmentName: rcpt.DepartmentName,
+		Date:           date,
+	}
+
+	deptID := rcpt.DepartmentID
+	for page, seen := 1, 0; ; page++ {
+		hist, err := s.atdSvc.ListDeparmentAtdHistories(ctx, atdsvc.ListInputDept{
+			DepartmentID: &deptID,
+			FromLocal:    date,
+			ToLocal:      date,
+			TZ:           loc.String(),
+			Limit:        historyPageSize,
+			Page:         page,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, it := range hist.Items {
+			if it.StatusIn == "late" && it.DeltaInMinutes != nil {
+				data.Late = append(data.Late, notify.DigestLate{
+					EmployeeID:   it.EmployeeID,
+					EmployeeName: it.EmployeeName,
+					ClockIn:      derefOr(it.ClockInLocal, "-"),
+					LateMinutes:  *it.DeltaInMinutes,
+				})
+			}
+			if it.ClockInLocal != nil && (it.StatusOut == "no_out" || it.NeedsReview) {
+				data.MissingOut = append(data.MissingOut, notify.DigestMissingOut{
+					EmployeeID:   it.EmployeeID,
+					EmployeeName: it.EmployeeName,
+					ClockIn:      *it.ClockInLocal,
+					AutoClosed:   it.NeedsReview,
+				})
+			}
+		}
+
+		seen += len(hist.Items)
+		if len(hist.Items) == 0 || int64(seen) >= hist.Total {
+			break
+		}
+	}
+	return data, nil
+}
+
+// sendOnce logs the notification first so a second run skips it, and drops
+// the log again if the mail could not be sent.
+func (s *service) sendOnce(ctx context.Context, kind, ref, to, subject, tmpl string, data any) (bool, error) {
+	text, html, err := notify.Render(tmpl, data)
+	if err != nil {
+		return false, err
+	}
+
+	fresh, err := s.repo.LogSent(ctx, kind, ref, to)
+	if err != nil || !fresh {
+		return false, err
+	}
+
+	if err := s.mailer.Send(ctx, notify.Message{
+		To:      []string{to},
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	}); err != nil {
+		if uerr := s.repo.Unlog(ctx, kind, ref); uerr != nil {
+			return false, fmt.Errorf("%v (and could not clear the log: %v)", err, uerr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func derefOr(s *string, def string) string {
+	if s == nil {
+		return def
+	}
+	return *s
+}
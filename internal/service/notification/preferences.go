@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"gorm.io/gorm"
+)
+
+func (s *service) GetPreferences(ctx context.Context, employeeID string) (*model.NotificationPreference, error) {
+	empId := helper.NormalizeStringField(strings.TrimSpace(employeeID))
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	if _, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, empId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, empId)
+		}
+		return nil, err
+	}
+
+	pref, err := s.repo.GetPreference(ctx, empId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// never saved, everything is on
+			return &model.NotificationPreference{
+				EmployeeID:       empId,
+				DailyDigest:      true,
+				ClockOutReminder: true,
+			}, nil
+		}
+		return nil, err
+	}
+	return pref, nil
+}
+
+func (s *service) UpdatePreferences(ctx context.Context, employeeID string, in PreferencesInput) (*model.NotificationPreference, error) {
+	pref, err := s.GetPreferences(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.DailyDigest != nil {
+		pref.DailyDigest = *in.DailyDigest
+	}
+	if in.ClockOutReminder != nil {
+		pref.ClockOutReminder = *in.ClockOutReminder
+	}
+
+	if err := s.repo.UpsertPreference(ctx, pref); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPreference(ctx, pref.EmployeeID)
+}
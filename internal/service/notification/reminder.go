@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"github.com/itsaFan/fleetify-be/internal/notify"
+)
+
+func (s *service) SendClockOutReminders(ctx context.Context) (*ReminderOutput, error) {
+	loc := config.AppTimezone()
+	now := time.Now().UTC()
+
+	open, err := s.atdRepo.ListOpen(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]model.Attendance, 0, len(open))
+	ids := make([]string, 0, len(open))
+	for _, att := range open {
+		if att.Employee.Email == nil || att.ClockIn == nil {
+			continue
+		}
+		cutoff, err := clockOutCutoff(*att.ClockIn, att.Employee.Department.MaxClockOutTime, loc)
+		if err != nil || now.Before(cutoff.Add(s.cfg.ReminderAfter)) {
+			continue
+		}
+		due = append(due, att)
+		ids = append(ids, att.EmployeeID)
+	}
+
+	prefs, err := s.repo.ListPreferences(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ReminderOutput{Errors: []string{}}
+	for _, att := range due {
+		if p, ok := prefs[att.EmployeeID]; ok && !p.ClockOutReminder {
+			continue
+		}
+
+		emp := att.Employee
+		data := notify.ReminderData{
+			EmployeeName:   emp.Name,
+			DepartmentName: emp.Department.DepartmentName,
+			ClockIn:        att.ClockIn.In(loc).Format("2006-01-02 15:04"),
+			MaxClockOut:    emp.Department.MaxClockOutTime,
+		}
+		sent, err := s.sendOnce(ctx, model.NotificationClockOutReminder, att.AttendanceID, *emp.Email,
+			"Reminder: you have not clocked out yet", "reminder", data)
+		if err != nil {
+			out.Errors = append(out.Errors, fmt.Sprintf("attendance %s: %v", att.AttendanceID, err))
+			continue
+		}
+		if sent {
+			out.Sent++
+		}
+	}
+	return out, nil
+}
+
+// clockOutCutoff is the department max clock-out on the local day of clockIn.
+func clockOutCutoff(clockIn time.Time, maxOut string, loc *time.Location) (time.Time, error) {
+	h, m, sec, err := helper.ParseCutoffHHMMSS(maxOut)
+	if err != nil {
+		return time.Time{}, err
+	}
+	local := clockIn.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), h, m, sec, 0, loc), nil
+}
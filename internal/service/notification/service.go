@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	"github.com/itsaFan/fleetify-be/internal/notify"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	notifrepo "github.com/itsaFan/fleetify-be/internal/repo/notification"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+type service struct {
+	repo    notifrepo.Repository
+	atdSvc  atdsvc.Service
+	atdRepo atdrepo.Repository
+	empRepo emprepo.Repository
+	mailer  notify.Mailer
+	cfg     config.NotifyConfig
+}
+
+type Service interface {
+	GetPreferences(ctx context.Context, employeeID string) (*model.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, employeeID string, in PreferencesInput) (*model.NotificationPreference, error)
+
+	// SendDailyDigests mails every department manager the late arrivals and
+	// missing clock-outs of one local day. Already sent digests are skipped.
+	SendDailyDigests(ctx context.Context, in DigestInput) (*DigestOutput, error)
+	// SendClockOutReminders mails employees still clocked in well past their
+	// department max clock-out, once per attendance.
+	SendClockOutReminders(ctx context.Context) (*ReminderOutput, error)
+}
+
+func New(
+	repo notifrepo.Repository,
+	atdSvc atdsvc.Service,
+	atdRepo atdrepo.Repository,
+	empRepo emprepo.Repository,
+	mailer notify.Mailer,
+	cfg config.NotifyConfig,
+) Service {
+	return &service{
+		repo:    repo,
+		atdSvc:  atdSvc,
+		atdRepo: atdRepo,
+		empRepo: empRepo,
+		mailer:  mailer,
+		cfg:     cfg,
+	}
+}
@@ -0,0 +1,24 @@
+package notification
+
+type PreferencesInput struct {
+	DailyDigest      *bool
+	ClockOutReminder *bool
+}
+
+type DigestInput struct {
+	// "YYYY-MM-DD" in APP_TZ, empty = yesterday
+	Date string
+}
+
+type DigestOutput struct {
+	Date string `json:"date"`
+	Sent int    `json:"sent"`
+	// nothing to report, or sent earlier
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors"`
+}
+
+type ReminderOutput struct {
+	Sent   int      `json:"sent"`
+	Errors []string `json:"errors"`
+}
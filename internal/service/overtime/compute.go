@@ -0,0 +1,294 @@
+package overtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	otrepo "github.com/itsaFan/fleetify-be/internal/repo/overtime"
+	"gorm.io/gorm"
+)
+
+const maxComputeDays = 93
+
+// Compute derives overtime records from closed attendances clocked in on the
+// local dates [From, To]. Attendances that already have a record are left as
+// they are, so the call can be repeated safely.
+func (s *service) Compute(ctx context.Context, in ComputeInput) (*ComputeOutput, error) {
+	from, err := parseDate("from", in.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate("to", in.To)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to must not be before from", appErr.ErrInvalidTimeRange)
+	}
+	if to.Sub(from) > maxComputeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range is limited to %d days", appErr.ErrInvalidRange, maxComputeDays)
+	}
+
+	loc := helper.LoadLocationOrUTC(in.TZ)
+	fromUTC, _ := helper.DayBoundsLocalToUTC(loc, from.Year(), from.Month(), from.Day())
+	_, toUTC := helper.DayBoundsLocalToUTC(loc, to.Year(), to.Month(), to.Day())
+
+	atts, err := s.atdRepo.ListClosedInRange(ctx, atdrepo.ListParamsClosed{
+		FromUTC:      fromUTC,
+		ToUTC:        toUTC,
+		EmployeeID:   helper.NormalizeStringField(strings.TrimSpace(in.EmployeeID)),
+		DepartmentID: in.DepartmentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.loadHolidays(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stints, err := s.loadStints(ctx, atts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ComputeOutput{
+		From:    helper.DateKey(from),
+		To:      helper.DateKey(to),
+		TZUsed:  loc.String(),
+		Created: []model.OvertimeRecord{},
+	}
+
+	for _, att := range atts {
+		local := att.ClockIn.In(loc)
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		dept := model.DepartmentOn(stints[att.EmployeeID], att.Employee.Department, day)
+
+		kind, raw, err := s.rawOvertime(att, dept, loc, holidays)
+		if err != nil {
+			return nil, err
+		}
+		if raw < s.policy.MinMinutes {
+			out.BelowThreshold++
+			continue
+		}
+
+		rec, skipped, err := s.createCapped(ctx, att, kind, raw, loc)
+		if err != nil {
+			return nil, err
+		}
+		switch skipped {
+		case skipExisting:
+			out.Existing++
+		case skipCapped:
+			out.Capped++
+		default:
+			rec.Employee = att.Employee
+			out.Created = append(out.Created, *rec)
+		}
+	}
+
+	return out, nil
+}
+
+// rawOvertime returns the day kind and the uncapped overtime in minutes. On a
+// working day only the time past the max clock-out of dept, the department of
+// the day, counts, on rest days and holidays the whole session does.
+func (s *service) rawOvertime(att model.Attendance, dept model.Department, loc *time.Location, holidays holidaySet) (string, int, error) {
+	in := att.ClockIn.In(loc)
+	out := att.ClockOut.In(loc)
+
+	workDays, err := helper.ParseWorkingDays(dept.WorkingDays)
+	if err != nil {
+		return "", 0, fmt.Errorf("department working days for employee %q: %w", att.EmployeeID, err)
+	}
+
+	kind := otrepo.DayWorkday
+	switch {
+	case holidays.has(dept.ID, helper.DateKey(in)):
+		kind = otrepo.DayHoliday
+	case !workDays[in.Weekday()]:
+		kind = otrepo.DayRest
+	}
+
+	if kind != otrepo.DayWorkday {
+		return kind, int(out.Sub(in).Minutes()), nil
+	}
+
+	h, m, sec, err := helper.ParseCutoffHHMMSS(dept.MaxClockOutTime)
+	if err != nil {
+		return "", 0, fmt.Errorf("department max clock-out for employee %q: %w", att.EmployeeID, err)
+	}
+	deadline := time.Date(in.Year(), in.Month(), in.Day(), h, m, sec, 0, loc)
+	return kind, int(out.Sub(deadline).Minutes()), nil
+}
+
+type skipReason int
+
+const (
+	skipNone skipReason = iota
+	skipExisting
+	skipCapped
+)
+
+// createCapped applies the daily and weekly caps against pending and approved
+// records and stores what is left. The employee row is locked first so two
+// computes of the same employee cannot both fit under the caps.
+func (s *service) createCapped(ctx context.Context, att model.Attendance, kind string, raw int, loc *time.Location) (*model.OvertimeRecord, skipReason, error) {
+	local := att.ClockIn.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	// ISO week, Monday first
+	weekStart := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	multiplier := s.policy.WeekdayMultiplier
+	if kind != otrepo.DayWorkday {
+		multiplier = s.policy.HolidayMultiplier
+	}
+
+	var rec *model.OvertimeRecord
+	reason := skipNone
+
+	err := s.repo.WithTx(ctx, func(tx otrepo.Repository) error {
+		if err := tx.LockEmployee(ctx, att.EmployeeID); err != nil {
+			return err
+		}
+		exists, err := tx.ExistsByAttendanceID(ctx, att.AttendanceID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			reason = skipExisting
+			return nil
+		}
+
+		counted := []string{otrepo.StatusPending, otrepo.StatusApproved}
+		dayUsed, err := tx.SumMinutes(ctx, att.EmployeeID, day, day, counted...)
+		if err != nil {
+			return err
+		}
+		weekUsed, err := tx.SumMinutes(ctx, att.EmployeeID, weekStart, weekEnd, counted...)
+		if err != nil {
+			return err
+		}
+
+		minutes := min(raw, s.policy.DailyCapMinutes-dayUsed, s.policy.WeeklyCapMinutes-weekUsed)
+		if minutes <= 0 {
+			reason = skipCapped
+			return nil
+		}
+
+		rec = &model.OvertimeRecord{
+			OvertimeID:   uuid.NewString(),
+			EmployeeID:   att.EmployeeID,
+			AttendanceID: att.AttendanceID,
+			WorkDate:     day,
+			DayKind:      kind,
+			RawMinutes:   raw,
+			Minutes:      minutes,
+			Multiplier:   multiplier,
+			Status:       otrepo.StatusPending,
+		}
+		return tx.Create(ctx, rec)
+	})
+	if err != nil {
+		// a concurrent compute got there first
+		if isDuplicateKey(err) {
+			return nil, skipExisting, nil
+		}
+		return nil, skipNone, err
+	}
+	return rec, reason, nil
+}
+
+// holidaySet indexes holidays by local date (YYYY-MM-DD).
+type holidaySet struct {
+	global map[string]bool
+	byDept map[uint64]map[string]bool
+}
+
+func (h holidaySet) has(departmentID uint64, key string) bool {
+	return h.global[key] || h.byDept[departmentID][key]
+}
+
+func (s *service) loadHolidays(ctx context.Context, from, to time.Time) (holidaySet, error) {
+	set := holidaySet{global: map[string]bool{}, byDept: map[uint64]map[string]bool{}}
+
+	rows, err := s.holidayRepo.ListInRange(ctx, from, to)
+	if err != nil {
+		return set, err
+	}
+	for _, h := range rows {
+		key := helper.DateKey(h.HolidayDate)
+		if h.DepartmentID == nil {
+			set.global[key] = true
+			continue
+		}
+		if set.byDept[*h.DepartmentID] == nil {
+			set.byDept[*h.DepartmentID] = map[string]bool{}
+		}
+		set.byDept[*h.DepartmentID][key] = true
+	}
+	return set, nil
+}
+
+// loadStints returns the department stints of the employees behind atts by
+// employee_id, oldest first.
+func (s *service) loadStints(ctx context.Context, atts []model.Attendance) (map[string][]model.EmployeeDepartmentAssignment, error) {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, a := range atts {
+		if !seen[a.EmployeeID] {
+			seen[a.EmployeeID] = true
+			ids = append(ids, a.EmployeeID)
+		}
+	}
+	rows, err := s.empRepo.ListAssignments(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]model.EmployeeDepartmentAssignment, len(ids))
+	for _, a := range rows {
+		out[a.EmployeeID] = append(out[a.EmployeeID], a)
+	}
+	return out, nil
+}
+
+func (s *service) getEmployee(ctx context.Context, employeeID string) (*model.Employee, error) {
+	emp, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, employeeID)
+		}
+		return nil, err
+	}
+	return emp, nil
+}
+
+func parseDate(field, s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, fmt.Errorf("%w: %s is required (YYYY-MM-DD)", appErr.ErrRequiredField, field)
+	}
+	y, m, d, err := helper.ParseYYYYMMDD(strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s %q", appErr.ErrInvalidInput, field, s)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+func isDuplicateKey(err error) bool {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		return me.Number == 1062
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package overtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	otrepo "github.com/itsaFan/fleetify-be/internal/repo/overtime"
+	"gorm.io/gorm"
+)
+
+func (s *service) Approve(ctx context.Context, overtimeID string, in DecisionInput) (*model.OvertimeRecord, error) {
+	return s.decide(ctx, overtimeID, otrepo.StatusApproved, in)
+}
+
+func (s *service) Reject(ctx context.Context, overtimeID string, in DecisionInput) (*model.OvertimeRecord, error) {
+	return s.decide(ctx, overtimeID, otrepo.StatusRejected, in)
+}
+
+func (s *service) decide(ctx context.Context, overtimeID, status string, in DecisionInput) (*model.OvertimeRecord, error) {
+	otId := helper.NormalizeStringField(strings.TrimSpace(overtimeID))
+	if otId == "" {
+		return nil, fmt.Errorf("%w: overtime_id is required", appErr.ErrRequiredField)
+	}
+	approverId := helper.NormalizeStringField(strings.TrimSpace(in.ApproverID))
+	if approverId == "" {
+		return nil, fmt.Errorf("%w: approver_id is required", appErr.ErrRequiredField)
+	}
+	if _, err := s.getEmployee(ctx, approverId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.WithTx(ctx, func(tx otrepo.Repository) error {
+		cur, err := tx.GetForUpdate(ctx, otId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: overtime record %q", appErr.ErrNotFound, otId)
+			}
+			return err
+		}
+		if cur.Status != otrepo.StatusPending {
+			return fmt.Errorf("%w: overtime record %q is already %s", appErr.ErrConflict, otId, cur.Status)
+		}
+		if cur.EmployeeID == approverId {
+			return fmt.Errorf("%w: employees cannot decide their own overtime", appErr.ErrInvalidInput)
+		}
+		dept, err := s.empRepo.DepartmentOn(ctx, cur.EmployeeID, cur.WorkDate)
+		if err != nil {
+			return err
+		}
+		if dept.ManagerEmployeeID == nil || *dept.ManagerEmployeeID != approverId {
+			return fmt.Errorf("%w: only the manager of department %q can decide this overtime", appErr.ErrForbidden, dept.DepartmentName)
+		}
+
+		var note *string
+		if in.Note != nil {
+			n := strings.TrimSpace(*in.Note)
+			note = &n
+		}
+
+		return tx.UpdateDecision(ctx, otId, otrepo.DecisionParams{
+			Status:     status,
+			ApproverID: approverId,
+			Note:       note,
+			DecidedAt:  time.Now().UTC(),
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByOvertimeID(ctx, otId)
+}
@@ -0,0 +1,76 @@
+package overtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	otrepo "github.com/itsaFan/fleetify-be/internal/repo/overtime"
+	"gorm.io/gorm"
+)
+
+func (in *ListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	in.Status = strings.ToLower(strings.TrimSpace(in.Status))
+}
+
+func (s *service) List(ctx context.Context, in ListInput) (*ListOutput, error) {
+	in.normalize()
+
+	params := otrepo.ListParams{
+		EmployeeID:   helper.NormalizeStringField(in.EmployeeID),
+		DepartmentID: in.DepartmentID,
+		Status:       in.Status,
+		Limit:        in.Limit,
+		Page:         in.Page,
+	}
+	if in.From != "" {
+		from, err := parseDate("from", in.From)
+		if err != nil {
+			return nil, err
+		}
+		params.From = &from
+	}
+	if in.To != "" {
+		to, err := parseDate("to", in.To)
+		if err != nil {
+			return nil, err
+		}
+		params.To = &to
+	}
+
+	items, total, err := s.repo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
+
+func (s *service) GetByOvertimeID(ctx context.Context, overtimeID string) (*model.OvertimeRecord, error) {
+	otId := helper.NormalizeStringField(strings.TrimSpace(overtimeID))
+	if otId == "" {
+		return nil, fmt.Errorf("%w: overtime_id is required", appErr.ErrRequiredField)
+	}
+
+	out, err := s.repo.GetByOvertimeID(ctx, otId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: overtime record %q", appErr.ErrNotFound, otId)
+		}
+		return nil, err
+	}
+	return out, nil
+}
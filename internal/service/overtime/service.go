@@ -0,0 +1,47 @@
+package overtime
+
+import (
+	"context"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdrepo "github.com/itsaFan/fleetify-be/internal/repo/attendance"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	holidayrepo "github.com/itsaFan/fleetify-be/internal/repo/holiday"
+	otrepo "github.com/itsaFan/fleetify-be/internal/repo/overtime"
+)
+
+type service struct {
+	repo        otrepo.Repository
+	atdRepo     atdrepo.Repository
+	empRepo     emprepo.Repository
+	holidayRepo holidayrepo.Repository
+	policy      config.OvertimePolicy
+}
+
+type Service interface {
+	Compute(ctx context.Context, in ComputeInput) (*ComputeOutput, error)
+	List(ctx context.Context, in ListInput) (*ListOutput, error)
+	GetByOvertimeID(ctx context.Context, overtimeID string) (*model.OvertimeRecord, error)
+	Approve(ctx context.Context, overtimeID string, in DecisionInput) (*model.OvertimeRecord, error)
+	Reject(ctx context.Context, overtimeID string, in DecisionInput) (*model.OvertimeRecord, error)
+
+	// ApprovedTotals is what reports and payroll exports read
+	ApprovedTotals(ctx context.Context, in TotalsInput) (*TotalsOutput, error)
+}
+
+func New(
+	repo otrepo.Repository,
+	atdRepo atdrepo.Repository,
+	empRepo emprepo.Repository,
+	holidayRepo holidayrepo.Repository,
+	policy config.OvertimePolicy,
+) Service {
+	return &service{
+		repo:        repo,
+		atdRepo:     atdRepo,
+		empRepo:     empRepo,
+		holidayRepo: holidayRepo,
+		policy:      policy,
+	}
+}
@@ -0,0 +1,61 @@
+package overtime
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	otrepo "github.com/itsaFan/fleetify-be/internal/repo/overtime"
+)
+
+// ApprovedTotals sums approved overtime per employee over work dates in
+// [From, To]. Weighted minutes apply each record's multiplier.
+func (s *service) ApprovedTotals(ctx context.Context, in TotalsInput) (*TotalsOutput, error) {
+	from, err := parseDate("from", in.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate("to", in.To)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to must not be before from", appErr.ErrInvalidTimeRange)
+	}
+
+	rows, err := s.repo.ApprovedTotals(ctx, otrepo.TotalsParams{
+		From:         from,
+		To:           to,
+		EmployeeID:   helper.NormalizeStringField(in.EmployeeID),
+		DepartmentID: in.DepartmentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]TotalItem, len(rows))
+	for i, r := range rows {
+		items[i] = TotalItem{
+			EmployeeID:      r.EmployeeID,
+			EmployeeName:    r.EmployeeName,
+			DepartmentID:    r.DepartmentID,
+			Records:         r.Records,
+			Minutes:         r.Minutes,
+			Hours:           round2(float64(r.Minutes) / 60),
+			WeightedMinutes: round2(r.WeightedMinutes),
+			WeightedHours:   round2(r.WeightedMinutes / 60),
+		}
+	}
+
+	return &TotalsOutput{
+		From:  helper.DateKey(from),
+		To:    helper.DateKey(to),
+		Items: items,
+	}, nil
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,72 @@
+package overtime
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+type ComputeInput struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	// "YYYY-MM-DD" local dates, inclusive
+	From string
+	To   string
+	TZ   string
+}
+
+type ComputeOutput struct {
+	From    string                 `json:"from"`
+	To      string                 `json:"to"`
+	TZUsed  string                 `json:"tz_used"`
+	Created []model.OvertimeRecord `json:"-"`
+	// attendances already derived earlier
+	Existing int `json:"existing"`
+	// under the minimum threshold
+	BelowThreshold int `json:"below_threshold"`
+	// daily or weekly cap already used up
+	Capped int `json:"capped"`
+}
+
+type DecisionInput struct {
+	ApproverID string
+	Note       *string
+}
+
+type ListInput struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	Status       string
+	From         string
+	To           string
+	Limit        int
+	Page         int
+}
+
+type ListOutput struct {
+	Data       []model.OvertimeRecord `json:"data"`
+	Pagination helper.Pagination      `json:"pagination"`
+}
+
+type TotalsInput struct {
+	EmployeeID   string
+	DepartmentID *uint64
+	From         string
+	To           string
+}
+
+type TotalItem struct {
+	EmployeeID      string  `json:"employee_id"`
+	EmployeeName    string  `json:"employee_name"`
+	DepartmentID    uint64  `json:"department_id"`
+	Records         int64   `json:"records"`
+	Minutes         int64   `json:"minutes"`
+	Hours           float64 `json:"hours"`
+	WeightedMinutes float64 `json:"weighted_minutes"`
+	WeightedHours   float64 `json:"weighted_hours"`
+}
+
+type TotalsOutput struct {
+	From  string      `json:"from"`
+	To    string      `json:"to"`
+	Items []TotalItem `json:"items"`
+}
@@ -0,0 +1,182 @@
+package payroll
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+	otsvc "github.com/itsaFan/fleetify-be/internal/service/overtime"
+)
+
+const defaultTemplate = "default"
+
+// columnSpec is a template column with its padding rules resolved.
+type columnSpec struct {
+	model.PayrollColumn
+	pad        string
+	alignRight bool
+}
+
+// Export renders one row per employee of the department (or of everyone)
+// for the pay period. Employees without any attendance still get a row, so
+// the vendor sees the full roster.
+func (s *service) Export(ctx context.Context, in ExportInput) (*ExportOutput, error) {
+	name := helper.NormalizeStringField(in.Template)
+	if name == "" {
+		name = defaultTemplate
+	}
+	tmpl, err := s.GetTemplate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tz := strings.TrimSpace(in.TZ)
+	if tz == "" {
+		tz = config.AppTimezone().String()
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, fmt.Errorf("%w: unknown tz %q", appErr.ErrInvalidInput, tz)
+	}
+
+	if in.From == "" || in.To == "" {
+		return nil, fmt.Errorf("%w: from/to are required (YYYY-MM-DD)", appErr.ErrRequiredField)
+	}
+	y1, m1, d1, err := helper.ParseYYYYMMDD(in.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'from' date", appErr.ErrInvalidInput)
+	}
+	y2, m2, d2, err := helper.ParseYYYYMMDD(in.To)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid 'to' date", appErr.ErrInvalidInput)
+	}
+	from, to := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC), time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+
+	att, err := s.atdSvc.RangeTotals(ctx, atdsvc.RangeTotalsInput{
+		DepartmentID: in.DepartmentID,
+		FromLocal:    in.From,
+		ToLocal:      in.To,
+		TZ:           tz,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ot, err := s.otSvc.ApprovedTotals(ctx, otsvc.TotalsInput{
+		DepartmentID: in.DepartmentID,
+		From:         in.From,
+		To:           in.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	otByEmp := make(map[string]otsvc.TotalItem, len(ot.Items))
+	for _, it := range ot.Items {
+		otByEmp[it.EmployeeID] = it
+	}
+
+	rows := make([]payRow, len(att))
+	for i, a := range att {
+		rows[i] = payRow{att: a, ot: otByEmp[a.EmployeeID], from: from, to: to}
+	}
+
+	body, err := render(tmpl, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportOutput{
+		Filename:    fmt.Sprintf("payroll_%s_%s_%s.csv", helper.FileSafe(tmpl.Name), in.From, in.To),
+		ContentType: "text/csv; charset=utf-8",
+		Body:        body,
+		Rows:        len(rows),
+	}, nil
+}
+
+func render(tmpl *Template, rows []payRow) ([]byte, error) {
+	cols := make([]columnSpec, len(tmpl.Columns))
+	for i, c := range tmpl.Columns {
+		spec := columnSpec{PayrollColumn: c, pad: c.Pad, alignRight: c.Align == "right"}
+		if spec.pad == "" {
+			spec.pad = " "
+		}
+		if c.Align == "" {
+			k := fields[c.Field]
+			spec.alignRight = k == kindInt || k == kindHours
+		}
+		cols[i] = spec
+	}
+
+	var buf bytes.Buffer
+	write := recordWriter(&buf, tmpl)
+
+	if tmpl.IncludeHeader {
+		rec := make([]string, len(cols))
+		for i, c := range cols {
+			h := c.Header
+			if h == "" {
+				h = c.Field
+			}
+			// headers keep their own alignment, only the width applies
+			rec[i] = fit(h, columnSpec{PayrollColumn: c.PayrollColumn, pad: " "})
+		}
+		if err := write(rec); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, r := range rows {
+		rec := make([]string, len(cols))
+		for i, c := range cols {
+			v := c.Value
+			if c.Field != FieldConstant {
+				v = r.value(c.Field, tmpl, c.Decimals)
+			}
+			rec[i] = fit(v, c)
+		}
+		if err := write(rec); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := write(nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// recordWriter returns a func writing one record, nil flushes.
+func recordWriter(buf *bytes.Buffer, tmpl *Template) func(rec []string) error {
+	eol := "\n"
+	if tmpl.UseCRLF {
+		eol = "\r\n"
+	}
+
+	if tmpl.Delimiter == "" {
+		return func(rec []string) error {
+			if rec == nil {
+				return nil
+			}
+			buf.WriteString(strings.Join(rec, ""))
+			buf.WriteString(eol)
+			return nil
+		}
+	}
+
+	w := csv.NewWriter(buf)
+	w.Comma = rune(tmpl.Delimiter[0])
+	w.UseCRLF = tmpl.UseCRLF
+	return func(rec []string) error {
+		if rec == nil {
+			w.Flush()
+			return w.Error()
+		}
+		return w.Write(rec)
+	}
+}
@@ -0,0 +1,133 @@
+package payroll
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+	otsvc "github.com/itsaFan/fleetify-be/internal/service/overtime"
+)
+
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindInt
+	kindHours
+	kindDate
+)
+
+// FieldConstant writes the column's value as is, for vendor codes and fillers.
+const FieldConstant = "constant"
+
+// fields a template column can map
+var fields = map[string]fieldKind{
+	"employee_id":             kindText,
+	"employee_name":           kindText,
+	"department_name":         kindText,
+	FieldConstant:             kindText,
+	"period_from":             kindDate,
+	"period_to":               kindDate,
+	"worked_days":             kindInt,
+	"worked_minutes":          kindInt,
+	"worked_hours":            kindHours,
+	"late_count":              kindInt,
+	"late_minutes":            kindInt,
+	"early_leave_count":       kindInt,
+	"overtime_minutes":        kindInt,
+	"overtime_hours":          kindHours,
+	"overtime_weighted_hours": kindHours,
+	"leave_days":              kindInt,
+	"absent_days":             kindInt,
+	"holiday_work_days":       kindInt,
+}
+
+var dateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD-MM-YYYY": "02-01-2006",
+	"YYYYMMDD":   "20060102",
+}
+
+const defaultDecimals = 2
+
+// payRow is one employee's figures for the pay period.
+type payRow struct {
+	att      atdsvc.EmployeeTotals
+	ot       otsvc.TotalItem
+	from, to time.Time
+}
+
+// value renders field for r; minutes and hours come straight from the
+// attendance and approved overtime totals.
+func (r payRow) value(field string, tmpl *Template, decimals *int) string {
+	t := r.att.Totals
+	switch field {
+	case "employee_id":
+		return r.att.EmployeeID
+	case "employee_name":
+		return r.att.EmployeeName
+	case "department_name":
+		return r.att.DepartmentName
+	case "period_from":
+		return r.from.Format(dateFormats[tmpl.DateFormat])
+	case "period_to":
+		return r.to.Format(dateFormats[tmpl.DateFormat])
+	case "worked_days":
+		return strconv.Itoa(t.PresentDays)
+	case "worked_minutes":
+		return strconv.Itoa(t.WorkedMinutes)
+	case "worked_hours":
+		return hours(float64(t.WorkedMinutes), tmpl, decimals)
+	case "late_count":
+		return strconv.Itoa(t.LateCount)
+	case "late_minutes":
+		return strconv.Itoa(t.LateMinutes)
+	case "early_leave_count":
+		return strconv.Itoa(t.EarlyLeaveCount)
+	case "overtime_minutes":
+		return strconv.FormatInt(r.ot.Minutes, 10)
+	case "overtime_hours":
+		return hours(float64(r.ot.Minutes), tmpl, decimals)
+	case "overtime_weighted_hours":
+		return hours(r.ot.WeightedMinutes, tmpl, decimals)
+	case "leave_days":
+		return strconv.Itoa(t.OnLeaveDays)
+	case "absent_days":
+		return strconv.Itoa(t.AbsentDays)
+	case "holiday_work_days":
+		return strconv.Itoa(t.HolidayWorkDays)
+	}
+	return ""
+}
+
+func hours(minutes float64, tmpl *Template, decimals *int) string {
+	d := defaultDecimals
+	if decimals != nil {
+		d = *decimals
+	}
+	s := strconv.FormatFloat(minutes/60, 'f', d, 64)
+	if tmpl.DecimalSeparator != "." {
+		s = strings.Replace(s, ".", tmpl.DecimalSeparator, 1)
+	}
+	return s
+}
+
+// fit pads or cuts s to the column width, a zero width leaves s alone.
+func fit(s string, col columnSpec) string {
+	if col.Width <= 0 {
+		return s
+	}
+	r := []rune(s)
+	if len(r) >= col.Width {
+		return string(r[:col.Width])
+	}
+
+	pad := strings.Repeat(col.pad, col.Width-len(r))
+	if col.alignRight {
+		return pad + s
+	}
+	return s + pad
+}
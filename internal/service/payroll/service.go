@@ -0,0 +1,31 @@
+package payroll
+
+import (
+	"context"
+
+	payrollrepo "github.com/itsaFan/fleetify-be/internal/repo/payroll"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+	otsvc "github.com/itsaFan/fleetify-be/internal/service/overtime"
+)
+
+type service struct {
+	repo   payrollrepo.Repository
+	atdSvc atdsvc.Service
+	otSvc  otsvc.Service
+}
+
+// Service keeps vendor CSV layouts in the database and renders pay period
+// exports through them, a vendor change is a template edit.
+type Service interface {
+	CreateTemplate(ctx context.Context, in CreateTemplateInput) (*Template, error)
+	ListTemplates(ctx context.Context) ([]Template, error)
+	GetTemplate(ctx context.Context, name string) (*Template, error)
+	UpdateTemplate(ctx context.Context, name string, in UpdateTemplateInput) (*Template, error)
+	DeleteTemplate(ctx context.Context, name string) error
+
+	Export(ctx context.Context, in ExportInput) (*ExportOutput, error)
+}
+
+func New(repo payrollrepo.Repository, atdSvc atdsvc.Service, otSvc otsvc.Service) Service {
+	return &service{repo: repo, atdSvc: atdSvc, otSvc: otSvc}
+}
@@ -0,0 +1,270 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	payrollrepo "github.com/itsaFan/fleetify-be/internal/repo/payroll"
+	"gorm.io/gorm"
+)
+
+const maxColumns = 100
+
+var delimiters = map[string]bool{"": true, ",": true, ";": true, "|": true, "\t": true}
+
+func (s *service) CreateTemplate(ctx context.Context, in CreateTemplateInput) (*Template, error) {
+	t := &Template{
+		Name:             helper.NormalizeStringField(in.Name),
+		Description:      trimmedOrNil(in.Description),
+		Delimiter:        ",",
+		IncludeHeader:    true,
+		DateFormat:       strings.TrimSpace(in.DateFormat),
+		DecimalSeparator: in.DecimalSeparator,
+		UseCRLF:          true,
+		Columns:          in.Columns,
+	}
+	if in.Delimiter != nil {
+		t.Delimiter = *in.Delimiter
+	}
+	if in.IncludeHeader != nil {
+		t.IncludeHeader = *in.IncludeHeader
+	}
+	if in.UseCRLF != nil {
+		t.UseCRLF = *in.UseCRLF
+	}
+	if t.DateFormat == "" {
+		t.DateFormat = "YYYY-MM-DD"
+	}
+	if t.DecimalSeparator == "" {
+		t.DecimalSeparator = "."
+	}
+
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.ExistsByName(ctx, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: payroll template %q", appErr.ErrAlreadyExists, t.Name)
+	}
+
+	cols, err := json.Marshal(t.Columns)
+	if err != nil {
+		return nil, err
+	}
+	m := &model.PayrollTemplate{
+		Name:             t.Name,
+		Description:      t.Description,
+		Delimiter:        t.Delimiter,
+		IncludeHeader:    t.IncludeHeader,
+		DateFormat:       t.DateFormat,
+		DecimalSeparator: t.DecimalSeparator,
+		UseCRLF:          t.UseCRLF,
+		Columns:          cols,
+	}
+	if err := s.repo.Create(ctx, m); err != nil {
+		return nil, err
+	}
+	return toTemplate(m)
+}
+
+func (s *service) ListTemplates(ctx context.Context) ([]Template, error) {
+	items, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Template, 0, len(items))
+	for i := range items {
+		t, err := toTemplate(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *t)
+	}
+	return out, nil
+}
+
+func (s *service) GetTemplate(ctx context.Context, name string) (*Template, error) {
+	name = helper.NormalizeStringField(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: template name is required", appErr.ErrRequiredField)
+	}
+
+	m, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: payroll template %q", appErr.ErrNotFound, name)
+		}
+		return nil, err
+	}
+	return toTemplate(m)
+}
+
+func (s *service) UpdateTemplate(ctx context.Context, name string, in UpdateTemplateInput) (*Template, error) {
+	cur, err := s.GetTemplate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate the template as it will be stored
+	next := *cur
+	p := payrollrepo.UpdateParams{
+		Delimiter:        in.Delimiter,
+		IncludeHeader:    in.IncludeHeader,
+		DecimalSeparator: in.DecimalSeparator,
+		UseCRLF:          in.UseCRLF,
+	}
+	if in.Name != nil {
+		n := helper.NormalizeStringField(*in.Name)
+		next.Name, p.Name = n, &n
+	}
+	if in.Description != nil {
+		d := strings.TrimSpace(*in.Description)
+		next.Description, p.Description = trimmedOrNil(&d), &d
+	}
+	if in.Delimiter != nil {
+		next.Delimiter = *in.Delimiter
+	}
+	if in.IncludeHeader != nil {
+		next.IncludeHeader = *in.IncludeHeader
+	}
+	if in.DateFormat != nil {
+		f := strings.TrimSpace(*in.DateFormat)
+		next.DateFormat, p.DateFormat = f, &f
+	}
+	if in.DecimalSeparator != nil {
+		next.DecimalSeparator = *in.DecimalSeparator
+	}
+	if in.UseCRLF != nil {
+		next.UseCRLF = *in.UseCRLF
+	}
+	if in.Columns != nil {
+		next.Columns = in.Columns
+		if p.Columns, err = json.Marshal(in.Columns); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := next.validate(); err != nil {
+		return nil, err
+	}
+
+	if next.Name != cur.Name {
+		exists, err := s.repo.ExistsByName(ctx, next.Name)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: payroll template %q", appErr.ErrAlreadyExists, next.Name)
+		}
+	}
+
+	if err := s.repo.UpdateByName(ctx, cur.Name, p); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: payroll template %q", appErr.ErrNotFound, cur.Name)
+		}
+		return nil, err
+	}
+	return s.GetTemplate(ctx, next.Name)
+}
+
+func (s *service) DeleteTemplate(ctx context.Context, name string) error {
+	name = helper.NormalizeStringField(name)
+	if name == "" {
+		return fmt.Errorf("%w: template name is required", appErr.ErrRequiredField)
+	}
+
+	if err := s.repo.DeleteByName(ctx, name); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: payroll template %q", appErr.ErrNotFound, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *Template) validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", appErr.ErrRequiredField)
+	}
+	if utf8.RuneCountInString(t.Name) > 100 {
+		return fmt.Errorf("%w: name is limited to 100 characters", appErr.ErrInvalidInput)
+	}
+	if !delimiters[t.Delimiter] {
+		return fmt.Errorf("%w: delimiter must be one of , ; | tab or empty for fixed width", appErr.ErrInvalidInput)
+	}
+	if _, ok := dateFormats[t.DateFormat]; !ok {
+		return fmt.Errorf("%w: date_format %q is not supported", appErr.ErrInvalidInput, t.DateFormat)
+	}
+	if t.DecimalSeparator != "." && t.DecimalSeparator != "," {
+		return fmt.Errorf("%w: decimal_separator must be . or ,", appErr.ErrInvalidInput)
+	}
+
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("%w: at least one column is required", appErr.ErrRequiredField)
+	}
+	if len(t.Columns) > maxColumns {
+		return fmt.Errorf("%w: at most %d columns", appErr.ErrInvalidInput, maxColumns)
+	}
+	for i, c := range t.Columns {
+		if _, ok := fields[c.Field]; !ok {
+			return fmt.Errorf("%w: column %d: unknown field %q", appErr.ErrInvalidInput, i+1, c.Field)
+		}
+		if c.Decimals != nil && (*c.Decimals < 0 || *c.Decimals > 6) {
+			return fmt.Errorf("%w: column %d: decimals must be between 0 and 6", appErr.ErrInvalidInput, i+1)
+		}
+		if c.Width < 0 || c.Width > 255 {
+			return fmt.Errorf("%w: column %d: width must be between 0 and 255", appErr.ErrInvalidInput, i+1)
+		}
+		if t.Delimiter == "" && c.Width == 0 {
+			return fmt.Errorf("%w: column %d: fixed width templates need a width on every column", appErr.ErrInvalidInput, i+1)
+		}
+		if c.Align != "" && c.Align != "left" && c.Align != "right" {
+			return fmt.Errorf("%w: column %d: align must be left or right", appErr.ErrInvalidInput, i+1)
+		}
+		if utf8.RuneCountInString(c.Pad) > 1 {
+			return fmt.Errorf("%w: column %d: pad is a single character", appErr.ErrInvalidInput, i+1)
+		}
+	}
+	return nil
+}
+
+func toTemplate(m *model.PayrollTemplate) (*Template, error) {
+	t := &Template{
+		Name:             m.Name,
+		Description:      m.Description,
+		Delimiter:        m.Delimiter,
+		IncludeHeader:    m.IncludeHeader,
+		DateFormat:       m.DateFormat,
+		DecimalSeparator: m.DecimalSeparator,
+		UseCRLF:          m.UseCRLF,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+	if err := json.Unmarshal(m.Columns, &t.Columns); err != nil {
+		return nil, fmt.Errorf("payroll template %q: columns: %w", m.Name, err)
+	}
+	return t, nil
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,67 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+type Template struct {
+	Name             string                `json:"name"`
+	Description      *string               `json:"description"`
+	Delimiter        string                `json:"delimiter"`
+	IncludeHeader    bool                  `json:"include_header"`
+	DateFormat       string                `json:"date_format"`
+	DecimalSeparator string                `json:"decimal_separator"`
+	UseCRLF          bool                  `json:"use_crlf"`
+	Columns          []model.PayrollColumn `json:"columns"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+type CreateTemplateInput struct {
+	Name        string
+	Description *string
+	// nil = ",", "" = fixed width
+	Delimiter *string
+	// nil = true
+	IncludeHeader *bool
+	// "" = YYYY-MM-DD
+	DateFormat string
+	// "" = "."
+	DecimalSeparator string
+	// nil = true
+	UseCRLF *bool
+	Columns []model.PayrollColumn
+}
+
+type UpdateTemplateInput struct {
+	Name *string
+	// "" clears
+	Description      *string
+	Delimiter        *string
+	IncludeHeader    *bool
+	DateFormat       *string
+	DecimalSeparator *string
+	UseCRLF          *bool
+	// replaces the whole mapping
+	Columns []model.PayrollColumn
+}
+
+type ExportInput struct {
+	// "" = the template named default
+	Template     string
+	DepartmentID *uint64
+	// pay period, YYYY-MM-DD, inclusive
+	From string
+	To   string
+	// defaults to APP_TZ
+	TZ string
+}
+
+type ExportOutput struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+	Rows        int
+}
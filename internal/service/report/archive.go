@@ -0,0 +1,78 @@
+package report
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+)
+
+func (s *service) WriteMonthlyArchive(ctx context.Context, in MonthlyInput, w io.Writer) (*ArchiveOutput, error) {
+	m, err := resolveMonth(in)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ArchiveOutput{Month: m.key, Filename: archiveFilename(m)}
+	zw := zip.NewWriter(w)
+
+	for page := 1; ; page++ {
+		depts, total, err := s.deptRepo.List(ctx, deptrepo.ListParams{Limit: 100, Page: page})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range depts {
+			sheet, err := s.departmentSheet(ctx, &depts[i], m)
+			if err != nil {
+				return nil, fmt.Errorf("department %q: %w", depts[i].DepartmentName, err)
+			}
+			if len(sheet.Employees) == 0 {
+				continue
+			}
+
+			body, err := renderDepartment(sheet, m)
+			if err != nil {
+				return nil, err
+			}
+			f, err := zw.CreateHeader(&zip.FileHeader{
+				// names that sanitize alike ("R&D", "R D") stay apart by id
+				Name:     fmt.Sprintf("attendance_%s/%d_%s.pdf", m.key, depts[i].ID, helper.FileSafe(depts[i].DepartmentName)),
+				Method:   zip.Deflate,
+				Modified: time.Now(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if _, err := f.Write(body); err != nil {
+				return nil, err
+			}
+			out.Departments++
+		}
+
+		if int64(page*100) >= total {
+			break
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *service) ArchiveFilename(in MonthlyInput) (string, error) {
+	m, err := resolveMonth(in)
+	if err != nil {
+		return "", err
+	}
+	return archiveFilename(m), nil
+}
+
+func archiveFilename(m month) string {
+	return fmt.Sprintf("attendance_%s.zip", m.key)
+}
@@ -0,0 +1,154 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+	"gorm.io/gorm"
+)
+
+func (s *service) EmployeeMonthly(ctx context.Context, employeeID string, in MonthlyInput) (*Report, error) {
+	m, err := resolveMonth(in)
+	if err != nil {
+		return nil, err
+	}
+
+	empId := helper.NormalizeStringField(employeeID)
+	if empId == "" {
+		return nil, fmt.Errorf("%w: employee_id is required", appErr.ErrRequiredField)
+	}
+	emp, err := s.empRepo.GetByEmployeeIDWithArchived(ctx, empId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: employee %q", appErr.ErrNotFound, empId)
+		}
+		return nil, err
+	}
+
+	sheet, err := s.employeeSheet(ctx, emp, m)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := renderEmployee(sheet, m)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Filename: fmt.Sprintf("attendance_%s_%s.pdf", helper.FileSafe(emp.EmployeeID), m.key),
+		Body:     body,
+	}, nil
+}
+
+func (s *service) DepartmentMonthly(ctx context.Context, departmentName string, in MonthlyInput) (*Report, error) {
+	m, err := resolveMonth(in)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(departmentName)
+	if name == "" {
+		return nil, fmt.Errorf("%w: department name is required", appErr.ErrRequiredField)
+	}
+	dept, err := s.deptRepo.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: department %q", appErr.ErrNotFound, name)
+		}
+		return nil, err
+	}
+
+	return s.departmentReport(ctx, dept, m)
+}
+
+func (s *service) departmentReport(ctx context.Context, dept *model.Department, m month) (*Report, error) {
+	sheet, err := s.departmentSheet(ctx, dept, m)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := renderDepartment(sheet, m)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Filename: fmt.Sprintf("attendance_%s_%s.pdf", helper.FileSafe(dept.DepartmentName), m.key),
+		Body:     body,
+	}, nil
+}
+
+func (s *service) departmentSheet(ctx context.Context, dept *model.Department, m month) (*departmentSheet, error) {
+	out := &departmentSheet{DepartmentName: dept.DepartmentName}
+
+	if dept.ManagerEmployeeID != nil {
+		mgr, err := s.empRepo.GetByEmployeeIDJoinDept(ctx, *dept.ManagerEmployeeID)
+		switch {
+		case err == nil:
+			out.ManagerName = mgr.Name
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, err
+		}
+	}
+
+	roster, err := s.empRepo.ListByDepartment(ctx, &dept.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range roster {
+		sheet, err := s.employeeSheet(ctx, &roster[i], m)
+		if err != nil {
+			// left between the roster read and the timesheet
+			if errors.Is(err, appErr.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		sheet.DepartmentName = dept.DepartmentName
+		out.Employees = append(out.Employees, sheet)
+	}
+	return out, nil
+}
+
+func (s *service) employeeSheet(ctx context.Context, emp *model.Employee, m month) (employeeSheet, error) {
+	ts, err := s.atdSvc.EmployeeTimesheet(ctx, atdsvc.TimesheetInput{
+		EmployeeID: emp.EmployeeID,
+		Period:     atdsvc.PeriodMonth,
+		Date:       helper.DateKey(m.first),
+		TZ:         m.loc.String(),
+	})
+	if err != nil {
+		return employeeSheet{}, err
+	}
+	return employeeSheet{DepartmentName: emp.Department.DepartmentName, Timesheet: ts}, nil
+}
+
+func resolveMonth(in MonthlyInput) (month, error) {
+	loc := config.AppTimezone()
+	if in.TZ != "" {
+		l, err := time.LoadLocation(in.TZ)
+		if err != nil {
+			return month{}, fmt.Errorf("%w: unknown tz %q", appErr.ErrInvalidInput, in.TZ)
+		}
+		loc = l
+	}
+
+	if in.Month == "" {
+		now := time.Now().In(loc)
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc).AddDate(0, -1, 0)
+		return month{key: first.Format("2006-01"), first: first, loc: loc}, nil
+	}
+
+	first, err := time.ParseInLocation("2006-01", in.Month, loc)
+	if err != nil {
+		return month{}, fmt.Errorf("%w: month must be YYYY-MM", appErr.ErrInvalidInput)
+	}
+	return month{key: in.Month, first: first, loc: loc}, nil
+}
@@ -0,0 +1,358 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/pdf"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+// page layout in points, top-left origin
+const (
+	marginLeft   = 40.0
+	marginRight  = pdf.PageWidth - 40
+	contentWidth = marginRight - marginLeft
+	pageBottom   = 770.0
+)
+
+var (
+	colorLate    = pdf.RGB(200, 45, 45)
+	colorMuted   = pdf.RGB(120, 120, 120)
+	colorWeekend = pdf.RGB(246, 246, 246)
+	colorBar     = pdf.RGB(220, 90, 60)
+)
+
+func renderEmployee(sh employeeSheet, m month) ([]byte, error) {
+	doc := pdf.New(fmt.Sprintf("Attendance %s %s", sh.Timesheet.EmployeeID, m.key))
+	drawEmployeeSheet(doc, sh, m)
+	stampFooters(doc, m)
+	return doc.Bytes()
+}
+
+func renderDepartment(sh *departmentSheet, m month) ([]byte, error) {
+	doc := pdf.New(fmt.Sprintf("Attendance %s %s", sh.DepartmentName, m.key))
+	drawDepartmentRollup(doc, sh, m)
+	for _, e := range sh.Employees {
+		drawEmployeeSheet(doc, e, m)
+	}
+	stampFooters(doc, m)
+	return doc.Bytes()
+}
+
+func drawEmployeeSheet(doc *pdf.Document, sh employeeSheet, m month) {
+	ts := sh.Timesheet
+	p := doc.AddPage()
+
+	drawTitle(p, "Monthly Attendance Report", m)
+	p.Text(marginLeft, 72, 9, false, pdf.Black, fmt.Sprintf("Employee: %s (%s)", ts.EmployeeName, ts.EmployeeID))
+	p.Text(marginLeft, 86, 9, false, pdf.Black, "Department: "+sh.DepartmentName)
+	p.Text(marginLeft, 100, 9, false, pdf.Black, fmt.Sprintf("Period: %s to %s (%s)", ts.From, ts.To, ts.TZUsed))
+
+	// daily rows
+	type col struct {
+		title string
+		x     float64
+		right bool
+	}
+	cols := []col{
+		{"Date", 44, false}, {"Day", 98, false}, {"In", 126, false}, {"Status in", 164, false},
+		{"Out", 226, false}, {"Status out", 264, false}, {"Worked", 372, true}, {"Late", 408, true},
+		{"Overtime", 450, true}, {"Note", 458, false},
+	}
+	y := 116.0
+	p.Rect(marginLeft, y, contentWidth, 14, pdf.LightGray)
+	for _, c := range cols {
+		if c.right {
+			p.TextRight(c.x, y+10, 7.5, true, pdf.Black, c.title)
+		} else {
+			p.Text(c.x, y+10, 7.5, true, pdf.Black, c.title)
+		}
+	}
+	y += 14
+
+	const rowH = 12.5
+	late := make([]float64, len(ts.Days))
+	for i, d := range ts.Days {
+		if d.Weekday == "Saturday" || d.Weekday == "Sunday" {
+			p.Rect(marginLeft, y, contentWidth, rowH, colorWeekend)
+		}
+		base := y + 9
+		inColor := pdf.Black
+		if d.StatusIn == "late" {
+			inColor = colorLate
+		} else if d.StatusIn == "absent" {
+			inColor = colorMuted
+		}
+
+		p.Text(44, base, 7.5, false, pdf.Black, d.DateLocal)
+		p.Text(98, base, 7.5, false, pdf.Black, d.Weekday[:3])
+		p.Text(126, base, 7.5, false, pdf.Black, deref(d.ClockInLocal))
+		p.Text(164, base, 7.5, false, inColor, statusLabel(d.StatusIn))
+		p.Text(226, base, 7.5, false, pdf.Black, deref(d.ClockOutLocal))
+		p.Text(264, base, 7.5, false, pdf.Black, statusLabel(d.StatusOut))
+		p.TextRight(372, base, 7.5, false, pdf.Black, hm(d.WorkedMinutes))
+		p.TextRight(408, base, 7.5, false, inColor, minutes(d.LateMinutes))
+		p.TextRight(450, base, 7.5, false, pdf.Black, hm(d.OvertimeMinutes))
+		p.Text(458, base, 7.5, false, colorMuted, pdf.Fit(dayNote(d), marginRight-458, 7.5, false))
+
+		late[i] = float64(d.LateMinutes)
+		y += rowH
+	}
+	p.Line(marginLeft, y, marginRight, y, 0.5, pdf.Gray)
+
+	// totals
+	t := ts.Totals
+	y += 18
+	p.Text(marginLeft, y, 10, true, pdf.Black, "Totals")
+	drawFigures(p, y+14, [][2]string{
+		{"Present days", strconv.Itoa(t.PresentDays)},
+		{"Late arrivals", strconv.Itoa(t.LateCount)},
+		{"Late minutes", strconv.Itoa(t.LateMinutes)},
+		{"Early leaves", strconv.Itoa(t.EarlyLeaveCount)},
+		{"Absent days", strconv.Itoa(t.AbsentDays)},
+		{"Leave days", strconv.Itoa(t.OnLeaveDays)},
+		{"Holiday work days", strconv.Itoa(t.HolidayWorkDays)},
+		{"Overtime", hmOrZero(t.OvertimeMinutes)},
+		{"Worked", hmOrZero(t.WorkedMinutes)},
+		{"Breaks", hmOrZero(t.BreakMinutes)},
+	})
+
+	// lateness chart
+	y = 632
+	p.Text(marginLeft, y, 10, true, pdf.Black, "Lateness (minutes per day)")
+	drawBars(p, y+8, late, "No late arrivals this month.")
+
+	drawSignatures(p, "Employee", ts.EmployeeName, "Approved by (department manager)", "")
+}
+
+func drawDepartmentRollup(doc *pdf.Document, sh *departmentSheet, m month) {
+	p := doc.AddPage()
+
+	drawTitle(p, "Department Attendance Report", m)
+	manager := sh.ManagerName
+	if manager == "" {
+		manager = "-"
+	}
+	from, to := m.first, m.first.AddDate(0, 1, -1)
+	p.Text(marginLeft, 72, 9, false, pdf.Black, "Department: "+sh.DepartmentName)
+	p.Text(marginLeft, 86, 9, false, pdf.Black, "Manager: "+manager)
+	p.Text(marginLeft, 100, 9, false, pdf.Black, fmt.Sprintf("Period: %s to %s (%s), %d employees",
+		from.Format("2006-01-02"), to.Format("2006-01-02"), m.loc.String(), len(sh.Employees)))
+
+	type col struct {
+		title string
+		x     float64
+		right bool
+	}
+	cols := []col{
+		{"Employee", 44, false}, {"ID", 178, false}, {"Present", 290, true}, {"Late", 322, true},
+		{"Late min", 366, true}, {"Early", 400, true}, {"Absent", 436, true}, {"Leave", 468, true},
+		{"Overtime", 512, true}, {"Worked", 551, true},
+	}
+	header := func(y float64) float64 {
+		p.Rect(marginLeft, y, contentWidth, 14, pdf.LightGray)
+		for _, c := range cols {
+			if c.right {
+				p.TextRight(c.x, y+10, 7.5, true, pdf.Black, c.title)
+			} else {
+				p.Text(c.x, y+10, 7.5, true, pdf.Black, c.title)
+			}
+		}
+		return y + 14
+	}
+	row := func(y float64, bold bool, name, id string, t atdsvc.TimesheetTotals) {
+		base := y + 9
+		p.Text(44, base, 7.5, bold, pdf.Black, pdf.Fit(name, 130, 7.5, bold))
+		p.Text(178, base, 7.5, bold, pdf.Black, pdf.Fit(id, 80, 7.5, bold))
+		lateColor := pdf.Black
+		if t.LateCount > 0 {
+			lateColor = colorLate
+		}
+		p.TextRight(290, base, 7.5, bold, pdf.Black, strconv.Itoa(t.PresentDays))
+		p.TextRight(322, base, 7.5, bold, lateColor, strconv.Itoa(t.LateCount))
+		p.TextRight(366, base, 7.5, bold, lateColor, strconv.Itoa(t.LateMinutes))
+		p.TextRight(400, base, 7.5, bold, pdf.Black, strconv.Itoa(t.EarlyLeaveCount))
+		p.TextRight(436, base, 7.5, bold, pdf.Black, strconv.Itoa(t.AbsentDays))
+		p.TextRight(468, base, 7.5, bold, pdf.Black, strconv.Itoa(t.OnLeaveDays))
+		p.TextRight(512, base, 7.5, bold, pdf.Black, hmOrZero(t.OvertimeMinutes))
+		p.TextRight(551, base, 7.5, bold, pdf.Black, hmOrZero(t.WorkedMinutes))
+	}
+
+	const rowH = 13.0
+	y := header(116)
+
+	var sum atdsvc.TimesheetTotals
+	var lateByDay []float64
+	for _, e := range sh.Employees {
+		if y+rowH > pageBottom {
+			p = doc.AddPage()
+			y = header(50)
+		}
+		t := e.Timesheet.Totals
+		row(y, false, e.Timesheet.EmployeeName, e.Timesheet.EmployeeID, t)
+		y += rowH
+
+		sum.PresentDays += t.PresentDays
+		sum.LateCount += t.LateCount
+		sum.LateMinutes += t.LateMinutes
+		sum.EarlyLeaveCount += t.EarlyLeaveCount
+		sum.AbsentDays += t.AbsentDays
+		sum.OnLeaveDays += t.OnLeaveDays
+		sum.OvertimeMinutes += t.OvertimeMinutes
+		sum.WorkedMinutes += t.WorkedMinutes
+
+		if lateByDay == nil {
+			lateByDay = make([]float64, len(e.Timesheet.Days))
+		}
+		for i, d := range e.Timesheet.Days {
+			if i < len(lateByDay) && d.StatusIn == "late" {
+				lateByDay[i]++
+			}
+		}
+	}
+
+	if y+rowH > pageBottom {
+		p = doc.AddPage()
+		y = header(50)
+	}
+	p.Line(marginLeft, y, marginRight, y, 0.5, pdf.Gray)
+	row(y+2, true, "Department total", "", sum)
+	y += rowH + 2
+
+	// the chart and signatures need the lower part of a page
+	if y > 600 {
+		p = doc.AddPage()
+		y = 50
+	}
+	y += 28
+	p.Text(marginLeft, y, 10, true, pdf.Black, "Late arrivals per day")
+	drawBars(p, y+8, lateByDay, "No late arrivals this month.")
+
+	drawSignatures(p, "Prepared by", "", "Department manager", sh.ManagerName)
+}
+
+func drawTitle(p *pdf.Page, title string, m month) {
+	p.Text(marginLeft, 52, 16, true, pdf.Black, title)
+	p.TextRight(marginRight, 52, 11, true, pdf.Black, m.first.Format("January 2006"))
+	p.Line(marginLeft, 60, marginRight, 60, 0.75, pdf.Black)
+}
+
+// drawFigures lays label/value pairs out four per row.
+func drawFigures(p *pdf.Page, y float64, figures [][2]string) {
+	colW := contentWidth / 4
+	for i, f := range figures {
+		x := marginLeft + float64(i%4)*colW
+		rowY := y + float64(i/4)*26
+		p.Text(x, rowY, 7.5, false, colorMuted, f[0])
+		p.Text(x, rowY+11, 10, true, pdf.Black, f[1])
+	}
+}
+
+// drawBars charts one bar per day below y, scaled to the largest value.
+func drawBars(p *pdf.Page, y float64, values []float64, empty string) {
+	const (
+		axisX  = marginLeft + 24
+		height = 96.0
+	)
+	width := marginRight - axisX
+
+	var peak float64
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	if peak == 0 || len(values) == 0 {
+		p.Text(marginLeft, y+24, 8, false, colorMuted, empty)
+		return
+	}
+
+	bottom := y + height
+	p.Line(axisX, y, axisX, bottom, 0.5, pdf.Gray)
+	p.Line(axisX, bottom, marginRight, bottom, 0.5, pdf.Gray)
+	p.TextRight(axisX-4, y+6, 6.5, false, colorMuted, strconv.FormatFloat(peak, 'f', -1, 64))
+	p.TextRight(axisX-4, bottom, 6.5, false, colorMuted, "0")
+
+	slot := width / float64(len(values))
+	for i, v := range values {
+		x := axisX + float64(i)*slot
+		if v > 0 {
+			h := v / peak * (height - 4)
+			p.Rect(x+slot*0.2, bottom-h, slot*0.6, h, colorBar)
+		}
+		if day := i + 1; day == 1 || day%5 == 0 {
+			label := strconv.Itoa(day)
+			p.Text(x+slot/2-pdf.TextWidth(label, 6.5, false)/2, bottom+9, 6.5, false, colorMuted, label)
+		}
+	}
+}
+
+func drawSignatures(p *pdf.Page, leftLabel, leftName, rightLabel, rightName string) {
+	const y = 790.0
+	half := contentWidth / 2
+	for i, s := range [][2]string{{leftLabel, leftName}, {rightLabel, rightName}} {
+		x := marginLeft + float64(i)*(half+20)
+		p.Line(x, y, x+half-20, y, 0.5, pdf.Black)
+		p.Text(x, y+10, 7.5, false, colorMuted, s[0])
+		if s[1] != "" {
+			p.Text(x, y-4, 8, false, pdf.Black, s[1])
+		}
+	}
+}
+
+func stampFooters(doc *pdf.Document, m month) {
+	pages := doc.Pages()
+	generated := "Generated " + time.Now().In(m.loc).Format("2006-01-02 15:04 MST")
+	for i, p := range pages {
+		p.Text(marginLeft, 826, 6.5, false, colorMuted, generated)
+		p.TextRight(marginRight, 826, 6.5, false, colorMuted, fmt.Sprintf("Page %d of %d", i+1, len(pages)))
+	}
+}
+
+func dayNote(d atdsvc.TimesheetDay) string {
+	var notes []string
+	if d.Holiday != nil {
+		notes = append(notes, *d.Holiday)
+	}
+	if d.LeaveType != nil {
+		notes = append(notes, *d.LeaveType)
+	}
+	if d.Corrected {
+		notes = append(notes, "corrected")
+	}
+	if d.NeedsReview {
+		notes = append(notes, "needs review")
+	}
+	return strings.Join(notes, ", ")
+}
+
+func statusLabel(s string) string {
+	return strings.ReplaceAll(s, "_", " ")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// hm formats minutes as h:mm, blank for zero so empty days stay quiet.
+func hm(min int) string {
+	if min == 0 {
+		return ""
+	}
+	return hmOrZero(min)
+}
+
+func hmOrZero(min int) string {
+	return fmt.Sprintf("%d:%02d", min/60, min%60)
+}
+
+func minutes(min int) string {
+	if min == 0 {
+		return ""
+	}
+	return strconv.Itoa(min)
+}
@@ -0,0 +1,33 @@
+package report
+
+import (
+	"context"
+	"io"
+
+	deptrepo "github.com/itsaFan/fleetify-be/internal/repo/department"
+	emprepo "github.com/itsaFan/fleetify-be/internal/repo/employee"
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+type service struct {
+	atdSvc   atdsvc.Service
+	empRepo  emprepo.Repository
+	deptRepo deptrepo.Repository
+}
+
+// Service renders monthly attendance PDFs from the attendance service's
+// timesheets, so the figures match the history and timesheet endpoints.
+type Service interface {
+	EmployeeMonthly(ctx context.Context, employeeID string, in MonthlyInput) (*Report, error)
+	// DepartmentMonthly is a roll-up page followed by each employee's sheet.
+	DepartmentMonthly(ctx context.Context, departmentName string, in MonthlyInput) (*Report, error)
+	// WriteMonthlyArchive zips the department reports of every department
+	// with employees into w.
+	WriteMonthlyArchive(ctx context.Context, in MonthlyInput, w io.Writer) (*ArchiveOutput, error)
+	// ArchiveFilename validates in and names its archive without reading data.
+	ArchiveFilename(in MonthlyInput) (string, error)
+}
+
+func New(atdSvc atdsvc.Service, empRepo emprepo.Repository, deptRepo deptrepo.Repository) Service {
+	return &service{atdSvc: atdSvc, empRepo: empRepo, deptRepo: deptRepo}
+}
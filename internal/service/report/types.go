@@ -0,0 +1,43 @@
+package report
+
+import (
+	"time"
+
+	atdsvc "github.com/itsaFan/fleetify-be/internal/service/attendance"
+)
+
+type MonthlyInput struct {
+	// "YYYY-MM", defaults to the previous month
+	Month string
+	// defaults to APP_TZ
+	TZ string
+}
+
+type Report struct {
+	Filename string
+	Body     []byte
+}
+
+type ArchiveOutput struct {
+	Month       string `json:"month"`
+	Filename    string `json:"filename"`
+	Departments int    `json:"departments"`
+}
+
+// month is a resolved MonthlyInput
+type month struct {
+	key   string // YYYY-MM
+	first time.Time
+	loc   *time.Location
+}
+
+type employeeSheet struct {
+	DepartmentName string
+	Timesheet      *atdsvc.TimesheetOutput
+}
+
+type departmentSheet struct {
+	DepartmentName string
+	ManagerName    string
+	Employees      []employeeSheet
+}
@@ -0,0 +1,88 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	jobrepo "github.com/itsaFan/fleetify-be/internal/repo/job"
+	notifrepo "github.com/itsaFan/fleetify-be/internal/repo/notification"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+)
+
+// rows per DELETE, keeps each statement's locks short
+const purgeBatch = 1000
+
+type service struct {
+	outboxRepo  outboxrepo.Repository
+	webhookRepo webhookrepo.Repository
+	notifRepo   notifrepo.Repository
+	jobRepo     jobrepo.Repository
+}
+
+type Service interface {
+	// Purge deletes bookkeeping rows older than the given number of days.
+	// Attendance data itself is never touched.
+	Purge(ctx context.Context, in PurgeInput) (*PurgeOutput, error)
+}
+
+func New(
+	outboxRepo outboxrepo.Repository,
+	webhookRepo webhookrepo.Repository,
+	notifRepo notifrepo.Repository,
+	jobRepo jobrepo.Repository,
+) Service {
+	return &service{
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		notifRepo:   notifRepo,
+		jobRepo:     jobRepo,
+	}
+}
+
+type PurgeInput struct {
+	OlderThanDays int
+}
+
+type PurgeOutput struct {
+	Before            time.Time `json:"before"`
+	OutboxEvents      int64     `json:"outbox_events"`
+	WebhookDeliveries int64     `json:"webhook_deliveries"`
+	NotificationLogs  int64     `json:"notification_logs"`
+	JobRuns           int64     `json:"job_runs"`
+}
+
+func (s *service) Purge(ctx context.Context, in PurgeInput) (*PurgeOutput, error) {
+	if in.OlderThanDays < 1 {
+		return nil, fmt.Errorf("%w: older_than_days must be at least 1", appErr.ErrInvalidInput)
+	}
+
+	before := time.Now().UTC().AddDate(0, 0, -in.OlderThanDays)
+	out := &PurgeOutput{Before: before}
+
+	steps := []struct {
+		name  string
+		purge func(ctx context.Context, before time.Time, batch int) (int64, error)
+		count *int64
+	}{
+		{"outbox events", s.outboxRepo.PurgeDispatchedBefore, &out.OutboxEvents},
+		{"webhook deliveries", s.webhookRepo.PurgeDeliveriesBefore, &out.WebhookDeliveries},
+		{"notification logs", s.notifRepo.PurgeLogsBefore, &out.NotificationLogs},
+		{"job runs", s.jobRepo.PurgeRunsBefore, &out.JobRuns},
+	}
+	for _, st := range steps {
+		for {
+			n, err := st.purge(ctx, before, purgeBatch)
+			if err != nil {
+				return out, fmt.Errorf("purge %s: %w", st.name, err)
+			}
+			*st.count += n
+			if n < purgeBatch {
+				break
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	searchrepo "github.com/itsaFan/fleetify-be/internal/repo/search"
+)
+
+// shorter than the ngram token size nothing can match
+const (
+	minQueryLen = 2
+	maxQueryLen = 100
+)
+
+type service struct {
+	repo searchrepo.Repository
+}
+
+type Service interface {
+	Search(ctx context.Context, in SearchInput) ([]Hit, error)
+}
+
+func New(repo searchrepo.Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) Search(ctx context.Context, in SearchInput) ([]Hit, error) {
+	q := strings.Join(strings.Fields(in.Query), " ")
+	if q == "" {
+		return nil, fmt.Errorf("%w: q is required", appErr.ErrRequiredField)
+	}
+	if n := utf8.RuneCountInString(q); n < minQueryLen || n > maxQueryLen {
+		return nil, fmt.Errorf("%w: q must be %d to %d characters", appErr.ErrInvalidRange, minQueryLen, maxQueryLen)
+	}
+
+	var types []string
+	for _, t := range in.Types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch t {
+		case "":
+		case searchrepo.TypeEmployee, searchrepo.TypeDepartment:
+			types = append(types, t)
+		default:
+			return nil, fmt.Errorf("%w: unknown type %q", appErr.ErrInvalidInput, t)
+		}
+	}
+
+	limit := in.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	rows, err := s.repo.Search(ctx, searchrepo.Params{Query: q, Types: types, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Hit, 0, len(rows))
+	for _, r := range rows {
+		h := Hit{Type: r.Type, ID: r.ID, Score: r.Score}
+		switch r.Type {
+		case searchrepo.TypeEmployee:
+			h.EmployeeID = r.Ref
+			h.Name = &r.Title
+			h.DepartmentName = r.Detail
+		case searchrepo.TypeDepartment:
+			h.DepartmentName = &r.Title
+		}
+		out = append(out, h)
+	}
+	return out, nil
+}
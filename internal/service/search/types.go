@@ -0,0 +1,18 @@
+package search
+
+type SearchInput struct {
+	Query string
+	// employee, department; empty searches both
+	Types []string
+	Limit int
+}
+
+// Hit is a ranked match, employee hits carry their department name.
+type Hit struct {
+	Type           string
+	ID             uint64
+	EmployeeID     *string
+	Name           *string
+	DepartmentName *string
+	Score          float64
+}
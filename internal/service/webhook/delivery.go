@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+	"gorm.io/gorm"
+)
+
+func (in *DeliveryListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	in.Status = strings.ToLower(strings.TrimSpace(in.Status))
+	in.EventType = strings.TrimSpace(in.EventType)
+}
+
+func (s *service) ListDeliveries(ctx context.Context, in DeliveryListInput) (*DeliveryListOutput, error) {
+	in.normalize()
+
+	switch in.Status {
+	case "", webhookrepo.DeliveryPending, webhookrepo.DeliverySucceeded, webhookrepo.DeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: status must be pending, succeeded or failed", appErr.ErrInvalidInput)
+	}
+
+	if in.SubscriptionID != "" {
+		if _, err := s.GetSubscription(ctx, in.SubscriptionID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, total, err := s.repo.ListDeliveries(ctx, webhookrepo.DeliveryListParams{
+		SubscriptionID: in.SubscriptionID,
+		Status:         in.Status,
+		EventType:      in.EventType,
+		Limit:          in.Limit,
+		Page:           in.Page,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
+
+func (s *service) GetDelivery(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error) {
+	dId := strings.TrimSpace(deliveryID)
+	if dId == "" {
+		return nil, fmt.Errorf("%w: delivery_id is required", appErr.ErrRequiredField)
+	}
+
+	out, err := s.repo.GetDelivery(ctx, dId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: webhook delivery %q", appErr.ErrNotFound, dId)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *service) Replay(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error) {
+	orig, err := s.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if !orig.Subscription.Active {
+		return nil, fmt.Errorf("%w: webhook subscription %q is inactive", appErr.ErrConflict, orig.SubscriptionID)
+	}
+
+	replayOf := orig.DeliveryID
+	d := model.WebhookDelivery{
+		DeliveryID:     uuid.NewString(),
+		SubscriptionID: orig.SubscriptionID,
+		EventID:        orig.EventID,
+		EventType:      orig.EventType,
+		Payload:        orig.Payload,
+		Status:         webhookrepo.DeliveryPending,
+		NextAttemptAt:  time.Now().UTC(),
+		ReplayOf:       &replayOf,
+	}
+	if err := s.repo.CreateDeliveries(ctx, []model.WebhookDelivery{d}); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDelivery(ctx, d.DeliveryID)
+}
@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+)
+
+const (
+	fanOutBatch   = 100
+	deliveryBatch = 50
+	senders       = 8
+	maxBackoff    = time.Hour
+	// long enough for a full batch to go out before another dispatcher may
+	// pick the same rows up again
+	claimLease = 2 * time.Minute
+
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+func (s *service) Dispatch(ctx context.Context) (*DispatchOutput, error) {
+	out := &DispatchOutput{}
+
+	for {
+		events, queued, err := s.fanOut(ctx)
+		if err != nil {
+			return out, err
+		}
+		out.Events += events
+		out.Queued += queued
+		if events < fanOutBatch {
+			break
+		}
+	}
+
+	due, err := s.repo.ClaimDueDeliveries(ctx, time.Now().UTC(), deliveryBatch, claimLease)
+	if err != nil {
+		return out, err
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, senders)
+	)
+	for i := range due {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *model.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			status := s.deliver(ctx, d)
+			mu.Lock()
+			defer mu.Unlock()
+			switch status {
+			case webhookrepo.DeliverySucceeded:
+				out.Succeeded++
+			case webhookrepo.DeliveryFailed:
+				out.Failed++
+			default:
+				out.Retrying++
+			}
+		}(&due[i])
+	}
+	wg.Wait()
+
+	return out, nil
+}
+
+// fanOut turns one batch of outbox events into deliveries, one per matching
+// active subscription, and marks the events dispatched in the same transaction.
+func (s *service) fanOut(ctx context.Context) (events, queued int, err error) {
+	err = s.repo.WithTx(ctx, func(tx webhookrepo.Repository) error {
+		pending, err := tx.Outbox().ClaimPending(ctx, fanOutBatch)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		subs, err := tx.ListActiveSubscriptions(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		ids := make([]uint64, 0, len(pending))
+		var deliveries []model.WebhookDelivery
+		for _, ev := range pending {
+			ids = append(ids, ev.ID)
+			for _, sub := range subs {
+				if !subscribed(sub.EventTypes, ev.EventType) {
+					continue
+				}
+				deliveries = append(deliveries, model.WebhookDelivery{
+					DeliveryID:     uuid.NewString(),
+					SubscriptionID: sub.SubscriptionID,
+					EventID:        ev.EventID,
+					EventType:      ev.EventType,
+					Payload:        ev.Payload,
+					Status:         webhookrepo.DeliveryPending,
+					NextAttemptAt:  now,
+				})
+			}
+		}
+
+		if err := tx.CreateDeliveries(ctx, deliveries); err != nil {
+			return err
+		}
+		if err := tx.Outbox().MarkDispatched(ctx, ids, now); err != nil {
+			return err
+		}
+
+		events, queued = len(pending), len(deliveries)
+		return nil
+	})
+	return events, queued, err
+}
+
+// deliver makes one attempt and records the outcome. It returns the status
+// the delivery ended up in.
+func (s *service) deliver(ctx context.Context, d *model.WebhookDelivery) string {
+	attempts := d.Attempts + 1
+	now := time.Now().UTC()
+
+	p := webhookrepo.AttemptParams{
+		Attempts:      attempts,
+		NextAttemptAt: now,
+	}
+
+	if !d.Subscription.Active {
+		msg := "subscription is inactive"
+		p.Status = webhookrepo.DeliveryFailed
+		p.Error = &msg
+	} else {
+		code, err := s.send(ctx, d)
+		if code != 0 {
+			p.StatusCode = &code
+		}
+		switch {
+		case err == nil:
+			p.Status = webhookrepo.DeliverySucceeded
+			p.DeliveredAt = &now
+		case attempts >= s.cfg.MaxAttempts:
+			msg := err.Error()
+			p.Status = webhookrepo.DeliveryFailed
+			p.Error = &msg
+		default:
+			msg := err.Error()
+			p.Status = webhookrepo.DeliveryPending
+			p.Error = &msg
+			p.NextAttemptAt = now.Add(backoff(s.cfg.Backoff, attempts))
+		}
+	}
+
+	if err := s.repo.RecordAttempt(ctx, d.DeliveryID, p); err != nil {
+		// the claim lease runs out and the delivery is picked up again
+		return webhookrepo.DeliveryPending
+	}
+	return p.Status
+}
+
+func (s *service) send(ctx context.Context, d *model.WebhookDelivery) (int, error) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Subscription.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "fleetify-webhooks/1")
+	req.Header.Set(EventHeader, d.EventType)
+	req.Header.Set(DeliveryHeader, d.DeliveryID)
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(d.Subscription.Secret, ts, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return resp.StatusCode, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+}
+
+// Sign is the hex HMAC-SHA256 of "<timestamp>.<body>" with the subscription
+// secret. Receivers recompute it and compare against X-Webhook-Signature.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff doubles base per attempt, capped at maxBackoff.
+func backoff(base time.Duration, attempts int) time.Duration {
+	d := min(base, maxBackoff)
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d = min(2*d, maxBackoff)
+	}
+	return d
+}
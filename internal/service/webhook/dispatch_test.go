@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	outboxrepo "github.com/itsaFan/fleetify-be/internal/repo/outbox"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{"json body", "secret", "1700000000", `{"a":1}`, "49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"},
+		{"empty object", "whsec_test", "1758000000", `{}`, "f5ef85644cc7e07013201c128b833d486c2b4a171c32963ba71a650fd7312c8d"},
+		{"all empty", "", "0", "", "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		base     time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{30 * time.Second, 0, 30 * time.Second},
+		{30 * time.Second, 1, 30 * time.Second},
+		{30 * time.Second, 2, time.Minute},
+		{30 * time.Second, 3, 2 * time.Minute},
+		{30 * time.Second, 7, 32 * time.Minute},
+		{30 * time.Second, 8, maxBackoff},
+		{30 * time.Second, 100, maxBackoff},
+		{2 * time.Hour, 1, maxBackoff},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.base, tt.attempts); got != tt.want {
+			t.Errorf("backoff(%v, %d) = %v, want %v", tt.base, tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// fakeRepo serves one batch of outbox events and of due deliveries and
+// records what the dispatcher writes back.
+type fakeRepo struct {
+	webhookrepo.Repository
+
+	events []model.OutboxEvent
+	subs   []model.WebhookSubscription
+	due    []model.WebhookDelivery
+
+	mu         sync.Mutex
+	lease      time.Duration
+	created    []model.WebhookDelivery
+	dispatched []uint64
+	attempts   map[string]webhookrepo.AttemptParams
+}
+
+func (f *fakeRepo) WithTx(ctx context.Context, fn func(txRepo webhookrepo.Repository) error) error {
+	return fn(f)
+}
+
+func (f *fakeRepo) Outbox() outboxrepo.Repository { return fakeOutbox{f: f} }
+
+func (f *fakeRepo) ListActiveSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	return f.subs, nil
+}
+
+func (f *fakeRepo) CreateDeliveries(ctx context.Context, items []model.WebhookDelivery) error {
+	f.created = append(f.created, items...)
+	return nil
+}
+
+func (f *fakeRepo) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	f.lease = lease
+	due := f.due
+	f.due = nil
+	return due, nil
+}
+
+func (f *fakeRepo) RecordAttempt(ctx context.Context, deliveryID string, p webhookrepo.AttemptParams) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.attempts[deliveryID]; ok {
+		panic("delivery " + deliveryID + " attempted twice")
+	}
+	f.attempts[deliveryID] = p
+	return nil
+}
+
+type fakeOutbox struct {
+	outboxrepo.Repository
+	f *fakeRepo
+}
+
+func (o fakeOutbox) ClaimPending(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	events := o.f.events
+	o.f.events = nil
+	return events, nil
+}
+
+func (o fakeOutbox) MarkDispatched(ctx context.Context, ids []uint64, at time.Time) error {
+	o.f.dispatched = append(o.f.dispatched, ids...)
+	return nil
+}
+
+func TestDispatchFansOutEvents(t *testing.T) {
+	repo := &fakeRepo{
+		events: []model.OutboxEvent{
+			{ID: 1, EventID: "ev-1", EventType: "employee.created", Payload: []byte(`{}`)},
+			{ID: 2, EventID: "ev-2", EventType: "attendance.clocked_in", Payload: []byte(`{}`)},
+		},
+		subs: []model.WebhookSubscription{
+			{SubscriptionID: "all", EventTypes: "*", Active: true},
+			{SubscriptionID: "employees", EventTypes: "employee.created,employee.deleted", Active: true},
+		},
+		attempts: map[string]webhookrepo.AttemptParams{},
+	}
+	s := &service{repo: repo, cfg: config.WebhookConfig{MaxAttempts: 3}, client: http.DefaultClient}
+
+	out, err := s.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if out.Events != 2 || out.Queued != 3 {
+		t.Errorf("Dispatch() events, queued = %d, %d, want 2, 3", out.Events, out.Queued)
+	}
+	if len(repo.dispatched) != 2 {
+		t.Errorf("dispatched %v, want both events", repo.dispatched)
+	}
+	for _, d := range repo.created {
+		if d.Status != webhookrepo.DeliveryPending || d.DeliveryID == "" {
+			t.Errorf("created delivery %+v, want a pending one with an id", d)
+		}
+		if d.SubscriptionID == "employees" && d.EventType != "employee.created" {
+			t.Errorf("employees subscription got %s", d.EventType)
+		}
+	}
+}
+
+func TestDispatchDeliversClaimed(t *testing.T) {
+	const secret = "whsec_test"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := "sha256=" + Sign(secret, r.Header.Get(TimestampHeader), body)
+		if r.Header.Get(SignatureHeader) != want {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/down") {
+			http.Error(w, "down", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sub := func(path string, active bool) model.WebhookSubscription {
+		return model.WebhookSubscription{URL: srv.URL + path, Secret: secret, Active: active}
+	}
+	repo := &fakeRepo{
+		due: []model.WebhookDelivery{
+			{DeliveryID: "ok", Payload: []byte(`{"a":1}`), Subscription: sub("/ok", true)},
+			{DeliveryID: "retry", Attempts: 1, Payload: []byte(`{}`), Subscription: sub("/down", true)},
+			{DeliveryID: "exhausted", Attempts: 2, Payload: []byte(`{}`), Subscription: sub("/down", true)},
+			{DeliveryID: "inactive", Payload: []byte(`{}`), Subscription: sub("/ok", false)},
+		},
+		attempts: map[string]webhookrepo.AttemptParams{},
+	}
+	cfg := config.WebhookConfig{Backoff: 30 * time.Second, MaxAttempts: 3}
+	s := &service{repo: repo, cfg: cfg, client: srv.Client()}
+
+	start := time.Now().UTC()
+	out, err := s.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if repo.lease != claimLease {
+		t.Errorf("claimed with lease %v, want %v", repo.lease, claimLease)
+	}
+	if out.Succeeded != 1 || out.Retrying != 1 || out.Failed != 2 {
+		t.Errorf("Dispatch() = %+v, want 1 succeeded, 1 retrying, 2 failed", out)
+	}
+
+	tests := []struct {
+		id       string
+		status   string
+		attempts int
+		code     int
+	}{
+		{"ok", webhookrepo.DeliverySucceeded, 1, http.StatusNoContent},
+		{"retry", webhookrepo.DeliveryPending, 2, http.StatusInternalServerError},
+		{"exhausted", webhookrepo.DeliveryFailed, 3, http.StatusInternalServerError},
+		{"inactive", webhookrepo.DeliveryFailed, 1, 0},
+	}
+	for _, tt := range tests {
+		p, ok := repo.attempts[tt.id]
+		if !ok {
+			t.Errorf("%s: no attempt recorded", tt.id)
+			continue
+		}
+		if p.Status != tt.status || p.Attempts != tt.attempts {
+			t.Errorf("%s: status, attempts = %s, %d, want %s, %d", tt.id, p.Status, p.Attempts, tt.status, tt.attempts)
+		}
+		code := 0
+		if p.StatusCode != nil {
+			code = *p.StatusCode
+		}
+		if code != tt.code {
+			t.Errorf("%s: status code = %d, want %d", tt.id, code, tt.code)
+		}
+	}
+
+	retry := repo.attempts["retry"]
+	if wait := retry.NextAttemptAt.Sub(start); wait < time.Minute || wait > time.Minute+5*time.Second {
+		t.Errorf("retry: next attempt in %v, want the second backoff step of 1m", wait)
+	}
+	if ok := repo.attempts["ok"]; ok.DeliveredAt == nil || ok.Error != nil {
+		t.Errorf("ok: delivered_at = %v, error = %v", ok.DeliveredAt, ok.Error)
+	}
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/itsaFan/fleetify-be/internal/config"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+)
+
+type service struct {
+	repo   webhookrepo.Repository
+	cfg    config.WebhookConfig
+	client *http.Client
+}
+
+type Service interface {
+	CreateSubscription(ctx context.Context, in CreateInput) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, in ListInput) (*ListOutput, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*model.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscriptionID string, in UpdateInput) (*model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	ListDeliveries(ctx context.Context, in DeliveryListInput) (*DeliveryListOutput, error)
+	GetDelivery(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error)
+	// Replay queues a fresh delivery of the same payload, attempts start over
+	Replay(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error)
+
+	// Dispatch fans pending outbox events out to subscriptions, then sends
+	// whatever deliveries are due. The dispatcher job calls it on a ticker.
+	Dispatch(ctx context.Context) (*DispatchOutput, error)
+}
+
+func New(repo webhookrepo.Repository, cfg config.WebhookConfig) Service {
+	return &service{
+		repo:   repo,
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/itsaFan/fleetify-be/internal/appErr"
+	"github.com/itsaFan/fleetify-be/internal/event"
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+	webhookrepo "github.com/itsaFan/fleetify-be/internal/repo/webhook"
+	"gorm.io/gorm"
+)
+
+const minSecretLen = 16
+
+func (s *service) CreateSubscription(ctx context.Context, in CreateInput) (*model.WebhookSubscription, error) {
+	u, err := validateURL(in.URL)
+	if err != nil {
+		return nil, err
+	}
+	types, err := joinEventTypes(in.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := strings.TrimSpace(in.Secret)
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minSecretLen {
+		return nil, fmt.Errorf("%w: secret must be at least %d characters", appErr.ErrInvalidInput, minSecretLen)
+	}
+
+	active := true
+	if in.Active != nil {
+		active = *in.Active
+	}
+
+	sub := &model.WebhookSubscription{
+		SubscriptionID: uuid.NewString(),
+		URL:            u,
+		Secret:         secret,
+		EventTypes:     types,
+		Description:    trimPtr(in.Description),
+		Active:         active,
+	}
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (in *ListInput) normalize() {
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 10
+	}
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+}
+
+func (s *service) ListSubscriptions(ctx context.Context, in ListInput) (*ListOutput, error) {
+	in.normalize()
+
+	items, total, err := s.repo.ListSubscriptions(ctx, webhookrepo.ListParams{
+		Active: in.Active,
+		Limit:  in.Limit,
+		Page:   in.Page,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOutput{
+		Data:       items,
+		Pagination: helper.BuildPagination(total, in.Page, in.Limit),
+	}, nil
+}
+
+func (s *service) GetSubscription(ctx context.Context, subscriptionID string) (*model.WebhookSubscription, error) {
+	subId := strings.TrimSpace(subscriptionID)
+	if subId == "" {
+		return nil, fmt.Errorf("%w: subscription_id is required", appErr.ErrRequiredField)
+	}
+
+	out, err := s.repo.GetSubscription(ctx, subId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: webhook subscription %q", appErr.ErrNotFound, subId)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *service) UpdateSubscription(ctx context.Context, subscriptionID string, in UpdateInput) (*model.WebhookSubscription, error) {
+	cur, err := s.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var p webhookrepo.UpdateParams
+	if in.URL != nil {
+		u, err := validateURL(*in.URL)
+		if err != nil {
+			return nil, err
+		}
+		p.URL = &u
+	}
+	if in.Secret != nil {
+		secret := strings.TrimSpace(*in.Secret)
+		if len(secret) < minSecretLen {
+			return nil, fmt.Errorf("%w: secret must be at least %d characters", appErr.ErrInvalidInput, minSecretLen)
+		}
+		p.Secret = &secret
+	}
+	if in.EventTypes != nil {
+		types, err := joinEventTypes(*in.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		p.EventTypes = &types
+	}
+	if in.Description != nil {
+		p.Description = trimPtr(in.Description)
+	}
+	p.Active = in.Active
+
+	if err := s.repo.UpdateSubscription(ctx, cur.SubscriptionID, p); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// nothing changed, the row is still there
+			return cur, nil
+		}
+		return nil, err
+	}
+	return s.repo.GetSubscription(ctx, cur.SubscriptionID)
+}
+
+func (s *service) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	subId := strings.TrimSpace(subscriptionID)
+	if subId == "" {
+		return fmt.Errorf("%w: subscription_id is required", appErr.ErrRequiredField)
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, subId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: webhook subscription %q", appErr.ErrNotFound, subId)
+		}
+		return err
+	}
+	return nil
+}
+
+func validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("%w: url is required", appErr.ErrRequiredField)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: url must be an absolute http(s) URL", appErr.ErrInvalidInput)
+	}
+	return u.String(), nil
+}
+
+// joinEventTypes validates and stores event types as a comma list.
+func joinEventTypes(types []string) (string, error) {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(types))
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		if t != "*" && !event.IsKnownType(t) {
+			return "", fmt.Errorf("%w: unknown event type %q", appErr.ErrInvalidInput, t)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	if len(out) == 0 {
+		return "", fmt.Errorf("%w: event_types is required", appErr.ErrRequiredField)
+	}
+	if seen["*"] {
+		return "*", nil
+	}
+	return strings.Join(out, ","), nil
+}
+
+// subscribed reports whether a stored event type list covers t.
+func subscribed(eventTypes, t string) bool {
+	for _, e := range strings.Split(eventTypes, ",") {
+		e = strings.TrimSpace(e)
+		if e == "*" || e == t {
+			return true
+		}
+	}
+	return false
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*s)
+	return &t
+}
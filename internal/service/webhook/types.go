@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"github.com/itsaFan/fleetify-be/internal/helper"
+	"github.com/itsaFan/fleetify-be/internal/model"
+)
+
+type CreateInput struct {
+	URL string
+	// empty = generated, returned once on create
+	Secret string
+	// event names or "*" for all
+	EventTypes  []string
+	Description *string
+	// nil = true
+	Active *bool
+}
+
+type UpdateInput struct {
+	URL         *string
+	Secret      *string
+	EventTypes  *[]string
+	Description *string
+	Active      *bool
+}
+
+type ListInput struct {
+	Active *bool
+	Limit  int
+	Page   int
+}
+
+type ListOutput struct {
+	Data       []model.WebhookSubscription `json:"data"`
+	Pagination helper.Pagination           `json:"pagination"`
+}
+
+type DeliveryListInput struct {
+	SubscriptionID string
+	Status         string
+	EventType      string
+	Limit          int
+	Page           int
+}
+
+type DeliveryListOutput struct {
+	Data       []model.WebhookDelivery `json:"data"`
+	Pagination helper.Pagination       `json:"pagination"`
+}
+
+type DispatchOutput struct {
+	// outbox events fanned out this pass
+	Events int `json:"events"`
+	// deliveries queued from those events
+	Queued    int `json:"queued"`
+	Succeeded int `json:"succeeded"`
+	Retrying  int `json:"retrying"`
+	Failed    int `json:"failed"`
+}